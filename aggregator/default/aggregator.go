@@ -4,17 +4,41 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
+	"vislab/libs/check"
+	"vislab/libs/ptr"
+	codeTypes "vislab/sources/code/types"
 	gitlabTypes "vislab/sources/gitlab/types"
+	kubernetesTypes "vislab/sources/kubernetes/types"
 	migrationTypes "vislab/sources/migrations/types"
+	ownersTypes "vislab/sources/owners/types"
+	pipelineTypes "vislab/sources/pipeline/types"
 	yamlTypes "vislab/sources/yaml/types"
 	"vislab/types"
 )
 
 type Aggregator struct {
-	data *types.All
+	data    *types.All
+	fileURL func(projectURL, ref, filePath string) string
 }
 
-func New() (*Aggregator, error) {
+type AggregatorOption func(*Aggregator) error
+
+// WithFileURL sets how links to repository files are built, they point to
+// gitlab by default.
+func WithFileURL(fileURL func(projectURL, ref, filePath string) string) AggregatorOption {
+	return func(a *Aggregator) error {
+		if fileURL == nil {
+			return fmt.Errorf("file url builder not specified")
+		}
+
+		a.fileURL = fileURL
+		return nil
+	}
+}
+
+func New(options ...AggregatorOption) (*Aggregator, error) {
 	myAggr := &Aggregator{
 		data: &types.All{
 			Service:       &types.Service{},
@@ -24,6 +48,13 @@ func New() (*Aggregator, error) {
 			RabbitMQs:     []*types.RabbitMQ{},
 			OtherServices: []*types.Service{},
 		},
+		fileURL: gitlabFileURL,
+	}
+
+	for _, option := range options {
+		if err := option(myAggr); err != nil {
+			return nil, err
+		}
 	}
 
 	return myAggr, nil
@@ -47,6 +78,22 @@ func (a *Aggregator) Set(ctx context.Context, data any) error {
 		if err := a.setMigration(ctx, d); err != nil {
 			return err
 		}
+	case *codeTypes.All:
+		if err := a.setCode(ctx, d); err != nil {
+			return err
+		}
+	case *kubernetesTypes.All:
+		if err := a.setKubernetes(ctx, d); err != nil {
+			return err
+		}
+	case *pipelineTypes.All:
+		if err := a.setPipeline(ctx, d); err != nil {
+			return err
+		}
+	case *ownersTypes.All:
+		if err := a.setOwners(ctx, d); err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("unknown data type: %T", data)
 	}
@@ -59,7 +106,13 @@ func (a *Aggregator) setGitlab(ctx context.Context, data *gitlabTypes.All) error
 	a.data.Service.Group = data.Project.Group.Name
 	a.data.Service.LatestTag = data.LatestTag.Name
 	a.data.Service.FullName = data.Project.PathWithGroup
-	// a.data.Service.Language = data.Project.Language
+	a.data.Service.Languages = data.Languages
+	if data.Language != "" {
+		a.data.Service.Language = ptr.Ptr(data.Language)
+	}
+	if data.Status != "" {
+		a.data.Service.Status = ptr.Ptr(data.Status)
+	}
 	a.data.Service.Link = data.Project.WebURL
 	a.data.Service.MainBranch = data.Project.DefaultBranch
 
@@ -106,55 +159,464 @@ func (a *Aggregator) setYaml(ctx context.Context, data *yamlTypes.All) error {
 }
 
 func (a *Aggregator) setMigration(ctx context.Context, data *migrationTypes.All) error {
+	migrationPostgres, migrationDatabase, err := a.migrationDatabase()
+	if err != nil {
+		return err
+	}
+
+	tables := map[string]*types.PostgresqlTable{}
+
+Tables:
+	for _, table := range data.Tables {
+		newTable := &types.PostgresqlTable{
+			Name:        &table.Name,
+			Type:        &table.Type,
+			Migrated:    ptr.Ptr(true),
+			Columns:     migrationColumns(table.Columns),
+			ForeignKeys: migrationForeignKeys(table.ForeignKeys),
+		}
+
+		if table.Owner != "" {
+			newTable.Owner = &table.Owner
+		}
+
+		if table.Type == "partition" { // TODO: make optional
+			continue Tables
+		}
+
+		tables[table.Key()] = newTable
+
+		scheme := migrationScheme(migrationDatabase, table.Schema)
+		scheme.Tables = append(scheme.Tables, newTable)
+	}
+
+	for _, index := range data.Indexes {
+		table, ok := tables[migrationTypes.TableKey(index.Schema, index.Table)]
+		if !ok {
+			slog.Warn("index table not found", "index", index.Name, "table", index.Table)
+			continue
+		}
+
+		table.Indexes = append(table.Indexes, &types.PostgresqlIndex{
+			Name:    &index.Name,
+			Columns: index.Columns,
+			Unique:  &index.Unique,
+		})
+	}
+
+	for _, trigger := range data.Triggers {
+		table, ok := tables[migrationTypes.TableKey(trigger.Schema, trigger.Table)]
+		if !ok {
+			slog.Warn("trigger table not found", "trigger", trigger.Name, "table", trigger.Table)
+			continue
+		}
+
+		table.Triggers = append(table.Triggers, &types.PostgresqlTrigger{
+			Name:     &trigger.Name,
+			Function: &trigger.Func,
+		})
+	}
+
+	for _, funcs := range data.Funcs {
+		for _, function := range funcs {
+			scheme := migrationScheme(migrationDatabase, function.Schema)
+			scheme.Functions = append(scheme.Functions, &types.PostgresqlFunction{
+				Name: &function.Name,
+				Args: function.Args,
+			})
+		}
+	}
+
+	for _, view := range data.Views {
+		scheme := migrationScheme(migrationDatabase, view.Schema)
+		scheme.Views = append(scheme.Views, &types.PostgresqlView{
+			Name:         &view.Name,
+			Materialized: &view.Materialized,
+			Reads:        migrationRelations(view.Tables, data),
+		})
+	}
+
+	for _, typ := range data.Types {
+		scheme := migrationScheme(migrationDatabase, typ.Schema)
+		scheme.Enums = append(scheme.Enums, &types.PostgresqlEnum{
+			Name:   &typ.Name,
+			Values: typ.Values,
+		})
+	}
+
+	for _, sequence := range data.Sequences {
+		scheme := migrationScheme(migrationDatabase, sequence.Schema)
+		scheme.Sequences = append(scheme.Sequences, &types.PostgresqlSequence{
+			Name: &sequence.Name,
+		})
+	}
+
+	for _, extension := range data.Extensions {
+		migrationDatabase.Extensions = append(migrationDatabase.Extensions, &types.PostgresqlExtension{
+			Name:   &extension.Name,
+			Scheme: &extension.Schema,
+		})
+	}
+
+	for _, role := range data.Roles {
+		if slices.ContainsFunc(migrationPostgres.Roles, func(r *types.PostgresqlRole) bool {
+			return check.ComparePointers(r.Name, &role.Name)
+		}) {
+			continue
+		}
+
+		migrationPostgres.Roles = append(migrationPostgres.Roles, &types.PostgresqlRole{
+			Name:     &role.Name,
+			Login:    &role.Login,
+			MemberOf: role.MemberOf,
+		})
+	}
+
+	for _, grant := range data.Grants {
+		for _, table := range migrationGrantTables(grant, data, tables) {
+			table.Grants = append(table.Grants, &types.PostgresqlGrant{
+				Role:       &grant.Role,
+				Privileges: grant.Privileges,
+			})
+		}
+	}
+
+	return nil
+}
+
+func (a *Aggregator) setCode(ctx context.Context, data *codeTypes.All) error {
+	_, migrationDatabase, err := a.migrationDatabase()
+	if err != nil {
+		return err
+	}
+
+	for _, table := range data.Tables {
+		migrationDatabase.Usages = append(migrationDatabase.Usages, &types.ServiceToPqTableConn{
+			From: a.data.Service,
+			To: &types.PostgresqlTable{
+				Name: &table.Name,
+			},
+			Scheme: &table.Schema,
+			Read:   &table.Read,
+			Write:  &table.Write,
+		})
+	}
+
+	return nil
+}
+
+func (a *Aggregator) setKubernetes(ctx context.Context, data *kubernetesTypes.All) error {
+	for _, deployment := range data.Deployments {
+		a.data.Deployments = append(a.data.Deployments, &types.Deployment{
+			Name:      &deployment.Name,
+			Link:      a.fileLink(deployment.Path),
+			Namespace: &deployment.Namespace,
+			Replicas:  deployment.Replicas,
+			Images:    deployment.Images,
+			Ports:     deployment.Ports,
+		})
+	}
+
+	for _, cronJob := range data.CronJobs {
+		a.data.CronJobs = append(a.data.CronJobs, &types.CronJob{
+			Name:      &cronJob.Name,
+			Link:      a.fileLink(cronJob.Path),
+			Namespace: &cronJob.Namespace,
+			Schedule:  &cronJob.Schedule,
+			Images:    cronJob.Images,
+		})
+	}
+
+	for _, job := range data.Jobs {
+		a.data.KubernetesJobs = append(a.data.KubernetesJobs, &types.KubernetesJob{
+			Name:      &job.Name,
+			Link:      a.fileLink(job.Path),
+			Namespace: &job.Namespace,
+			Images:    job.Images,
+		})
+	}
+
+	for _, service := range data.Services {
+		a.data.KubernetesServices = append(a.data.KubernetesServices, &types.KubernetesService{
+			Name:      &service.Name,
+			Link:      a.fileLink(service.Path),
+			Namespace: &service.Namespace,
+			Type:      &service.Type,
+			Ports:     service.Ports,
+		})
+	}
+
+	for _, ingress := range data.Ingresses {
+		a.data.Ingresses = append(a.data.Ingresses, &types.Ingress{
+			Name:      &ingress.Name,
+			Link:      a.fileLink(ingress.Path),
+			Namespace: &ingress.Namespace,
+			Hosts:     ingress.Hosts,
+			Backends:  ingress.Backends,
+		})
+	}
+
+	return nil
+}
+
+func (a *Aggregator) setPipeline(ctx context.Context, data *pipelineTypes.All) error {
+	pipeline := &types.Pipeline{
+		Name:   ptr.Ptr(data.Path),
+		Link:   a.fileLink(data.Path),
+		Stages: data.Stages,
+	}
+
+	for _, template := range data.Templates {
+		pipeline.Templates = append(pipeline.Templates, &types.PipelineTemplate{
+			Name: ptr.Ptr(template.Name),
+			Link: a.templateLink(template),
+		})
+	}
+
+	for _, job := range data.Jobs {
+		pipelineJob := &types.PipelineJob{
+			Name:           ptr.Ptr(job.Name),
+			Link:           a.fileLink(job.Path),
+			Stage:          ptr.Ptr(job.Stage),
+			Deploys:        ptr.Ptr(job.Deploys),
+			Migrates:       ptr.Ptr(job.Migrates),
+			PublishesImage: ptr.Ptr(job.PublishesImage),
+		}
+
+		if job.Image != "" {
+			pipelineJob.Image = ptr.Ptr(job.Image)
+		}
+		if job.Environment != "" {
+			pipelineJob.Environment = ptr.Ptr(job.Environment)
+		}
+		if job.Template != "" {
+			pipelineJob.Template = ptr.Ptr(job.Template)
+		}
+
+		pipeline.Jobs = append(pipeline.Jobs, pipelineJob)
+	}
+
+	a.data.Pipeline = pipeline
+
+	return nil
+}
+
+func (a *Aggregator) setOwners(ctx context.Context, data *ownersTypes.All) error {
+	for _, team := range data.Teams {
+		name := team.Name
+		if name == "" && a.data.Service.Group != nil {
+			name = *a.data.Service.Group
+		}
+		if name == "" {
+			slog.Warn("skipping owners without team", "service", a.data.Service.Name)
+			continue
+		}
+
+		aggrTeam := &types.Team{
+			Name: ptr.Ptr(name),
+		}
+
+		for _, dev := range team.Devs {
+			aggrDev := &types.Dev{}
+
+			if dev.Name != "" {
+				aggrDev.Name = ptr.Ptr(dev.Name)
+			}
+			if dev.Username != "" {
+				aggrDev.Username = ptr.Ptr(dev.Username)
+				aggrDev.Link = a.userLink(dev.Username)
+				if aggrDev.Name == nil {
+					aggrDev.Name = ptr.Ptr(dev.Username)
+				}
+			}
+			if dev.Email != "" {
+				aggrDev.Email = ptr.Ptr(dev.Email)
+				if aggrDev.Name == nil {
+					aggrDev.Name = ptr.Ptr(dev.Email)
+				}
+			}
+			if dev.Role != "" {
+				aggrDev.Role = ptr.Ptr(dev.Role)
+			}
+
+			aggrTeam.Devs = append(aggrTeam.Devs, aggrDev)
+		}
+
+		a.data.Teams = append(a.data.Teams, aggrTeam)
+	}
+
+	return nil
+}
+
+func (a *Aggregator) userLink(username string) *string {
+	baseURL := a.baseURL()
+	if baseURL == "" {
+		return nil
+	}
+
+	return ptr.Ptr(baseURL + "/" + username)
+}
+
+// baseURL is the gitlab instance address taken from the service link.
+func (a *Aggregator) baseURL() string {
+	if a.data.Service.Link == nil || a.data.Service.FullName == nil {
+		return ""
+	}
+
+	baseURL, ok := strings.CutSuffix(*a.data.Service.Link, "/"+*a.data.Service.FullName)
+	if !ok {
+		return ""
+	}
+
+	return baseURL
+}
+
+// templateLink points to a CI template of another project on the same
+// gitlab instance as the service.
+func (a *Aggregator) templateLink(template *pipelineTypes.Template) *string {
+	baseURL := a.baseURL()
+	if template.Project == "" || baseURL == "" {
+		return nil
+	}
+
+	ref := template.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	return ptr.Ptr(a.fileURL(baseURL+"/"+template.Project, ref, template.File))
+}
+
+// fileLink points to the file in the service repository, the gitlab step sets
+// the project link and ref beforehand.
+func (a *Aggregator) fileLink(filePath string) *string {
+	if a.data.Service.Link == nil || a.data.Service.LatestTag == nil || filePath == "" {
+		return nil
+	}
+
+	return ptr.Ptr(a.fileURL(*a.data.Service.Link, *a.data.Service.LatestTag, filePath))
+}
+
+func gitlabFileURL(projectURL, ref, filePath string) string {
+	return fmt.Sprintf("%s/-/blob/%s/%s", projectURL, ref, filePath)
+}
+
+// migrationDatabase returns the database marked for migrations, the first
+// database of the first postgres otherwise.
+func (a *Aggregator) migrationDatabase() (*types.Postgresql, *types.PostgresqlDB, error) {
 	if len(a.data.Postgresqls) == 0 {
-		return fmt.Errorf("no postgresqls for migrations found")
+		return nil, nil, fmt.Errorf("no postgresqls for migrations found")
 	}
 	migrationPostgres := a.data.Postgresqls[0]
 
 	if len(migrationPostgres.Databases) == 0 {
-		return fmt.Errorf("no databases for migrations found")
+		return nil, nil, fmt.Errorf("no databases for migrations found")
 	}
 	migrationDatabase := migrationPostgres.Databases[0]
 
-Postgreses:
 	for _, postgres := range a.data.Postgresqls {
 		for _, database := range postgres.Databases {
-			if database.ForMigrations == nil {
-				continue
-			}
-			if *database.ForMigrations {
-				migrationDatabase = database
-				break Postgreses
+			if database.ForMigrations != nil && *database.ForMigrations {
+				return postgres, database, nil
 			}
 		}
 	}
 
-Tables:
-	for _, table := range data.Tables {
-		newTable := &types.PostgresqlTable{
-			Name: &table.Name,
-			// Owner: &table.Owner,
-			Type: &table.Type,
-		}
+	return migrationPostgres, migrationDatabase, nil
+}
 
-		if table.Type == "partition" { // TODO: make optional
-			continue Tables
+func migrationScheme(database *types.PostgresqlDB, name string) *types.PostgresqlScheme {
+	if name == "" {
+		name = "public"
+	}
+
+	for _, scheme := range database.Schemes {
+		if *scheme.Name == name {
+			return scheme
 		}
+	}
 
-		for _, scheme := range migrationDatabase.Schemes {
-			if *scheme.Name == table.Schema {
-				scheme.Tables = append(scheme.Tables, newTable)
-				continue Tables
-			}
+	slog.Warn("schema not found", "schema", name)
+	scheme := &types.PostgresqlScheme{
+		Name: &name,
+	}
+	database.Schemes = append(database.Schemes, scheme)
+
+	return scheme
+}
+
+func migrationColumns(columns []*migrationTypes.Column) []*types.PostgresqlColumn {
+	newColumns := make([]*types.PostgresqlColumn, 0, len(columns))
+
+	for _, column := range columns {
+		newColumns = append(newColumns, &types.PostgresqlColumn{
+			Name:        &column.Name,
+			Type:        &column.Type,
+			Constraints: column.Constraints,
+		})
+	}
+
+	return newColumns
+}
+
+func migrationForeignKeys(fks []*migrationTypes.ForeignKey) []*types.PostgresqlForeignKey {
+	newFks := make([]*types.PostgresqlForeignKey, 0, len(fks))
+
+	for _, fk := range fks {
+		newFks = append(newFks, &types.PostgresqlForeignKey{
+			Name:       &fk.Name,
+			Columns:    fk.Columns,
+			RefScheme:  &fk.RefSchema,
+			RefTable:   &fk.RefTable,
+			RefColumns: fk.RefColumns,
+		})
+	}
+
+	return newFks
+}
+
+func migrationRelations(names []string, data *migrationTypes.All) []*types.PostgresqlRelation {
+	relations := make([]*types.PostgresqlRelation, 0, len(names))
+
+	for _, name := range names {
+		schema := "public"
+
+		if table, ok := migrationTypes.FindTable(data.Tables, name); ok {
+			schema, name = table.Schema, table.Name
+		} else if view, ok := data.Views[name]; ok {
+			schema = view.Schema
+		} else if relSchema, relName, ok := strings.Cut(name, "."); ok {
+			schema, name = relSchema, relName
 		}
-		slog.Warn("table schema not found", "table", table.Name, "schema", table.Schema)
-		migrationDatabase.Schemes = append(migrationDatabase.Schemes, &types.PostgresqlScheme{
-			Name: &table.Schema,
-			Tables: []*types.PostgresqlTable{
-				newTable,
-			},
+
+		relations = append(relations, &types.PostgresqlRelation{
+			Scheme: ptr.Ptr(schema),
+			Name:   ptr.Ptr(name),
 		})
 	}
 
-	return nil
+	return relations
+}
+
+// migrationGrantTables returns the tables a grant applies to, schema wide
+// grants apply to every table of the schema.
+func migrationGrantTables(grant *migrationTypes.Grant, data *migrationTypes.All, tables map[string]*types.PostgresqlTable) []*types.PostgresqlTable {
+	if grant.Table != "" {
+		table, ok := tables[migrationTypes.TableKey(grant.Schema, grant.Table)]
+		if !ok {
+			slog.Warn("grant table not found", "role", grant.Role, "table", grant.Table)
+			return nil
+		}
+
+		return []*types.PostgresqlTable{table}
+	}
+
+	schemeTables := []*types.PostgresqlTable{}
+	for key, table := range tables {
+		if data.Tables[key].Schema == grant.Schema {
+			schemeTables = append(schemeTables, table)
+		}
+	}
+
+	return schemeTables
 }
@@ -10,8 +10,17 @@ func setKafka(ctx context.Context, in []*yamlTypes.Kafka, out *types.All) error
 	for _, kafka := range in {
 		newKafka := &types.Kafka{
 			Name: kafka.Name,
-			Host: kafka.Host,
-			Port: kafka.Port,
+		}
+
+		for _, broker := range kafka.Brokers {
+			if broker.Host == nil {
+				continue
+			}
+
+			newKafka.Brokers = append(newKafka.Brokers, &types.KafkaBroker{
+				Host: broker.Host,
+				Port: broker.Port,
+			})
 		}
 
 		for _, queue := range kafka.Queues {
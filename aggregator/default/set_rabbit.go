@@ -16,10 +16,10 @@ func setRabbit(ctx context.Context, in []*yamlTypes.RabbitMQ, out *types.All) er
 
 		for _, queue := range rabbitMQ.Queues {
 			newQueue := &types.RabbitQueue{
-				Name: queue.Name,
-				// QueueType: queue.QueueType,
-				// Topic:     queue.Topic,
-				// TypeName:  queue.TypeName,
+				Name:       queue.Name,
+				QueueType:  queue.QueueType,
+				Exchange:   queue.Exchange,
+				RoutingKey: queue.RoutingKey,
 			}
 
 			newRabbitMQ.Queues = append(newRabbitMQ.Queues, newQueue)
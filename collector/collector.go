@@ -2,6 +2,7 @@ package collector
 
 import (
 	"context"
+	migrationTypes "vislab/sources/migrations/types"
 )
 
 type CollectorOption func(Collector) error
@@ -9,4 +10,6 @@ type CollectorOption func(Collector) error
 type Collector interface {
 	Collect(ctx context.Context) error
 	Update(ctx context.Context, options ...CollectorOption) error
+	Report() *Report
+	SchemaDiff(ctx context.Context, origin, projectPath, fromRef, toRef string) (*migrationTypes.SchemaDiff, error)
 }
@@ -2,16 +2,22 @@ package gitlabcollector
 
 import (
 	"context"
-	"encoding/base64"
 	"fmt"
 	"log/slog"
 	defaultaggregator "vislab/aggregator/default"
 	"vislab/collector"
 	gtlabjobsteps "vislab/collector/gitlab/steps"
 	"vislab/config"
+	"vislab/libs/ptr"
+	"vislab/resolver"
+	"vislab/sources/code"
 	"vislab/sources/gitlab"
 	"vislab/sources/gitlab/types"
+	"vislab/sources/kubernetes"
 	"vislab/sources/migrations"
+	migrationTypes "vislab/sources/migrations/types"
+	"vislab/sources/owners"
+	"vislab/sources/pipeline"
 	"vislab/sources/yaml"
 	yamlTypes "vislab/sources/yaml/types"
 	"vislab/storage"
@@ -19,30 +25,36 @@ import (
 )
 
 type Collector struct {
-	gitlabGroups   []string
+	endpoints []*endpoint
+
+	releaseOrigin  string
 	releaseProject string
 	releaseFile    string
 	releaseTag     string
 
 	steps []gtlabjobsteps.Step
 
+	report               *collector.Report
+	migrationStep        *gtlabjobsteps.MigrationStep
+	migrationDiagnostics *migrations.Diagnostics
+	unknownTables        []string
+
 	releaseYamlSource *yaml.Source
-	gitlabClient      *gitlab.Client
+	serviceResolver   *resolver.ServiceResolver
+	hostRegistry      *resolver.HostRegistry
+	requestStats      gitlab.Stats
 	storage           storage.Storage
 }
 
-func New(gitlabClient *gitlab.Client, storage storage.Storage, options ...collector.CollectorOption) (*Collector, error) {
-	if gitlabClient == nil {
-		return nil, fmt.Errorf("no gitlab client specified")
-	}
+func New(storage storage.Storage, options ...collector.CollectorOption) (*Collector, error) {
 	if storage == nil {
 		return nil, fmt.Errorf("no storage specified")
 	}
 
 	gitlabCollector := &Collector{
-		gitlabClient: gitlabClient,
-		storage:      storage,
-		steps:        []gtlabjobsteps.Step{},
+		endpoints: []*endpoint{},
+		storage:   storage,
+		steps:     []gtlabjobsteps.Step{},
 	}
 
 	for _, option := range options {
@@ -51,20 +63,62 @@ func New(gitlabClient *gitlab.Client, storage storage.Storage, options ...collec
 		}
 	}
 
+	if len(gitlabCollector.endpoints) == 0 {
+		return nil, fmt.Errorf("no scm endpoint specified")
+	}
+
 	if len(gitlabCollector.steps) == 0 {
 		return nil, fmt.Errorf("no source specified")
 	}
 
+	if gitlabCollector.serviceResolver == nil {
+		serviceResolver, err := resolver.NewServiceResolver(&config.ServiceResolverConfig{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create service resolver: %w", err)
+		}
+
+		gitlabCollector.serviceResolver = serviceResolver
+	}
+
 	return gitlabCollector, nil
 }
 
 func (c *Collector) Collect(ctx context.Context) error {
+	var err error
+
+	c.report = collector.NewReport()
+	c.requestStats = c.stats()
+	for _, e := range c.endpoints {
+		e.scm.ResetRefs()
+	}
+	c.takeDiagnostics()
+	defer c.logReport()
+
 	switch {
 	case c.releaseProject != "" && c.releaseFile != "":
-		return c.collectFromReleaseFile(ctx)
+		err = c.collectFromReleaseFile(ctx)
 	default:
-		return c.collectAll(ctx)
+		err = c.collectAll(ctx)
 	}
+	if err != nil {
+		return err
+	}
+
+	if err := storefuncs.LinkServices(ctx, c.storage); err != nil {
+		return fmt.Errorf("failed to link services: %w", err)
+	}
+
+	shared, err := storefuncs.DetectSharing(ctx, c.storage)
+	if err != nil {
+		return fmt.Errorf("failed to detect shared resources: %w", err)
+	}
+	slog.Info("shared postgres resources detected", "count", len(shared))
+
+	return nil
+}
+
+func (c *Collector) Report() *collector.Report {
+	return c.report
 }
 
 func (c *Collector) Update(ctx context.Context, options ...collector.CollectorOption) error {
@@ -76,45 +130,82 @@ func (c *Collector) Update(ctx context.Context, options ...collector.CollectorOp
 	return nil
 }
 
-func (c *Collector) collectAll(ctx context.Context) error {
-	neededGroups, err := getNeededGroups(c.gitlabGroups, c.gitlabClient)
+// SchemaDiff compares the migrated schema of a project between two refs.
+func (c *Collector) SchemaDiff(ctx context.Context, origin, projectPath, fromRef, toRef string) (*migrationTypes.SchemaDiff, error) {
+	if c.migrationStep == nil {
+		return nil, fmt.Errorf("migration source not specified")
+	}
+
+	e, err := c.endpoint(origin)
 	if err != nil {
-		return fmt.Errorf("failed to get needed groups: %w", err)
+		return nil, err
 	}
 
-	neededProjects, err := getNeededProjects(neededGroups, c.gitlabClient)
+	project, err := e.scm.GetProjectByPath(ctx, projectPath)
 	if err != nil {
-		return fmt.Errorf("failed to get needed projects: %w", err)
+		return nil, fmt.Errorf("failed to get project: %w", err)
 	}
 
-	for _, project := range neededProjects {
-		params := &gtlabjobsteps.StepParams{
-			ServiceId:  *project.ID,
-			ServiceRef: *project.DefaultBranch,
+	defer c.takeDiagnostics()
+
+	return c.migrationStep.DiffRefs(ctx, e.files, *project.ID, fromRef, toRef)
+}
+
+func (c *Collector) collectAll(ctx context.Context) error {
+	neededProjects := []*endpointProject{}
+
+	for _, e := range c.endpoints {
+		neededGroups, err := getNeededGroups(ctx, e.groups, e.subgroups, e.scm)
+		if err != nil {
+			return fmt.Errorf("failed to get needed groups of %s: %w", e.name, err)
 		}
 
-		if err := c.collectProject(ctx, params); err != nil {
-			slog.Error("failed to collect project data", "err", err, "project", project.Name)
+		projects, err := getNeededProjects(ctx, neededGroups, e.subgroups, e.projectFilter, e.scm)
+		if err != nil {
+			return fmt.Errorf("failed to get needed projects of %s: %w", e.name, err)
+		}
+
+		for _, project := range projects {
+			neededProjects = append(neededProjects, &endpointProject{endpoint: e, project: project})
+		}
+	}
+
+	for _, needed := range neededProjects {
+		c.serviceResolver.AddService(needed.project.Name, needed.project.PathWithGroup, needed.endpoint.name)
+	}
+
+	for _, needed := range neededProjects {
+		project := needed.project
+
+		params := needed.endpoint.stepParams(*project.ID, *project.DefaultBranch)
+
+		err := c.collectProject(ctx, params)
+		c.report.AddProject(params.Origin, *project.PathWithGroup, params.ServiceRef, err, c.takeDiagnostics(), c.takeUnknownTables())
+		if err != nil {
+			slog.Error("failed to collect project data", "err", err, "origin", params.Origin, "project", *project.PathWithGroup)
 			continue
 		}
 	}
 	return nil
 }
 
+// collectFromReleaseFile collects the services listed in the release file,
+// a service can name the endpoint it comes from, by default it is the one of
+// the release project.
 func (c *Collector) collectFromReleaseFile(ctx context.Context) error {
-	project, _, err := c.gitlabClient.Projects.GetByNameWithGroup(ctx, c.releaseProject)
+	releaseEndpoint, err := c.endpoint(c.releaseOrigin)
 	if err != nil {
-		return fmt.Errorf("failed to get release project: %w", err)
+		return fmt.Errorf("failed to get release endpoint: %w", err)
 	}
 
-	releaseFile64, _, err := c.gitlabClient.Files.Get(ctx, c.releaseFile, *project.ID, c.releaseTag)
+	project, err := releaseEndpoint.scm.GetProjectByPath(ctx, c.releaseProject)
 	if err != nil {
-		return fmt.Errorf("failed to get release file: %w", err)
+		return fmt.Errorf("failed to get release project: %w", err)
 	}
 
-	releaseFile, err := base64.StdEncoding.DecodeString(releaseFile64.Content)
+	releaseFile, err := releaseEndpoint.scm.GetFile(ctx, *project.ID, c.releaseTag, c.releaseFile)
 	if err != nil {
-		return fmt.Errorf("failed to decode release file: %w", err)
+		return fmt.Errorf("failed to get release file: %w", err)
 	}
 
 	releaseInfo := &yamlTypes.All{}
@@ -124,28 +215,44 @@ func (c *Collector) collectFromReleaseFile(ctx context.Context) error {
 	}
 
 	for _, service := range releaseInfo.Service.Instances {
+		c.serviceResolver.AddService(service.Name, service.FullName, c.serviceOrigin(service))
+	}
+
+	for _, service := range releaseInfo.Service.Instances {
+		origin := c.serviceOrigin(service)
+
+		e, err := c.endpoint(origin)
+		if err != nil {
+			slog.Error("failed to get service endpoint", "err", err, "service", service.Name)
+			c.report.AddProject(origin, serviceReportName(service), ptr.Value(service.Tag), err, nil, nil)
+			continue
+		}
+
 		var project *types.Project
 		if service.ProjectID == nil {
-			project, _, err = c.gitlabClient.Projects.GetByNameWithGroup(ctx, *service.FullName)
+			project, err = e.scm.GetProjectByPath(ctx, *service.FullName)
 			if err != nil {
-				slog.Error("failed to get project", "err", err, "project", *service.FullName)
+				slog.Error("failed to get project", "err", err, "origin", origin, "project", *service.FullName)
+				c.report.AddProject(origin, *service.FullName, *service.Tag, fmt.Errorf("failed to get project: %w", err), nil, nil)
 				continue
 			}
 		} else {
-			project, _, err = c.gitlabClient.Projects.Get(ctx, *service.ProjectID)
+			project, err = e.scm.GetProject(ctx, *service.ProjectID)
 			if err != nil {
-				slog.Error("failed to get project", "err", err, "project", *service.ProjectID)
+				slog.Error("failed to get project", "err", err, "origin", origin, "project", *service.ProjectID)
+				c.report.AddProject(origin, fmt.Sprint(*service.ProjectID), *service.Tag, fmt.Errorf("failed to get project: %w", err), nil, nil)
 				continue
 			}
 		}
 
-		params := &gtlabjobsteps.StepParams{
-			ServiceId:  *project.ID,
-			ServiceRef: *service.Tag,
-		}
+		c.serviceResolver.AddService(project.Name, project.PathWithGroup, origin)
+
+		params := e.stepParams(*project.ID, *service.Tag)
 
-		if err := c.collectProject(ctx, params); err != nil {
-			slog.Error("failed to collect project data", "err", err, "project", project.Name)
+		err = c.collectProject(ctx, params)
+		c.report.AddProject(origin, *project.PathWithGroup, params.ServiceRef, err, c.takeDiagnostics(), c.takeUnknownTables())
+		if err != nil {
+			slog.Error("failed to collect project data", "err", err, "origin", origin, "project", *project.PathWithGroup)
 			continue
 		}
 	}
@@ -153,8 +260,27 @@ func (c *Collector) collectFromReleaseFile(ctx context.Context) error {
 	return nil
 }
 
+func (c *Collector) serviceOrigin(service *yamlTypes.Service) string {
+	if service.Origin != nil {
+		return *service.Origin
+	}
+
+	return c.releaseOrigin
+}
+
+func serviceReportName(service *yamlTypes.Service) string {
+	if service.FullName != nil {
+		return *service.FullName
+	}
+	if service.ProjectID != nil {
+		return fmt.Sprint(*service.ProjectID)
+	}
+
+	return ptr.Value(service.Name)
+}
+
 func (c *Collector) collectProject(ctx context.Context, params *gtlabjobsteps.StepParams) error {
-	aggr, err := defaultaggregator.New()
+	aggr, err := defaultaggregator.New(defaultaggregator.WithFileURL(params.SCM.FileURL))
 	if err != nil {
 		return fmt.Errorf("failed to create aggregator: %w", err)
 	}
@@ -172,6 +298,20 @@ func (c *Collector) collectProject(ctx context.Context, params *gtlabjobsteps.St
 		return fmt.Errorf("failed to aggregate data: %w", err)
 	}
 
+	aggrData.Service.External = ptr.Ptr(false)
+	if params.Origin != "" {
+		aggrData.Service.Origin = ptr.Ptr(params.Origin)
+	}
+	c.serviceResolver.AddService(aggrData.Service.Name, aggrData.Service.FullName, params.Origin)
+
+	for _, ingress := range aggrData.Ingresses {
+		for _, host := range ingress.Hosts {
+			c.serviceResolver.AddHost(host, *aggrData.Service.Name)
+		}
+	}
+
+	aggrData.OtherServices = resolveOtherServices(ctx, aggrData.OtherServices, params.Origin, c.serviceResolver, c.storage)
+
 	exist, err := isAlreadyExist(ctx, aggrData.Service, c.storage)
 	if err != nil {
 		return fmt.Errorf("failed to check if already exist: %w", err)
@@ -182,31 +322,67 @@ func (c *Collector) collectProject(ctx context.Context, params *gtlabjobsteps.St
 		return nil
 	}
 
-	if err := storefuncs.StoreResources(ctx, aggrData, c.storage); err != nil {
+	storeReport, err := storefuncs.StoreResources(ctx, aggrData, c.storage, c.hostRegistry)
+	if err != nil {
 		return fmt.Errorf("failed to store resource: %w", err)
 	}
 
+	c.unknownTables = storeReport.UnknownTables
+
 	return nil
 }
 
 func GetOptions(collectorConf *config.CollectorConfig, sourcesConf *config.SourcesConfig) ([]collector.CollectorOption, error) {
 	options := []collector.CollectorOption{}
 
-	if collectorConf.GitLab.ReleaseProject != nil {
-		slog.Info("release project enabled")
+	endpoints := collectorConf.SCMEndpoints()
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("no scm endpoint configured")
+	}
+
+	if collectorConf.Files != nil {
+		slog.Info("files mode set", "mode", collectorConf.Files.Mode)
+	}
+
+	releaseEndpoint := ""
+	for _, endpoint := range endpoints {
+		slog.Info("scm endpoint enabled", "name", endpoint.Name, "provider", endpoint.Provider, "groups", endpoint.Groups, "subgroups", endpoint.Subgroups, "projects_filter", endpoint.Projects != nil)
+		options = append(options, WithEndpoint(endpoint, collectorConf.Files))
+
+		if endpoint.ReleaseProject == nil {
+			continue
+		}
+		if releaseEndpoint != "" {
+			return nil, fmt.Errorf("release project already set for endpoint %s", releaseEndpoint)
+		}
+		releaseEndpoint = endpoint.Name
+
+		slog.Info("release project enabled", "endpoint", endpoint.Name)
 		releaseYamlSource, err := yaml.NewSource(&config.YamlSourceConfig{
-			ParseConfigPath: collectorConf.GitLab.ReleaseProject.ParseConfigPath,
+			ParseConfigPath: endpoint.ReleaseProject.ParseConfigPath,
 			Weight:          0,
 			FromGitlab:      true,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to create release yaml source: %w", err)
 		}
-		options = append(options, WithReleaseProject(collectorConf.GitLab.ReleaseProject.Project, collectorConf.GitLab.ReleaseProject.ReleaseFilePath, collectorConf.GitLab.ReleaseProject.Tag, releaseYamlSource))
+		options = append(options, WithReleaseProject(endpoint.Name, endpoint.ReleaseProject.Project, endpoint.ReleaseProject.ReleaseFilePath, endpoint.ReleaseProject.Tag, releaseYamlSource))
 	}
-	if collectorConf.GitLab.Groups != nil {
-		slog.Info("groups filter enabled")
-		options = append(options, WithGitlabGroups(collectorConf.GitLab.Groups))
+	if collectorConf.ServiceResolver != nil {
+		slog.Info("service resolver enabled")
+		serviceResolver, err := resolver.NewServiceResolver(collectorConf.ServiceResolver)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create service resolver: %w", err)
+		}
+		options = append(options, WithServiceResolver(serviceResolver))
+	}
+	if collectorConf.HostAliasesPath != "" {
+		slog.Info("host aliases enabled")
+		hostRegistry, err := resolver.LoadHostRegistry(collectorConf.HostAliasesPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load host aliases: %w", err)
+		}
+		options = append(options, WithHostRegistry(hostRegistry))
 	}
 	if sourcesConf.Migration != nil {
 		slog.Info("migration source enabled")
@@ -216,6 +392,40 @@ func GetOptions(collectorConf *config.CollectorConfig, sourcesConf *config.Sourc
 		}
 		options = append(options, WithMigrationSource(migrationSource, collectorConf.MigrationPaths))
 	}
+	if sourcesConf.Code != nil {
+		slog.Info("code source enabled")
+		codeSource, err := code.NewSource(sourcesConf.Code)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create code source: %w", err)
+		}
+		// migrations are parsed by the migration step, not as queries of the code
+		codeSource.ExcludePaths(collectorConf.MigrationPaths...)
+		options = append(options, WithCodeSource(codeSource, collectorConf.CodePaths))
+	}
+	if sourcesConf.Kubernetes != nil {
+		slog.Info("kubernetes source enabled")
+		kubernetesSource, err := kubernetes.NewSource(sourcesConf.Kubernetes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create kubernetes source: %w", err)
+		}
+		options = append(options, WithKubernetesSource(kubernetesSource, collectorConf.ManifestPaths))
+	}
+	if sourcesConf.Pipeline != nil {
+		slog.Info("pipeline source enabled")
+		pipelineSource, err := pipeline.NewSource(sourcesConf.Pipeline)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create pipeline source: %w", err)
+		}
+		options = append(options, WithPipelineSource(pipelineSource, collectorConf.PipelinePath))
+	}
+	if sourcesConf.Owners != nil {
+		slog.Info("owners source enabled")
+		ownersSource, err := owners.NewSource(sourcesConf.Owners)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create owners source: %w", err)
+		}
+		options = append(options, WithOwnersSource(ownersSource))
+	}
 	if sourcesConf.Yaml != nil {
 		slog.Info("yaml source enabled")
 		yamlSource, err := yaml.NewSource(sourcesConf.Yaml)
@@ -226,6 +436,10 @@ func GetOptions(collectorConf *config.CollectorConfig, sourcesConf *config.Sourc
 	}
 	if sourcesConf.GitLab != nil {
 		slog.Info("gitlab source enabled")
+		// its project ids only match the ones of a single endpoint
+		if sourcesConf.GitLab.Client != nil && len(endpoints) > 1 {
+			return nil, fmt.Errorf("gitlab source client can not be used with several scm endpoints, remove it to read through the endpoints")
+		}
 		gitlabSource, err := gitlab.NewSource(sourcesConf.GitLab)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create gitlab source: %w", err)
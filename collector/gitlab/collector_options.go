@@ -5,8 +5,14 @@ import (
 	"sort"
 	"vislab/collector"
 	gtlabjobsteps "vislab/collector/gitlab/steps"
+	"vislab/config"
+	"vislab/resolver"
+	"vislab/sources/code"
 	"vislab/sources/gitlab"
+	"vislab/sources/kubernetes"
 	"vislab/sources/migrations"
+	"vislab/sources/owners"
+	"vislab/sources/pipeline"
 	"vislab/sources/yaml"
 )
 
@@ -17,7 +23,7 @@ func WithYamlSource(yamlSource *yaml.Source, configPaths []string, fromGitlab bo
 			return fmt.Errorf("invalid collector type")
 		}
 
-		step := gtlabjobsteps.NewYamlStep(configPaths, collector.gitlabClient, yamlSource, fromGitlab)
+		step := gtlabjobsteps.NewYamlStep(configPaths, yamlSource, fromGitlab)
 		collector.steps = insertStepByWeight(collector.steps, step)
 		return nil
 	}
@@ -43,31 +49,104 @@ func WithMigrationSource(migrationSource *migrations.Source, migrationsDirs []st
 			return fmt.Errorf("invalid collector type")
 		}
 
-		step := gtlabjobsteps.NewMigrationStep(migrationsDirs, collector.gitlabClient, migrationSource)
+		step := gtlabjobsteps.NewMigrationStep(migrationsDirs, migrationSource)
+		collector.migrationStep = step
+		collector.migrationDiagnostics = migrationSource.Diagnostics()
 		collector.steps = insertStepByWeight(collector.steps, step)
 		return nil
 	}
 }
 
-func WithGitlabGroups(groups []string) collector.CollectorOption {
+func WithCodeSource(codeSource *code.Source, codePaths []string) collector.CollectorOption {
 	return func(c collector.Collector) error {
 		collector, ok := c.(*Collector)
 		if !ok {
 			return fmt.Errorf("invalid collector type")
 		}
 
-		collector.gitlabGroups = groups
+		step := gtlabjobsteps.NewCodeStep(codePaths, codeSource)
+		collector.steps = insertStepByWeight(collector.steps, step)
+		return nil
+	}
+}
+
+func WithKubernetesSource(kubernetesSource *kubernetes.Source, manifestPaths []string) collector.CollectorOption {
+	return func(c collector.Collector) error {
+		collector, ok := c.(*Collector)
+		if !ok {
+			return fmt.Errorf("invalid collector type")
+		}
+
+		step := gtlabjobsteps.NewKubernetesStep(manifestPaths, kubernetesSource)
+		collector.steps = insertStepByWeight(collector.steps, step)
+		return nil
+	}
+}
+
+func WithPipelineSource(pipelineSource *pipeline.Source, pipelinePath string) collector.CollectorOption {
+	return func(c collector.Collector) error {
+		collector, ok := c.(*Collector)
+		if !ok {
+			return fmt.Errorf("invalid collector type")
+		}
+
+		step := gtlabjobsteps.NewPipelineStep(pipelinePath, pipelineSource)
+		collector.steps = insertStepByWeight(collector.steps, step)
+		return nil
+	}
+}
+
+func WithOwnersSource(ownersSource *owners.Source) collector.CollectorOption {
+	return func(c collector.Collector) error {
+		collector, ok := c.(*Collector)
+		if !ok {
+			return fmt.Errorf("invalid collector type")
+		}
+
+		step := gtlabjobsteps.NewOwnersStep(ownersSource)
+		collector.steps = insertStepByWeight(collector.steps, step)
+		return nil
+	}
+}
+
+// WithEndpoint adds an scm endpoint to collect projects from, its groups are
+// matched by full path, with subgroups their nested groups are collected as
+// well. filesConf is used unless the endpoint has an own files config.
+func WithEndpoint(endpointConf *config.GitLabCollectorConfig, filesConf *config.FilesConfig) collector.CollectorOption {
+	return func(c collector.Collector) error {
+		collector, ok := c.(*Collector)
+		if !ok {
+			return fmt.Errorf("invalid collector type")
+		}
+
+		if _, err := collector.endpoint(endpointConf.Name); err == nil {
+			return fmt.Errorf("scm endpoint %s already added", endpointConf.Name)
+		}
+
+		e, err := newEndpoint(endpointConf, filesConf)
+		if err != nil {
+			return fmt.Errorf("failed to create scm endpoint %s: %w", endpointConf.Name, err)
+		}
+
+		collector.endpoints = append(collector.endpoints, e)
 		return nil
 	}
 }
 
-func WithReleaseProject(project, releaseFile string, releaseTag string, releaseYamlSource *yaml.Source) collector.CollectorOption {
+// WithReleaseProject collects the services listed in the release file of the
+// project on the origin endpoint. Only one endpoint can have a release project.
+func WithReleaseProject(origin, project, releaseFile string, releaseTag string, releaseYamlSource *yaml.Source) collector.CollectorOption {
 	return func(c collector.Collector) error {
 		collector, ok := c.(*Collector)
 		if !ok {
 			return fmt.Errorf("invalid collector type")
 		}
 
+		if collector.releaseProject != "" && collector.releaseOrigin != origin {
+			return fmt.Errorf("release project already set for endpoint %s", collector.releaseOrigin)
+		}
+
+		collector.releaseOrigin = origin
 		collector.releaseProject = project
 		collector.releaseFile = releaseFile
 		collector.releaseTag = releaseTag
@@ -76,6 +155,30 @@ func WithReleaseProject(project, releaseFile string, releaseTag string, releaseY
 	}
 }
 
+func WithServiceResolver(serviceResolver *resolver.ServiceResolver) collector.CollectorOption {
+	return func(c collector.Collector) error {
+		collector, ok := c.(*Collector)
+		if !ok {
+			return fmt.Errorf("invalid collector type")
+		}
+
+		collector.serviceResolver = serviceResolver
+		return nil
+	}
+}
+
+func WithHostRegistry(hostRegistry *resolver.HostRegistry) collector.CollectorOption {
+	return func(c collector.Collector) error {
+		collector, ok := c.(*Collector)
+		if !ok {
+			return fmt.Errorf("invalid collector type")
+		}
+
+		collector.hostRegistry = hostRegistry
+		return nil
+	}
+}
+
 func WithReleaseTag(tag string) collector.CollectorOption {
 	return func(c collector.Collector) error {
 		collector, ok := c.(*Collector)
@@ -0,0 +1,99 @@
+package gitlabcollector
+
+import (
+	"fmt"
+	gtlabjobsteps "vislab/collector/gitlab/steps"
+	"vislab/config"
+	"vislab/sources/files"
+	"vislab/sources/gitlab"
+	gitlabTypes "vislab/sources/gitlab/types"
+	"vislab/sources/scm"
+)
+
+// endpoint is one scm instance projects are collected from. Project ids are
+// only unique within an instance, so everything of a project is read through
+// the endpoint it comes from.
+type endpoint struct {
+	name          string
+	scm           scm.Provider
+	files         files.Provider
+	groups        []string
+	subgroups     bool
+	projectFilter *projectFilter
+}
+
+type endpointProject struct {
+	endpoint *endpoint
+	project  *gitlabTypes.Project
+}
+
+// newEndpoint builds the endpoint, the files config of the endpoint takes
+// precedence over the collector one.
+func newEndpoint(conf *config.GitLabCollectorConfig, filesConf *config.FilesConfig) (*endpoint, error) {
+	provider, err := scm.New(conf.Provider, conf.Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scm provider: %w", err)
+	}
+
+	e := &endpoint{
+		name:      conf.Name,
+		scm:       provider,
+		files:     files.NewAPIProvider(provider),
+		groups:    conf.Groups,
+		subgroups: conf.Subgroups,
+	}
+
+	if conf.Files != nil {
+		filesConf = conf.Files
+	}
+	if filesConf != nil {
+		e.files, err = files.NewProvider(filesConf, provider)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create file provider: %w", err)
+		}
+	}
+
+	if conf.Projects != nil {
+		e.projectFilter, err = newProjectFilter(conf.Projects)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create project filter: %w", err)
+		}
+	}
+
+	return e, nil
+}
+
+func (e *endpoint) stepParams(projectId int64, ref string) *gtlabjobsteps.StepParams {
+	return &gtlabjobsteps.StepParams{
+		ServiceId:  projectId,
+		ServiceRef: ref,
+		Origin:     e.name,
+		SCM:        e.scm,
+		Files:      e.files,
+	}
+}
+
+func (c *Collector) endpoint(name string) (*endpoint, error) {
+	for _, e := range c.endpoints {
+		if e.name == name {
+			return e, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown scm endpoint: %s", name)
+}
+
+func (c *Collector) stats() gitlab.Stats {
+	stats := gitlab.Stats{}
+
+	for _, e := range c.endpoints {
+		endpointStats := e.scm.Stats()
+
+		stats.Requests += endpointStats.Requests
+		stats.Retries += endpointStats.Retries
+		stats.Failed += endpointStats.Failed
+		stats.CacheHits += endpointStats.CacheHits
+	}
+
+	return stats
+}
@@ -0,0 +1,86 @@
+package gitlabcollector
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"vislab/config"
+	gitlabTypes "vislab/sources/gitlab/types"
+)
+
+// projectFilter keeps the projects whose full path matches any include and
+// no exclude pattern and which have any of the topics and none of the
+// excluded ones. Empty lists do not filter.
+type projectFilter struct {
+	include       []*regexp.Regexp
+	exclude       []*regexp.Regexp
+	topics        []string
+	excludeTopics []string
+}
+
+func newProjectFilter(conf *config.ProjectFilterConfig) (*projectFilter, error) {
+	include, err := compilePatterns(conf.Include)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile include pattern: %w", err)
+	}
+
+	exclude, err := compilePatterns(conf.Exclude)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile exclude pattern: %w", err)
+	}
+
+	return &projectFilter{
+		include:       include,
+		exclude:       exclude,
+		topics:        conf.Topics,
+		excludeTopics: conf.ExcludeTopics,
+	}, nil
+}
+
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := []*regexp.Regexp{}
+
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		compiled = append(compiled, re)
+	}
+
+	return compiled, nil
+}
+
+func (f *projectFilter) match(project *gitlabTypes.Project) bool {
+	if f == nil {
+		return true
+	}
+
+	path := ""
+	if project.PathWithGroup != nil {
+		path = *project.PathWithGroup
+	}
+
+	matchPath := func(re *regexp.Regexp) bool {
+		return re.MatchString(path)
+	}
+	hasTopic := func(topic string) bool {
+		return slices.Contains(project.Topics, topic)
+	}
+
+	if len(f.include) > 0 && !slices.ContainsFunc(f.include, matchPath) {
+		return false
+	}
+	if slices.ContainsFunc(f.exclude, matchPath) {
+		return false
+	}
+	if len(f.topics) > 0 && !slices.ContainsFunc(f.topics, hasTopic) {
+		return false
+	}
+	if slices.ContainsFunc(f.excludeTopics, hasTopic) {
+		return false
+	}
+
+	return true
+}
@@ -2,64 +2,154 @@ package gitlabcollector
 
 import (
 	"context"
-	"fmt"
 	"log/slog"
 	"slices"
 	"strings"
 	"vislab/libs/check"
-	"vislab/sources/gitlab"
+	"vislab/libs/ptr"
+	"vislab/resolver"
 	gitlabTypes "vislab/sources/gitlab/types"
+	"vislab/sources/scm"
 	"vislab/storage"
 	"vislab/types"
 )
 
-func getNeededGroups(chosenGroups []string, git *gitlab.Client) ([]*gitlabTypes.Group, error) {
-	groups, _, err := git.Groups.ListAll(context.Background(), &gitlabTypes.ListGroupsOptions{})
+func getNeededGroups(ctx context.Context, chosenGroups []string, subgroups bool, scm scm.Provider) ([]*gitlabTypes.Group, error) {
+	groups, err := scm.ListGroups(ctx)
 	if err != nil {
 		return nil, err
 	}
-	slog.Debug("received all gitlab groups", "groups", fmt.Sprintf("%s", groups))
+	slog.Debug("received all scm groups", "count", len(groups))
 
 	neededGroups := groups
 
 	if len(chosenGroups) > 0 {
 		neededGroups = []*gitlabTypes.Group{}
 
-		for _, flagGroup := range chosenGroups {
-			if !slices.ContainsFunc(groups, func(group *gitlabTypes.Group) bool {
-				if group.Path == flagGroup {
-					neededGroups = append(neededGroups, group)
-					return true
-				}
-				return false
-			}) {
-				slog.Error("group not found in gitlab", "group", flagGroup)
+		for _, chosenGroup := range chosenGroups {
+			chosenGroup = strings.Trim(chosenGroup, "/")
+
+			idx := slices.IndexFunc(groups, func(group *gitlabTypes.Group) bool {
+				return group.FullPath == chosenGroup
+			})
+			if idx < 0 {
+				slog.Error("group not found in scm", "group", chosenGroup)
+				continue
 			}
+
+			neededGroups = append(neededGroups, groups[idx])
 		}
 	}
 
-	slog.Debug("got needed groups", "groups", fmt.Sprintf("%s", neededGroups))
+	// the projects of nested groups come with their ancestor
+	if subgroups {
+		ancestors := slices.Clone(neededGroups)
+		neededGroups = slices.DeleteFunc(neededGroups, func(group *gitlabTypes.Group) bool {
+			return slices.ContainsFunc(ancestors, func(ancestor *gitlabTypes.Group) bool {
+				return strings.HasPrefix(group.FullPath, ancestor.FullPath+"/")
+			})
+		})
+	}
+
+	slog.Debug("got needed groups", "groups", groupPaths(neededGroups))
 	return neededGroups, nil
 }
 
-func getNeededProjects(neededGroups []*gitlabTypes.Group, git *gitlab.Client) ([]*gitlabTypes.Project, error) {
+func getNeededProjects(ctx context.Context, neededGroups []*gitlabTypes.Group, subgroups bool, filter *projectFilter, scm scm.Provider) ([]*gitlabTypes.Project, error) {
 	neededProjects := []*gitlabTypes.Project{}
+	seen := map[int64]bool{}
 
 	for _, group := range neededGroups {
-		projects, _, err := git.Groups.ListAllProjects(context.Background(), group.ID, &gitlabTypes.ListProjectsOptions{})
+		projects, err := scm.ListProjects(ctx, group, subgroups)
 		if err != nil {
-			slog.Error("failed to get projects for group", "err", err, "group", group.Path)
+			slog.Error("failed to get projects for group", "err", err, "group", group.FullPath)
 		}
 
-		neededProjects = append(neededProjects, projects...)
+		for _, project := range projects {
+			if seen[*project.ID] {
+				continue
+			}
+			seen[*project.ID] = true
+
+			if !filter.match(project) {
+				slog.Debug("project filtered out", "project", *project.PathWithGroup)
+				continue
+			}
+
+			neededProjects = append(neededProjects, project)
+		}
 	}
 
-	slog.Debug("got needed projects", "projects", fmt.Sprintf("%s", neededProjects))
+	slog.Debug("got needed projects", "count", len(neededProjects))
 	return neededProjects, nil
 }
 
+func groupPaths(groups []*gitlabTypes.Group) []string {
+	paths := []string{}
+	for _, group := range groups {
+		paths = append(paths, group.FullPath)
+	}
+
+	return paths
+}
+
+// resolveOtherServices resolves the services used by a service of the origin
+// endpoint, known services keep their own origin.
+func resolveOtherServices(ctx context.Context, otherServices []*types.Service, origin string, serviceResolver *resolver.ServiceResolver, storage storage.Storage) []*types.Service {
+	resolvedServices := []*types.Service{}
+
+OtherServices:
+	for _, otherService := range otherServices {
+		if otherService.Name == nil {
+			continue
+		}
+
+		name, known := serviceResolver.Resolve(*otherService.Name)
+		if !known {
+			dbService, err := storage.Service().Get(ctx, name, origin)
+			known = err == nil && dbService.External != nil && !*dbService.External
+		}
+
+		if !known {
+			ingressService, err := storage.Kubernetes().GetServiceByIngressHost(ctx, name)
+			if err == nil {
+				name, known = ingressService, true
+			}
+		}
+
+		if !known {
+			slog.Warn("other service not resolved, marking as external", "service", *otherService.Name, "resolved", name)
+		}
+
+		for _, resolvedService := range resolvedServices {
+			if *resolvedService.Name != name {
+				continue
+			}
+
+			for _, port := range otherService.Ports {
+				if !slices.ContainsFunc(resolvedService.Ports, func(p *types.Port) bool {
+					return check.ComparePointers(p.Number, port.Number)
+				}) {
+					resolvedService.Ports = append(resolvedService.Ports, port)
+				}
+			}
+
+			continue OtherServices
+		}
+
+		otherService.Name = ptr.Ptr(name)
+		otherService.External = ptr.Ptr(!known)
+		if serviceOrigin := serviceResolver.Origin(name, origin); known && serviceOrigin != "" {
+			otherService.Origin = ptr.Ptr(serviceOrigin)
+		}
+		resolvedServices = append(resolvedServices, otherService)
+	}
+
+	return resolvedServices
+}
+
 func isAlreadyExist(ctx context.Context, service *types.Service, storage storage.Storage) (bool, error) {
-	dbService, err := storage.Service().Get(ctx, *service.Name)
+	dbService, err := storage.Service().Get(ctx, *service.Name, ptr.Value(service.Origin))
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") { // TODO: add cool err handle
 			return false, nil
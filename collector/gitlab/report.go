@@ -0,0 +1,80 @@
+package gitlabcollector
+
+import (
+	"log/slog"
+	"vislab/collector"
+	migrationTypes "vislab/sources/migrations/types"
+)
+
+func (c *Collector) takeDiagnostics() []*migrationTypes.Diagnostic {
+	if c.migrationDiagnostics == nil {
+		return nil
+	}
+
+	diagnostics := c.migrationDiagnostics.Items()
+	c.migrationDiagnostics.Reset()
+
+	return diagnostics
+}
+
+func (c *Collector) takeUnknownTables() []string {
+	unknownTables := c.unknownTables
+	c.unknownTables = nil
+
+	return unknownTables
+}
+
+func (c *Collector) logReport() {
+	c.report.Finish()
+
+	stats := c.stats()
+	c.report.SetRequestStats(&collector.RequestStats{
+		Total:     stats.Requests - c.requestStats.Requests,
+		Retries:   stats.Retries - c.requestStats.Retries,
+		Failed:    stats.Failed - c.requestStats.Failed,
+		CacheHits: stats.CacheHits - c.requestStats.CacheHits,
+	})
+
+	for _, project := range c.report.Projects {
+		for _, diagnostic := range project.Diagnostics {
+			slog.Warn("migration statement not applied",
+				"origin", project.Origin,
+				"project", project.Name,
+				"ref", project.Ref,
+				"file", diagnostic.File,
+				"index", diagnostic.Index,
+				"offset", diagnostic.Offset,
+				"statement", diagnostic.Statement,
+				"status", diagnostic.Status,
+				"message", diagnostic.Message,
+			)
+		}
+	}
+
+	for _, project := range c.report.Projects {
+		if len(project.UnknownTables) > 0 {
+			slog.Warn("used tables not found",
+				"origin", project.Origin,
+				"project", project.Name,
+				"ref", project.Ref,
+				"tables", project.UnknownTables,
+			)
+		}
+	}
+
+	counts := c.report.MigrationCounts()
+
+	slog.Info("run report",
+		"projects", len(c.report.Projects),
+		"failed_projects", c.report.Failed(),
+		"migrations_ok", counts[migrationTypes.DiagnosticOK],
+		"migrations_skipped", counts[migrationTypes.DiagnosticSkipped],
+		"migrations_unsupported", counts[migrationTypes.DiagnosticUnsupported],
+		"migrations_error", counts[migrationTypes.DiagnosticError],
+		"gitlab_requests", c.report.Requests.Total,
+		"gitlab_retries", c.report.Requests.Retries,
+		"gitlab_failed_requests", c.report.Requests.Failed,
+		"gitlab_cache_hits", c.report.Requests.CacheHits,
+		"duration", c.report.FinishedAt.Sub(c.report.StartedAt),
+	)
+}
@@ -0,0 +1,68 @@
+package gtlabjobsteps
+
+import (
+	"context"
+	"log/slog"
+	"vislab/sources/code"
+	codeTypes "vislab/sources/code/types"
+)
+
+type CodeStep struct {
+	codePaths  []string
+	codeSource *code.Source
+}
+
+func NewCodeStep(codePaths []string, codeSource *code.Source) *CodeStep {
+	if len(codePaths) == 0 {
+		codePaths = []string{""}
+	}
+
+	return &CodeStep{
+		codePaths:  codePaths,
+		codeSource: codeSource,
+	}
+}
+
+func (s *CodeStep) Run(ctx context.Context, params *StepParams) error {
+	all := codeTypes.NewAll()
+
+	for _, codePath := range s.codePaths {
+		slog.Info("getting code files", "service_id", params.ServiceId, "ref", params.ServiceRef, "path", codePath)
+		codeFiles, err := params.Files.ListDir(ctx, params.ServiceId, params.ServiceRef, codePath)
+		if err != nil {
+			slog.Error("failed to get code files", "err", err, "path", codePath, "service_id", params.ServiceId, "ref", params.ServiceRef)
+			continue
+		}
+
+		for _, codeFile := range codeFiles {
+			if !s.codeSource.Match(codeFile) {
+				continue
+			}
+
+			codeData, err := params.Files.Get(ctx, params.ServiceId, params.ServiceRef, codeFile)
+			if err != nil {
+				slog.Error("failed to get code file", "err", err, "path", codeFile, "service_id", params.ServiceId, "ref", params.ServiceRef)
+				continue
+			}
+
+			if err := s.codeSource.GetData(ctx, codeFile, codeData, all); err != nil {
+				slog.Error("failed to get data from code file", "err", err, "path", codeFile, "service_id", params.ServiceId, "ref", params.ServiceRef)
+				continue
+			}
+		}
+	}
+
+	if len(all.Tables) == 0 {
+		return nil
+	}
+
+	if err := params.Aggregator.Set(ctx, all); err != nil {
+		slog.Error("failed to set code data", "err", err, "service_id", params.ServiceId, "ref", params.ServiceRef)
+	}
+
+	return nil
+}
+
+func (s *CodeStep) Weight() int64 {
+	return s.codeSource.Weight()
+}
@@ -19,7 +19,7 @@ func NewGitlabStep(gitlabSource *gitlab.Source) *GitlabStep {
 
 func (s *GitlabStep) Run(ctx context.Context, params *StepParams) error {
 	slog.Info("running gitlab step", "service_id", params.ServiceId, "ref", params.ServiceRef)
-	gitlabSourceData, err := s.gitlabSource.GetData(ctx, params.ServiceId)
+	gitlabSourceData, err := s.gitlabSource.GetData(ctx, params.SCM, params.ServiceId)
 	if err != nil {
 		return fmt.Errorf("failed to get data from gitlab source: %w", err)
 	}
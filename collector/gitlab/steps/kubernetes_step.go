@@ -0,0 +1,60 @@
+package gtlabjobsteps
+
+import (
+	"context"
+	"log/slog"
+	"vislab/sources/kubernetes"
+	kubernetesTypes "vislab/sources/kubernetes/types"
+)
+
+type KubernetesStep struct {
+	manifestPaths    []string
+	kubernetesSource *kubernetes.Source
+}
+
+func NewKubernetesStep(manifestPaths []string, kubernetesSource *kubernetes.Source) *KubernetesStep {
+	return &KubernetesStep{
+		manifestPaths:    manifestPaths,
+		kubernetesSource: kubernetesSource,
+	}
+}
+
+func (s *KubernetesStep) Run(ctx context.Context, params *StepParams) error {
+	all := &kubernetesTypes.All{}
+
+	for _, manifestPath := range s.manifestPaths {
+		slog.Info("getting manifest files", "service_id", params.ServiceId, "ref", params.ServiceRef, "path", manifestPath)
+		manifestFiles, err := params.Files.ListDir(ctx, params.ServiceId, params.ServiceRef, manifestPath)
+		if err != nil {
+			slog.Error("failed to get manifest files", "err", err, "path", manifestPath, "service_id", params.ServiceId, "ref", params.ServiceRef)
+			continue
+		}
+
+		for _, manifestFile := range manifestFiles {
+			if !s.kubernetesSource.Match(manifestFile) {
+				continue
+			}
+
+			manifestData, err := params.Files.Get(ctx, params.ServiceId, params.ServiceRef, manifestFile)
+			if err != nil {
+				slog.Error("failed to get manifest file", "err", err, "path", manifestFile, "service_id", params.ServiceId, "ref", params.ServiceRef)
+				continue
+			}
+
+			if err := s.kubernetesSource.GetData(ctx, manifestFile, manifestData, all); err != nil {
+				slog.Error("failed to get data from manifest file", "err", err, "path", manifestFile, "service_id", params.ServiceId, "ref", params.ServiceRef)
+				continue
+			}
+		}
+	}
+
+	if err := params.Aggregator.Set(ctx, all); err != nil {
+		slog.Error("failed to set manifest data", "err", err, "service_id", params.ServiceId, "ref", params.ServiceRef)
+	}
+
+	return nil
+}
+
+func (s *KubernetesStep) Weight() int64 {
+	return s.kubernetesSource.Weight()
+}
@@ -2,61 +2,38 @@ package gtlabjobsteps
 
 import (
 	"context"
-	"encoding/base64"
+	"errors"
+	"fmt"
 	"log/slog"
-	"vislab/sources/gitlab"
+	"vislab/sources/files"
 	"vislab/sources/migrations"
 	migrationsTypes "vislab/sources/migrations/types"
 )
 
 type MigrationStep struct {
 	migrationDirs   []string
-	gitlabClient    *gitlab.Client
 	migrationSource *migrations.Source
 }
 
-func NewMigrationStep(migrationDirs []string, gitlabClient *gitlab.Client, migrationSource *migrations.Source) *MigrationStep {
+func NewMigrationStep(migrationDirs []string, migrationSource *migrations.Source) *MigrationStep {
 	return &MigrationStep{
 		migrationDirs:   migrationDirs,
-		gitlabClient:    gitlabClient,
 		migrationSource: migrationSource,
 	}
 }
 
 func (s *MigrationStep) Run(ctx context.Context, params *StepParams) error {
 	for _, migrationDir := range s.migrationDirs {
-		slog.Info("getting migration files", "service_id", params.ServiceId, "ref", params.ServiceRef, "path", migrationDir)
-		migrationFiles, _, err := s.gitlabClient.Files.ListDir(ctx, migrationDir, params.ServiceId, params.ServiceRef)
+		migrationList, err := s.loadMigrations(ctx, params.Files, migrationDir, params.ServiceId, params.ServiceRef)
 		if err != nil {
 			slog.Error("failed to get migration files", "err", err, "path", migrationDir, "service_id", params.ServiceId, "ref", params.ServiceRef)
 			continue
 		}
 
-		all := &migrationsTypes.All{
-			Tables:   make(map[string]*migrationsTypes.Table),
-			Funcs:    make(map[string][]*migrationsTypes.Func),
-			Indexes:  make(map[string]*migrationsTypes.Index),
-			Triggers: make(map[string]*migrationsTypes.Trigger),
-			Types:    make(map[string]*migrationsTypes.Type),
-		}
-
-		for _, migrationFile := range migrationFiles {
-			migrationData64, _, err := s.gitlabClient.Files.Get(ctx, migrationFile.Path, params.ServiceId, params.ServiceRef)
-			if err != nil {
-				slog.Error("failed to get migration file", "err", err, "path", migrationFile.Path, "service_id", params.ServiceId, "ref", params.ServiceRef)
-				continue
-			}
+		all := migrationsTypes.NewAll()
 
-			migrationData, err := base64.StdEncoding.DecodeString(migrationData64.Content)
-			if err != nil {
-				slog.Error("failed to decode migration file", "err", err, "path", migrationFile.Path, "service_id", params.ServiceId, "ref", params.ServiceRef)
-				continue
-			}
-
-			if err := s.migrationSource.GetData(ctx, migrationData, all); err != nil {
-				slog.Error("failed to get data from migration file", "err", err, "path", migrationFile.Path, "service_id", params.ServiceId, "ref", params.ServiceRef)
-				continue
-			}
+		if err := s.migrationSource.Apply(ctx, migrationList, s.migrationSource.Version(), all); err != nil {
+			slog.Error("failed to apply migrations", "err", err, "path", migrationDir, "service_id", params.ServiceId, "ref", params.ServiceRef)
 		}
 
 		if err := params.Aggregator.Set(ctx, all); err != nil {
@@ -72,3 +49,66 @@ func (s *MigrationStep) Run(ctx context.Context, params *StepParams) error {
 func (s *MigrationStep) Weight() int64 {
 	return s.migrationSource.Weight()
 }
+
+// SchemaAt reconstructs the schema of the project at the given ref with the
+// migrations applied up to the given version when it is not empty.
+func (s *MigrationStep) SchemaAt(ctx context.Context, files files.Provider, projectId int64, ref, version string) (*migrationsTypes.All, error) {
+	for _, migrationDir := range s.migrationDirs {
+		migrationList, err := s.loadMigrations(ctx, files, migrationDir, projectId, ref)
+		if err != nil {
+			slog.Error("failed to get migration files", "err", err, "path", migrationDir, "service_id", projectId, "ref", ref)
+			continue
+		}
+
+		return s.migrationSource.SchemaAt(ctx, migrationList, version)
+	}
+
+	return nil, fmt.Errorf("no migrations found for ref %s", ref)
+}
+
+func (s *MigrationStep) DiffRefs(ctx context.Context, files files.Provider, projectId int64, fromRef, toRef string) (*migrationsTypes.SchemaDiff, error) {
+	from, err := s.SchemaAt(ctx, files, projectId, fromRef, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schema at %s: %w", fromRef, err)
+	}
+
+	to, err := s.SchemaAt(ctx, files, projectId, toRef, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schema at %s: %w", toRef, err)
+	}
+
+	return migrations.DiffSchemas(from, to), nil
+}
+
+func (s *MigrationStep) loadMigrations(ctx context.Context, files files.Provider, migrationDir string, projectId int64, ref string) ([]*migrationsTypes.Migration, error) {
+	slog.Info("getting migration files", "service_id", projectId, "ref", ref, "path", migrationDir)
+	migrationFiles, err := files.ListDir(ctx, projectId, ref, migrationDir)
+	if err != nil {
+		return nil, err
+	}
+
+	migrationList := []*migrationsTypes.Migration{}
+
+	for _, migrationFile := range migrationFiles {
+		migrationData, err := files.Get(ctx, projectId, ref, migrationFile)
+		if err != nil {
+			slog.Error("failed to get migration file", "err", err, "path", migrationFile, "service_id", projectId, "ref", ref)
+			continue
+		}
+
+		migration, err := s.migrationSource.Load(migrationDir, migrationFile, migrationData)
+		if err != nil {
+			if errors.Is(err, migrations.ErrNotMigration) {
+				slog.Debug("skipping non migration file", "path", migrationFile, "service_id", projectId, "ref", ref)
+				continue
+			}
+
+			slog.Error("failed to load migration file", "err", err, "path", migrationFile, "service_id", projectId, "ref", ref)
+			continue
+		}
+
+		migrationList = append(migrationList, migration)
+	}
+
+	return migrationList, nil
+}
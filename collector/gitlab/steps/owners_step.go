@@ -0,0 +1,108 @@
+package gtlabjobsteps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	gitlabTypes "vislab/sources/gitlab/types"
+	"vislab/sources/owners"
+	ownersTypes "vislab/sources/owners/types"
+	"vislab/sources/scm"
+)
+
+type OwnersStep struct {
+	ownersSource *owners.Source
+}
+
+func NewOwnersStep(ownersSource *owners.Source) *OwnersStep {
+	return &OwnersStep{
+		ownersSource: ownersSource,
+	}
+}
+
+func (s *OwnersStep) Run(ctx context.Context, params *StepParams) error {
+	project, err := params.SCM.GetProject(ctx, params.ServiceId)
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+
+	files := &ownersTypes.Files{}
+	if project.Name != nil {
+		files.ServiceName = *project.Name
+	}
+	if project.PathWithGroup != nil {
+		files.ServiceFullName = *project.PathWithGroup
+	}
+
+	for _, codeownersPath := range owners.CodeownersPaths {
+		files.Codeowners = s.getFile(ctx, codeownersPath, params)
+		if files.Codeowners != nil {
+			break
+		}
+	}
+
+	files.Owners = s.getFile(ctx, s.ownersSource.OwnersPath(), params)
+
+	if files.Codeowners == nil && files.Owners == nil {
+		files.Contributors = s.getContributors(ctx, params)
+	}
+
+	all := &ownersTypes.All{}
+
+	if err := s.ownersSource.GetData(ctx, files, all); err != nil {
+		slog.Error("failed to get owners data", "err", err, "service_id", params.ServiceId, "ref", params.ServiceRef)
+		return nil
+	}
+
+	if err := params.Aggregator.Set(ctx, all); err != nil {
+		slog.Error("failed to set owners data", "err", err, "service_id", params.ServiceId, "ref", params.ServiceRef)
+	}
+
+	return nil
+}
+
+func (s *OwnersStep) getFile(ctx context.Context, filePath string, params *StepParams) []byte {
+	data, err := params.Files.Get(ctx, params.ServiceId, params.ServiceRef, filePath)
+	if err != nil {
+		slog.Debug("owners file not found", "err", err, "path", filePath, "service_id", params.ServiceId, "ref", params.ServiceRef)
+		return nil
+	}
+
+	return data
+}
+
+func (s *OwnersStep) getContributors(ctx context.Context, params *StepParams) []*ownersTypes.Dev {
+	contributors, err := params.SCM.ListContributors(ctx, params.ServiceId)
+	if errors.Is(err, scm.ErrUnsupported) {
+		slog.Debug("contributors not supported", "service_id", params.ServiceId)
+		return nil
+	}
+	if err != nil {
+		slog.Error("failed to get contributors", "err", err, "service_id", params.ServiceId)
+		return nil
+	}
+
+	slices.SortStableFunc(contributors, func(a, b *gitlabTypes.Contributor) int {
+		return int(b.CommitsNum - a.CommitsNum)
+	})
+
+	devs := []*ownersTypes.Dev{}
+	for _, contributor := range contributors {
+		devs = append(devs, &ownersTypes.Dev{
+			Name:  contributor.Name,
+			Email: contributor.Email,
+		})
+
+		if len(devs) == s.ownersSource.TopContributors() {
+			break
+		}
+	}
+
+	return devs
+}
+
+func (s *OwnersStep) Weight() int64 {
+	return s.ownersSource.Weight()
+}
@@ -0,0 +1,70 @@
+package gtlabjobsteps
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"vislab/sources/pipeline"
+	pipelineTypes "vislab/sources/pipeline/types"
+)
+
+const defaultPipelinePath = ".gitlab-ci.yml"
+
+type PipelineStep struct {
+	pipelinePath   string
+	pipelineSource *pipeline.Source
+}
+
+func NewPipelineStep(pipelinePath string, pipelineSource *pipeline.Source) *PipelineStep {
+	if pipelinePath == "" {
+		pipelinePath = defaultPipelinePath
+	}
+
+	return &PipelineStep{
+		pipelinePath:   pipelinePath,
+		pipelineSource: pipelineSource,
+	}
+}
+
+func (s *PipelineStep) Run(ctx context.Context, params *StepParams) error {
+	all := &pipelineTypes.All{}
+
+	slog.Info("getting pipeline file", "service_id", params.ServiceId, "ref", params.ServiceRef, "path", s.pipelinePath)
+	if err := s.pipelineSource.GetData(ctx, s.pipelinePath, s.fileLoader(params), all); err != nil {
+		slog.Error("failed to get data from pipeline file", "err", err, "path", s.pipelinePath, "service_id", params.ServiceId, "ref", params.ServiceRef)
+		return nil
+	}
+
+	if err := params.Aggregator.Set(ctx, all); err != nil {
+		slog.Error("failed to set pipeline data", "err", err, "service_id", params.ServiceId, "ref", params.ServiceRef)
+	}
+
+	return nil
+}
+
+// fileLoader reads local includes from the collected project and project
+// includes from the default branch of the template project unless a ref is set.
+func (s *PipelineStep) fileLoader(params *StepParams) pipeline.FileLoader {
+	return func(ctx context.Context, project, ref, filePath string) ([]byte, error) {
+		projectId := params.ServiceId
+		if project == "" {
+			ref = params.ServiceRef
+		} else {
+			templateProject, err := params.SCM.GetProjectByPath(ctx, project)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get template project: %w", err)
+			}
+
+			projectId = *templateProject.ID
+			if ref == "" && templateProject.DefaultBranch != nil {
+				ref = *templateProject.DefaultBranch
+			}
+		}
+
+		return params.Files.Get(ctx, projectId, ref, filePath)
+	}
+}
+
+func (s *PipelineStep) Weight() int64 {
+	return s.pipelineSource.Weight()
+}
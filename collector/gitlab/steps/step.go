@@ -3,6 +3,8 @@ package gtlabjobsteps
 import (
 	"context"
 	"vislab/aggregator"
+	"vislab/sources/files"
+	"vislab/sources/scm"
 )
 
 type (
@@ -11,9 +13,14 @@ type (
 		Weight() int64
 	}
 
+	// StepParams carries the scm endpoint the service comes from, project ids
+	// are only unique within it.
 	StepParams struct {
 		ServiceId  int64
 		ServiceRef string
+		Origin     string
+		SCM        scm.Provider
+		Files      files.Provider
 		Aggregator aggregator.Aggregator
 	}
 )
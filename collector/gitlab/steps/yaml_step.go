@@ -2,27 +2,23 @@ package gtlabjobsteps
 
 import (
 	"context"
-	"encoding/base64"
 	"log/slog"
 	"os"
-	"vislab/sources/gitlab"
 	"vislab/sources/yaml"
 	yamlTypes "vislab/sources/yaml/types"
 )
 
 type YamlStep struct {
-	filePaths    []string
-	gitlabClient *gitlab.Client
-	yamlSource   *yaml.Source
-	fromGitlab   bool
+	filePaths  []string
+	yamlSource *yaml.Source
+	fromGitlab bool
 }
 
-func NewYamlStep(filePaths []string, gitlabClient *gitlab.Client, yamlSource *yaml.Source, fromGitlab bool) *YamlStep {
+func NewYamlStep(filePaths []string, yamlSource *yaml.Source, fromGitlab bool) *YamlStep {
 	return &YamlStep{
-		filePaths:    filePaths,
-		gitlabClient: gitlabClient,
-		yamlSource:   yamlSource,
-		fromGitlab:   fromGitlab,
+		filePaths:  filePaths,
+		yamlSource: yamlSource,
+		fromGitlab: fromGitlab,
 	}
 }
 
@@ -33,19 +29,14 @@ func (s *YamlStep) Run(ctx context.Context, params *StepParams) error {
 		var configData []byte
 
 		if s.fromGitlab {
-			config, _, err := s.gitlabClient.Files.Get(ctx, configPath, params.ServiceId, params.ServiceRef)
+			var err error
+			configData, err = params.Files.Get(ctx, params.ServiceId, params.ServiceRef, configPath)
 			if err != nil {
 				slog.Error("failed to get config file", "err", err, "path", configPath, "service_id", params.ServiceId, "ref", params.ServiceRef)
 				continue
 			}
-
-			configData, err = base64.StdEncoding.DecodeString(config.Content)
-			if err != nil {
-				slog.Error("failed to decode config file", "err", err, "path", configPath, "service_id", params.ServiceId, "ref", params.ServiceRef)
-				continue
-			}
 		} else {
-			service, _, err := s.gitlabClient.Projects.Get(ctx, params.ServiceId)
+			service, err := params.SCM.GetProject(ctx, params.ServiceId)
 			if err != nil {
 				slog.Error("failed to get service", "err", err, "service_id", params.ServiceId)
 				continue
@@ -0,0 +1,111 @@
+package collector
+
+import (
+	"sync"
+	"time"
+	migrationTypes "vislab/sources/migrations/types"
+)
+
+type (
+	Report struct {
+		mu sync.Mutex
+
+		StartedAt  time.Time        `json:"started_at"`
+		FinishedAt time.Time        `json:"finished_at"`
+		Projects   []*ProjectReport `json:"projects"`
+		Requests   *RequestStats    `json:"requests,omitempty"`
+	}
+
+	RequestStats struct {
+		Total     int64 `json:"total"`
+		Retries   int64 `json:"retries"`
+		Failed    int64 `json:"failed"`
+		CacheHits int64 `json:"cache_hits"`
+	}
+
+	ProjectReport struct {
+		Origin        string                                  `json:"origin,omitempty"`
+		Name          string                                  `json:"name"`
+		Ref           string                                  `json:"ref"`
+		Error         string                                  `json:"error,omitempty"`
+		Migrations    map[migrationTypes.DiagnosticStatus]int `json:"migrations,omitempty"`
+		Diagnostics   []*migrationTypes.Diagnostic            `json:"diagnostics,omitempty"`
+		UnknownTables []string                                `json:"unknown_tables,omitempty"`
+	}
+)
+
+func NewReport() *Report {
+	return &Report{
+		StartedAt: time.Now(),
+		Projects:  []*ProjectReport{},
+	}
+}
+
+func (r *Report) AddProject(origin, name, ref string, err error, diagnostics []*migrationTypes.Diagnostic, unknownTables []string) {
+	project := &ProjectReport{
+		Origin:        origin,
+		Name:          name,
+		Ref:           ref,
+		Migrations:    map[migrationTypes.DiagnosticStatus]int{},
+		UnknownTables: unknownTables,
+	}
+
+	if err != nil {
+		project.Error = err.Error()
+	}
+
+	for _, diagnostic := range diagnostics {
+		project.Migrations[diagnostic.Status]++
+
+		if diagnostic.Status == migrationTypes.DiagnosticError || diagnostic.Status == migrationTypes.DiagnosticUnsupported {
+			project.Diagnostics = append(project.Diagnostics, diagnostic)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.Projects = append(r.Projects, project)
+}
+
+func (r *Report) Finish() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.FinishedAt = time.Now()
+}
+
+func (r *Report) SetRequestStats(stats *RequestStats) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.Requests = stats
+}
+
+func (r *Report) Failed() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	failed := 0
+	for _, project := range r.Projects {
+		if project.Error != "" {
+			failed++
+		}
+	}
+
+	return failed
+}
+
+func (r *Report) MigrationCounts() map[migrationTypes.DiagnosticStatus]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	counts := map[migrationTypes.DiagnosticStatus]int{}
+	for _, project := range r.Projects {
+		for status, count := range project.Migrations {
+			counts[status] += count
+		}
+	}
+
+	return counts
+}
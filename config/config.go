@@ -18,16 +18,43 @@ type (
 		Port string `yaml:"port"`
 	}
 	CollectorConfig struct {
-		ParallelJobs       int64                  `yaml:"parallel_jobs"`
-		ServiceConfigPaths []string               `yaml:"service_config_paths"`
-		MigrationPaths     []string               `yaml:"migration_paths"`
-		GitLab             *GitLabCollectorConfig `yaml:"gitlab"`
+		ParallelJobs       int64                    `yaml:"parallel_jobs"`
+		ServiceConfigPaths []string                 `yaml:"service_config_paths"`
+		MigrationPaths     []string                 `yaml:"migration_paths"`
+		CodePaths          []string                 `yaml:"code_paths"`
+		ManifestPaths      []string                 `yaml:"manifest_paths"`
+		PipelinePath       string                   `yaml:"pipeline_path"`
+		Files              *FilesConfig             `yaml:"files"`
+		GitLab             *GitLabCollectorConfig   `yaml:"gitlab"`
+		Endpoints          []*GitLabCollectorConfig `yaml:"endpoints"`
+		ServiceResolver    *ServiceResolverConfig   `yaml:"service_resolver"`
+		HostAliasesPath    string                   `yaml:"host_aliases_path"`
+	}
+	FilesConfig struct {
+		Mode        string `yaml:"mode"`
+		LocalPath   string `yaml:"local_path"`
+		MaxArchives int    `yaml:"max_archives"`
+	}
+	ServiceResolverConfig struct {
+		Aliases     map[string]string `yaml:"aliases"`
+		DNSSuffixes []string          `yaml:"dns_suffixes"`
 	}
 	GitLabCollectorConfig struct {
+		Name           string                `yaml:"name"`
+		Provider       string                `yaml:"provider"`
 		Client         *GitLabClientConfig   `yaml:"client"`
 		Groups         []string              `yaml:"groups"`
+		Subgroups      bool                  `yaml:"subgroups"`
+		Projects       *ProjectFilterConfig  `yaml:"projects"`
+		Files          *FilesConfig          `yaml:"files"`
 		ReleaseProject *ReleaseProjectConfig `yaml:"release_project"`
 	}
+	ProjectFilterConfig struct {
+		Include       []string `yaml:"include"`
+		Exclude       []string `yaml:"exclude"`
+		Topics        []string `yaml:"topics"`
+		ExcludeTopics []string `yaml:"exclude_topics"`
+	}
 	ReleaseProjectConfig struct {
 		Project         string `yaml:"project"`
 		ReleaseFilePath string `yaml:"release_file_path"`
@@ -35,16 +62,27 @@ type (
 		ParseConfigPath string `yaml:"parse_config_path"`
 	}
 	StorageConfig struct {
-		Host     string `yaml:"host"`
-		Port     string `yaml:"port"`
-		User     string `yaml:"user"`
-		Name     string `yaml:"name"`
-		Password string `yaml:"password"`
+		Host     string     `yaml:"host"`
+		Port     string     `yaml:"port"`
+		User     string     `yaml:"user"`
+		Name     string     `yaml:"name"`
+		Password string     `yaml:"password"`
+		TLS      *TLSConfig `yaml:"tls"`
+	}
+	TLSConfig struct {
+		Verify   *bool  `yaml:"verify"`
+		CAFile   string `yaml:"ca_file"`
+		CertFile string `yaml:"cert_file"`
+		KeyFile  string `yaml:"key_file"`
 	}
 	SourcesConfig struct {
-		Yaml      *YamlSourceConfig      `yaml:"yaml"`
-		GitLab    *GitSourceConfig       `yaml:"gitlab"`
-		Migration *MigrationSourceConfig `yaml:"migration"`
+		Yaml       *YamlSourceConfig       `yaml:"yaml"`
+		GitLab     *GitSourceConfig        `yaml:"gitlab"`
+		Migration  *MigrationSourceConfig  `yaml:"migration"`
+		Code       *CodeSourceConfig       `yaml:"code"`
+		Kubernetes *KubernetesSourceConfig `yaml:"kubernetes"`
+		Pipeline   *PipelineSourceConfig   `yaml:"pipeline"`
+		Owners     *OwnersSourceConfig     `yaml:"owners"`
 	}
 	YamlSourceConfig struct {
 		ParseConfigPath string `yaml:"parse_config_path"`
@@ -52,20 +90,65 @@ type (
 		Weight          int64  `yaml:"weight"`
 	}
 	GitLabClientConfig struct {
-		Token           string `yaml:"token"`
-		BaseURL         string `yaml:"base_url"`
-		UseArchived     bool   `yaml:"use_archived"`
-		GitlabPerPage   int64  `yaml:"per_page"`
-		GitlabTimeout   int64  `yaml:"timeout"`
-		GitLabRateLimit int64  `yaml:"rate_limit"`
-		GitlabAPIPrefix string `yaml:"api_prefix"`
+		Token              string                                `yaml:"token"`
+		BaseURL            string                                `yaml:"base_url"`
+		UseArchived        bool                                  `yaml:"use_archived"`
+		GitlabPerPage      int64                                 `yaml:"per_page"`
+		GitlabTimeout      int64                                 `yaml:"timeout"`
+		GitLabRateLimit    int64                                 `yaml:"rate_limit"`
+		RateBurst          int64                                 `yaml:"rate_burst"`
+		EndpointRateLimits map[string]*GitLabEndpointLimitConfig `yaml:"endpoint_rate_limits"`
+		GitlabAPIPrefix    string                                `yaml:"api_prefix"`
+		Retry              *GitLabRetryConfig                    `yaml:"retry"`
+		Cache              *GitLabCacheConfig                    `yaml:"cache"`
+		TLS                *TLSConfig                            `yaml:"tls"`
+	}
+	GitLabCacheConfig struct {
+		Path    string `yaml:"path"`
+		MaxSize int64  `yaml:"max_size"`
+	}
+	GitLabEndpointLimitConfig struct {
+		RateLimit int64 `yaml:"rate_limit"`
+		Burst     int64 `yaml:"burst"`
+	}
+	GitLabRetryConfig struct {
+		MaxAttempts int   `yaml:"max_attempts"`
+		MinBackoff  int64 `yaml:"min_backoff"`
+		MaxBackoff  int64 `yaml:"max_backoff"`
 	}
 	MigrationSourceConfig struct {
+		Weight       int64             `yaml:"weight"`
+		Dialect      string            `yaml:"dialect"`
+		PathDialects map[string]string `yaml:"path_dialects"`
+		Version      string            `yaml:"version"`
+	}
+	CodeSourceConfig struct {
+		Weight       int64    `yaml:"weight"`
+		Extensions   []string `yaml:"extensions"`
+		ExcludePaths []string `yaml:"exclude_paths"`
+		MaxFileSize  int64    `yaml:"max_file_size"`
+	}
+	KubernetesSourceConfig struct {
 		Weight int64 `yaml:"weight"`
 	}
+	PipelineSourceConfig struct {
+		Weight      int64 `yaml:"weight"`
+		MaxIncludes int   `yaml:"max_includes"`
+	}
+	OwnersSourceConfig struct {
+		Weight          int64  `yaml:"weight"`
+		MappingPath     string `yaml:"mapping_path"`
+		OwnersPath      string `yaml:"owners_path"`
+		TopContributors int    `yaml:"top_contributors"`
+	}
 	GitSourceConfig struct {
-		Client *GitLabClientConfig `yaml:"client"`
-		Weight int64               `yaml:"weight"`
+		Client *GitLabClientConfig  `yaml:"client"`
+		Weight int64                `yaml:"weight"`
+		Status *ServiceStatusConfig `yaml:"status"`
+	}
+	ServiceStatusConfig struct {
+		StaleAfterDays    int64 `yaml:"stale_after_days"`
+		ArchivedAfterDays int64 `yaml:"archived_after_days"`
 	}
 )
 
@@ -107,5 +190,56 @@ func (c *Config) Unmarshal(data []byte) (err error) {
 		return fmt.Errorf("config: Storage.password is empty")
 	}
 
+	if c.Collector != nil {
+		if err := c.Collector.validateEndpoints(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SCMEndpoints returns the endpoints projects are collected from, the gitlab
+// block is the single unnamed endpoint of older configs.
+func (c *CollectorConfig) SCMEndpoints() []*GitLabCollectorConfig {
+	if len(c.Endpoints) > 0 {
+		return c.Endpoints
+	}
+	if c.GitLab != nil {
+		return []*GitLabCollectorConfig{c.GitLab}
+	}
+
+	return nil
+}
+
+func (c *CollectorConfig) validateEndpoints() error {
+	if len(c.Endpoints) == 0 {
+		return nil
+	}
+	if c.GitLab != nil {
+		return fmt.Errorf("config: Collector.gitlab and Collector.endpoints can not be set together")
+	}
+
+	names := map[string]bool{}
+	releaseProjects := 0
+
+	for _, endpoint := range c.Endpoints {
+		if endpoint.Name == "" {
+			return fmt.Errorf("config: Collector.endpoints name is empty")
+		}
+		if names[endpoint.Name] {
+			return fmt.Errorf("config: Collector.endpoints name %s is not unique", endpoint.Name)
+		}
+		names[endpoint.Name] = true
+
+		if endpoint.ReleaseProject != nil {
+			releaseProjects++
+		}
+	}
+
+	if releaseProjects > 1 {
+		return fmt.Errorf("config: Collector.endpoints has more than one release_project")
+	}
+
 	return nil
 }
@@ -7,3 +7,13 @@ func Ptr[T any](v T) *T {
 func DePtr[T any](v *T) T {
 	return *v
 }
+
+// Value returns the zero value of T for nil.
+func Value[T any](v *T) T {
+	var zero T
+	if v == nil {
+		return zero
+	}
+
+	return *v
+}
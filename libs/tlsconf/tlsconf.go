@@ -0,0 +1,63 @@
+package tlsconf
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"vislab/config"
+)
+
+// New builds the client tls config. Certificates are verified against the
+// system pool unless a CA file is set, verification is only skipped when it
+// is turned off explicitly.
+func New(conf *config.TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if conf == nil {
+		return tlsConfig, nil
+	}
+
+	if !Verify(conf) {
+		if conf.CAFile != "" {
+			return nil, fmt.Errorf("tls ca_file is set but verify is off, remove one of them")
+		}
+
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	if conf.CAFile != "" {
+		caData, err := os.ReadFile(conf.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tls ca_file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caData) {
+			return nil, fmt.Errorf("tls ca_file %s contains no PEM certificates", conf.CAFile)
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if (conf.CertFile == "") != (conf.KeyFile == "") {
+		return nil, fmt.Errorf("tls cert_file and key_file must be set together")
+	}
+
+	if conf.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(conf.CertFile, conf.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tls client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+func Verify(conf *config.TLSConfig) bool {
+	return conf == nil || conf.Verify == nil || *conf.Verify
+}
@@ -3,23 +3,30 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"strings"
+	"text/tabwriter"
 	gitlabcollector "vislab/collector/gitlab"
 	"vislab/config"
-	"vislab/sources/gitlab"
+	storefuncs "vislab/storage/middleware"
 	"vislab/storage/neo4j"
+	storeTypes "vislab/storage/neo4j/types"
 	defaultupdater "vislab/updater/default"
 )
 
 var (
-	confFile string
-	debug    bool
+	confFile     string
+	debug        bool
+	sharedReport bool
 )
 
 func init() {
 	flag.StringVar(&confFile, "conf", "./config.yaml", "Path to config file")
 	flag.BoolVar(&debug, "debug", false, "Debug mode")
+	flag.BoolVar(&sharedReport, "shared", false, "Print postgres resources shared between services and exit")
 }
 
 func main() {
@@ -50,12 +57,20 @@ func main() {
 		panic(err)
 	}
 
-	gitlabOptions := gitlab.GetOptions(config.Collector.GitLab.Client)
+	if sharedReport {
+		shared, err := storefuncs.DetectSharing(ctx, store)
+		if err != nil {
+			slog.Error("failed to detect shared resources", "err", err)
+			panic(err)
+		}
 
-	gitlabClient, err := gitlab.NewClient(config.Collector.GitLab.Client.Token, config.Collector.GitLab.Client.BaseURL, gitlabOptions...)
-	if err != nil {
-		slog.Error("failed to create gitlab client", "err", err)
-		panic(err)
+		printSharedReport(os.Stdout, shared)
+
+		if err := store.Disconnect(ctx); err != nil {
+			slog.Error("failed to disconnect from storage", "err", err)
+			panic(err)
+		}
+		return
 	}
 
 	collectorOptions, err := gitlabcollector.GetOptions(config.Collector, config.Sources)
@@ -64,7 +79,7 @@ func main() {
 		panic(err)
 	}
 
-	collector, err := gitlabcollector.New(gitlabClient, store, collectorOptions...)
+	collector, err := gitlabcollector.New(store, collectorOptions...)
 	if err != nil {
 		slog.Error("failed to create collector", "err", err)
 		panic(err)
@@ -92,3 +107,14 @@ func main() {
 		panic(err)
 	}
 }
+
+func printSharedReport(out io.Writer, shared []*storeTypes.SharedResource) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	defer w.Flush()
+
+	fmt.Fprintln(w, "KIND\tRESOURCE\tOWNERS\tUSED BY")
+
+	for _, resource := range shared {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", resource.Node.Class, resource.Path(), strings.Join(resource.Owners, ","), strings.Join(resource.Users, ","))
+	}
+}
@@ -0,0 +1,81 @@
+package resolver
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type HostRegistry struct {
+	aliases map[string]string
+}
+
+func NewHostRegistry(instances map[string][]string) (*HostRegistry, error) {
+	r := &HostRegistry{
+		aliases: map[string]string{},
+	}
+
+	errs := []error{}
+
+	for canonical := range instances {
+		r.aliases[normalizeHost(canonical)] = canonical
+	}
+
+	for canonical, aliases := range instances {
+		for _, alias := range aliases {
+			normalized := normalizeHost(alias)
+			if normalized == "" {
+				continue
+			}
+
+			existing, ok := r.aliases[normalized]
+			if ok && existing != canonical {
+				errs = append(errs, fmt.Errorf("alias %s is defined for both %s and %s", alias, existing, canonical))
+				continue
+			}
+
+			r.aliases[normalized] = canonical
+		}
+	}
+
+	if len(errs) != 0 {
+		return nil, fmt.Errorf("conflicting host aliases: %w", errors.Join(errs...))
+	}
+
+	return r, nil
+}
+
+func LoadHostRegistry(path string) (*HostRegistry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	instances := map[string][]string{}
+
+	if err := yaml.Unmarshal(data, &instances); err != nil {
+		return nil, fmt.Errorf("failed to parse host aliases file: %w", err)
+	}
+
+	return NewHostRegistry(instances)
+}
+
+// Resolve returns the canonical instance for a host, or the host itself when no alias is registered.
+func (r *HostRegistry) Resolve(host string) string {
+	if r == nil {
+		return host
+	}
+
+	if canonical, ok := r.aliases[normalizeHost(host)]; ok {
+		return canonical
+	}
+
+	return host
+}
+
+func normalizeHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+}
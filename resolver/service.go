@@ -0,0 +1,156 @@
+package resolver
+
+import (
+	"slices"
+	"strings"
+	"vislab/config"
+)
+
+var defaultDNSSuffixes = []string{
+	".svc.cluster.local",
+	".svc",
+}
+
+type ServiceResolver struct {
+	aliases     map[string]string
+	dnsSuffixes []string
+	services    map[string]string
+	origins     map[string][]string
+}
+
+func NewServiceResolver(config *config.ServiceResolverConfig) (*ServiceResolver, error) {
+	r := &ServiceResolver{
+		aliases:     map[string]string{},
+		dnsSuffixes: slices.Clone(defaultDNSSuffixes),
+		services:    map[string]string{},
+		origins:     map[string][]string{},
+	}
+
+	for _, suffix := range config.DNSSuffixes {
+		suffix = strings.ToLower(strings.TrimSpace(suffix))
+		if suffix == "" {
+			continue
+		}
+		if !strings.HasPrefix(suffix, ".") {
+			suffix = "." + suffix
+		}
+
+		r.dnsSuffixes = append(r.dnsSuffixes, suffix)
+	}
+
+	for alias, service := range config.Aliases {
+		r.aliases[r.Normalize(alias)] = service
+	}
+
+	return r, nil
+}
+
+// AddService registers the service of the scm endpoint origin, a name can be
+// known from several endpoints.
+func (r *ServiceResolver) AddService(name, fullName *string, origin string) {
+	if name == nil {
+		return
+	}
+
+	r.services[r.Normalize(*name)] = *name
+
+	if !slices.Contains(r.origins[*name], origin) {
+		r.origins[*name] = append(r.origins[*name], origin)
+	}
+
+	if fullName != nil {
+		r.services[strings.ToLower(*fullName)] = *name
+
+		parts := strings.Split(*fullName, "/")
+		r.services[r.Normalize(parts[len(parts)-1])] = *name
+	}
+}
+
+// AddHost registers an external host of a service, e.g. an ingress host.
+func (r *ServiceResolver) AddHost(host, service string) {
+	if host == "" || service == "" {
+		return
+	}
+
+	r.services[r.Normalize(host)] = service
+}
+
+// Resolve returns the canonical service name for a host and whether that service is known.
+func (r *ServiceResolver) Resolve(host string) (string, bool) {
+	normalized := r.Normalize(host)
+
+	if alias, ok := r.aliases[normalized]; ok {
+		if service, ok := r.services[r.Normalize(alias)]; ok {
+			return service, true
+		}
+
+		return alias, false
+	}
+
+	if service, ok := r.services[normalized]; ok {
+		return service, true
+	}
+
+	return normalized, false
+}
+
+// Origin returns the endpoint of a resolved service. When the name is known
+// from several endpoints the preferred one wins, it is the origin of the
+// calling service as services mostly talk to services of the same instance.
+func (r *ServiceResolver) Origin(service, preferred string) string {
+	origins := r.origins[service]
+
+	if len(origins) == 0 || slices.Contains(origins, preferred) {
+		return preferred
+	}
+
+	return origins[0]
+}
+
+func (r *ServiceResolver) Normalize(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+
+	if _, after, ok := strings.Cut(host, "://"); ok {
+		host = after
+	}
+	if _, after, ok := strings.Cut(host, "@"); ok {
+		host = after
+	}
+	if before, _, ok := strings.Cut(host, "/"); ok {
+		host = before
+	}
+	if before, after, ok := strings.Cut(host, ":"); ok && isNumber(after) {
+		host = before
+	}
+
+	host = strings.TrimSuffix(host, ".")
+
+	for _, suffix := range r.dnsSuffixes {
+		if !strings.HasSuffix(host, suffix) {
+			continue
+		}
+
+		// what is left is service.namespace, custom suffixes are cluster
+		// domains like the defaults
+		host = strings.TrimSuffix(host, suffix)
+		host, _, _ = strings.Cut(host, ".")
+
+		break
+	}
+
+	return host
+}
+
+func isNumber(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,202 @@
+package code
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+var (
+	queryPrefixes = []string{"SELECT", "INSERT", "UPDATE", "DELETE", "WITH"}
+
+	// placeholders of database drivers are replaced with postgres ones,
+	// pg_query can not parse the others
+	questionPlaceholder = regexp.MustCompile(`\?`)
+	printfPlaceholder   = regexp.MustCompile(`%(\([A-Za-z_]\w*\))?[sdv]`)
+	namedPlaceholder    = regexp.MustCompile(`(^|[^:\w]):[A-Za-z_]\w*`)
+	atPlaceholder       = regexp.MustCompile(`(^|[^@\w])@[A-Za-z_]\w*`)
+)
+
+type literalSyntax struct {
+	lineComment  string
+	quotes       []string
+	skipQuotes   []string
+	concatenator string
+}
+
+var literalSyntaxes = map[string]*literalSyntax{
+	".go": {
+		lineComment:  "//",
+		quotes:       []string{"`", `"`},
+		skipQuotes:   []string{"'"},
+		concatenator: "+",
+	},
+	".java": {
+		lineComment:  "//",
+		quotes:       []string{`"""`, `"`},
+		skipQuotes:   []string{"'"},
+		concatenator: "+",
+	},
+	".py": {
+		lineComment: "#",
+		quotes:      []string{`"""`, `'''`, `"`, `'`},
+	},
+}
+
+// extractQueries returns the SQL statements found in the file. SQL files are
+// used as is, for source files adjacent string literals are joined first.
+func extractQueries(filePath string, in []byte) []string {
+	extension := strings.ToLower(path.Ext(filePath))
+
+	if extension == ".sql" {
+		return []string{string(in)}
+	}
+
+	syntax, ok := literalSyntaxes[extension]
+	if !ok {
+		return nil
+	}
+
+	queries := []string{}
+	for _, literal := range extractLiterals(string(in), syntax) {
+		if !isQuery(literal) {
+			continue
+		}
+
+		queries = append(queries, normalizePlaceholders(literal))
+	}
+
+	return queries
+}
+
+func extractLiterals(in string, syntax *literalSyntax) []string {
+	literals := []string{}
+
+	var (
+		current   strings.Builder
+		inLiteral bool
+	)
+
+	flush := func() {
+		if inLiteral {
+			literals = append(literals, current.String())
+			current.Reset()
+			inLiteral = false
+		}
+	}
+
+	for i := 0; i < len(in); {
+		switch {
+		case strings.HasPrefix(in[i:], syntax.lineComment):
+			end := strings.IndexByte(in[i:], '\n')
+			if end == -1 {
+				end = len(in) - i
+			}
+			i += end
+			continue
+		case syntax.lineComment == "//" && strings.HasPrefix(in[i:], "/*"):
+			end := strings.Index(in[i+2:], "*/")
+			if end == -1 {
+				end = len(in) - i - 2
+			}
+			i += end + 4
+			continue
+		}
+
+		if quote := matchQuote(in[i:], syntax.skipQuotes); quote != "" {
+			_, end := readLiteral(in[i:], quote)
+			i += end
+			continue
+		}
+
+		if quote := matchQuote(in[i:], syntax.quotes); quote != "" {
+			value, end := readLiteral(in[i:], quote)
+			current.WriteString(value)
+			inLiteral = true
+			i += end
+			continue
+		}
+
+		// literals split across lines are joined while only whitespace and
+		// the concatenation operator are between them
+		switch {
+		case in[i] == ' ' || in[i] == '\t' || in[i] == '\n' || in[i] == '\r':
+		case syntax.concatenator != "" && strings.HasPrefix(in[i:], syntax.concatenator):
+		default:
+			flush()
+		}
+
+		i++
+	}
+
+	flush()
+
+	return literals
+}
+
+func matchQuote(in string, quotes []string) string {
+	for _, quote := range quotes {
+		if strings.HasPrefix(in, quote) {
+			return quote
+		}
+	}
+
+	return ""
+}
+
+// readLiteral returns the literal value and the length of the literal
+// including the quotes.
+func readLiteral(in, quote string) (string, int) {
+	var value strings.Builder
+
+	escapable := quote != "`" && len(quote) == 1
+
+	for i := len(quote); i < len(in); i++ {
+		if strings.HasPrefix(in[i:], quote) {
+			return value.String(), i + len(quote)
+		}
+
+		if in[i] == '\\' && escapable && i+1 < len(in) {
+			switch in[i+1] {
+			case 'n', 'r', 't':
+				value.WriteByte(' ')
+			default:
+				value.WriteByte(in[i+1])
+			}
+			i++
+			continue
+		}
+
+		if in[i] == '\n' && escapable {
+			return value.String(), i
+		}
+
+		value.WriteByte(in[i])
+	}
+
+	return value.String(), len(in)
+}
+
+func isQuery(literal string) bool {
+	fields := strings.Fields(literal)
+	if len(fields) < 2 {
+		return false
+	}
+
+	for _, prefix := range queryPrefixes {
+		if strings.EqualFold(fields[0], prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func normalizePlaceholders(query string) string {
+	query = questionPlaceholder.ReplaceAllString(query, "$$1")
+	query = printfPlaceholder.ReplaceAllString(query, "$$1")
+	query = namedPlaceholder.ReplaceAllString(query, "${1}$$1")
+	query = atPlaceholder.ReplaceAllString(query, "${1}$$1")
+
+	return query
+}
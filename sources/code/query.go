@@ -0,0 +1,198 @@
+package code
+
+import (
+	"slices"
+
+	pg_query "github.com/pganalyze/pg_query_go/v5"
+)
+
+type (
+	relation struct {
+		schema string
+		name   string
+	}
+
+	queryUsage struct {
+		reads  []relation
+		writes []relation
+		ctes   []string
+	}
+)
+
+func parseQuery(query string) (*queryUsage, error) {
+	stmts, err := pg_query.Parse(query)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &queryUsage{}
+
+	for _, stmt := range stmts.Stmts {
+		usage.collectStmt(stmt.Stmt)
+	}
+
+	usage.reads = slices.DeleteFunc(usage.reads, usage.isCte)
+	usage.writes = slices.DeleteFunc(usage.writes, usage.isCte)
+
+	return usage, nil
+}
+
+func (u *queryUsage) isCte(r relation) bool {
+	return r.schema == "public" && slices.Contains(u.ctes, r.name)
+}
+
+func (u *queryUsage) collectStmt(node *pg_query.Node) {
+	if node == nil {
+		return
+	}
+
+	switch stmt := node.Node.(type) {
+	case *pg_query.Node_SelectStmt:
+		u.collectSelect(stmt.SelectStmt)
+	case *pg_query.Node_InsertStmt:
+		u.collectWith(stmt.InsertStmt.WithClause)
+		u.addWrite(stmt.InsertStmt.Relation)
+		u.collectStmt(stmt.InsertStmt.SelectStmt)
+	case *pg_query.Node_UpdateStmt:
+		u.collectWith(stmt.UpdateStmt.WithClause)
+		u.addWrite(stmt.UpdateStmt.Relation)
+		for _, target := range stmt.UpdateStmt.TargetList {
+			if targetNode, ok := target.Node.(*pg_query.Node_ResTarget); ok {
+				u.collectExpr(targetNode.ResTarget.Val)
+			}
+		}
+		for _, from := range stmt.UpdateStmt.FromClause {
+			u.collectFrom(from)
+		}
+		u.collectExpr(stmt.UpdateStmt.WhereClause)
+	case *pg_query.Node_DeleteStmt:
+		u.collectWith(stmt.DeleteStmt.WithClause)
+		u.addWrite(stmt.DeleteStmt.Relation)
+		for _, using := range stmt.DeleteStmt.UsingClause {
+			u.collectFrom(using)
+		}
+		u.collectExpr(stmt.DeleteStmt.WhereClause)
+	}
+}
+
+func (u *queryUsage) collectWith(with *pg_query.WithClause) {
+	if with == nil {
+		return
+	}
+
+	for _, cte := range with.Ctes {
+		cteNode, ok := cte.Node.(*pg_query.Node_CommonTableExpr)
+		if !ok {
+			continue
+		}
+
+		u.ctes = append(u.ctes, cteNode.CommonTableExpr.Ctename)
+		u.collectStmt(cteNode.CommonTableExpr.Ctequery)
+	}
+}
+
+func (u *queryUsage) collectSelect(stmt *pg_query.SelectStmt) {
+	if stmt == nil {
+		return
+	}
+
+	u.collectWith(stmt.WithClause)
+
+	if stmt.Op != pg_query.SetOperation_SETOP_NONE {
+		u.collectSelect(stmt.Larg)
+		u.collectSelect(stmt.Rarg)
+		return
+	}
+
+	for _, target := range stmt.TargetList {
+		if targetNode, ok := target.Node.(*pg_query.Node_ResTarget); ok {
+			u.collectExpr(targetNode.ResTarget.Val)
+		}
+	}
+
+	for _, from := range stmt.FromClause {
+		u.collectFrom(from)
+	}
+
+	u.collectExpr(stmt.WhereClause)
+	u.collectExpr(stmt.HavingClause)
+}
+
+func (u *queryUsage) collectFrom(node *pg_query.Node) {
+	if node == nil {
+		return
+	}
+
+	switch fromNode := node.Node.(type) {
+	case *pg_query.Node_RangeVar:
+		u.addRead(fromNode.RangeVar)
+	case *pg_query.Node_JoinExpr:
+		u.collectFrom(fromNode.JoinExpr.Larg)
+		u.collectFrom(fromNode.JoinExpr.Rarg)
+		u.collectExpr(fromNode.JoinExpr.Quals)
+	case *pg_query.Node_RangeSubselect:
+		u.collectStmt(fromNode.RangeSubselect.Subquery)
+	}
+}
+
+func (u *queryUsage) collectExpr(node *pg_query.Node) {
+	if node == nil {
+		return
+	}
+
+	switch exprNode := node.Node.(type) {
+	case *pg_query.Node_SubLink:
+		u.collectStmt(exprNode.SubLink.Subselect)
+		u.collectExpr(exprNode.SubLink.Testexpr)
+	case *pg_query.Node_BoolExpr:
+		for _, arg := range exprNode.BoolExpr.Args {
+			u.collectExpr(arg)
+		}
+	case *pg_query.Node_AExpr:
+		u.collectExpr(exprNode.AExpr.Lexpr)
+		u.collectExpr(exprNode.AExpr.Rexpr)
+	case *pg_query.Node_FuncCall:
+		for _, arg := range exprNode.FuncCall.Args {
+			u.collectExpr(arg)
+		}
+	case *pg_query.Node_CoalesceExpr:
+		for _, arg := range exprNode.CoalesceExpr.Args {
+			u.collectExpr(arg)
+		}
+	case *pg_query.Node_TypeCast:
+		u.collectExpr(exprNode.TypeCast.Arg)
+	case *pg_query.Node_List:
+		for _, item := range exprNode.List.Items {
+			u.collectExpr(item)
+		}
+	}
+}
+
+func (u *queryUsage) addRead(rangeVar *pg_query.RangeVar) {
+	if r, ok := newRelation(rangeVar); ok && !slices.Contains(u.reads, r) {
+		u.reads = append(u.reads, r)
+	}
+}
+
+func (u *queryUsage) addWrite(rangeVar *pg_query.RangeVar) {
+	if r, ok := newRelation(rangeVar); ok && !slices.Contains(u.writes, r) {
+		u.writes = append(u.writes, r)
+	}
+}
+
+func newRelation(rangeVar *pg_query.RangeVar) (relation, bool) {
+	if rangeVar == nil || rangeVar.Relname == "" {
+		return relation{}, false
+	}
+
+	r := relation{
+		schema: rangeVar.Schemaname,
+		name:   rangeVar.Relname,
+	}
+
+	if r.schema == "" {
+		r.schema = "public"
+	}
+
+	return r, true
+}
@@ -0,0 +1,125 @@
+package code
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"vislab/config"
+	"vislab/sources/code/types"
+)
+
+var defaultExtensions = []string{".go", ".java", ".py", ".sql"}
+
+// defaultExcludeDirs are skipped at any depth, their queries belong to
+// dependencies and tests rather than the service itself.
+var defaultExcludeDirs = []string{"vendor", "node_modules", "testdata", "fixtures"}
+
+type Source struct {
+	weight       int64
+	extensions   []string
+	excludePaths []string
+	maxFileSize  int64
+}
+
+func NewSource(config *config.CodeSourceConfig) (*Source, error) {
+	s := &Source{
+		weight:       config.Weight,
+		extensions:   defaultExtensions,
+		excludePaths: []string{},
+		maxFileSize:  config.MaxFileSize,
+	}
+
+	for _, excludePath := range config.ExcludePaths {
+		s.excludePaths = append(s.excludePaths, path.Clean(excludePath))
+	}
+
+	if len(config.Extensions) != 0 {
+		s.extensions = []string{}
+		for _, extension := range config.Extensions {
+			if !strings.HasPrefix(extension, ".") {
+				extension = "." + extension
+			}
+
+			s.extensions = append(s.extensions, strings.ToLower(extension))
+		}
+	}
+
+	return s, nil
+}
+
+func (s *Source) Weight() int64 {
+	return s.weight
+}
+
+// ExcludePaths skips the given paths in addition to the configured ones.
+func (s *Source) ExcludePaths(paths ...string) {
+	for _, excludePath := range paths {
+		excludePath = path.Clean(excludePath)
+		if excludePath == "." || excludePath == "/" {
+			continue
+		}
+
+		s.excludePaths = append(s.excludePaths, excludePath)
+	}
+}
+
+// Match reports whether the file should be scanned for queries.
+func (s *Source) Match(filePath string) bool {
+	for _, excludePath := range s.excludePaths {
+		if filePath == excludePath || strings.HasPrefix(filePath, excludePath+"/") {
+			return false
+		}
+	}
+
+	for _, dir := range strings.Split(path.Dir(filePath), "/") {
+		if slices.Contains(defaultExcludeDirs, dir) {
+			return false
+		}
+	}
+
+	return slices.Contains(s.extensions, strings.ToLower(path.Ext(filePath)))
+}
+
+func (s *Source) GetData(ctx context.Context, filePath string, in []byte, out *types.All) error {
+	if s.maxFileSize > 0 && int64(len(in)) > s.maxFileSize {
+		return fmt.Errorf("file %s is larger than %d bytes", filePath, s.maxFileSize)
+	}
+
+	for _, query := range extractQueries(filePath, in) {
+		usage, err := parseQuery(query)
+		if err != nil {
+			continue
+		}
+
+		for _, relation := range usage.reads {
+			addTable(out, relation, filePath).Read = true
+		}
+		for _, relation := range usage.writes {
+			addTable(out, relation, filePath).Write = true
+		}
+	}
+
+	return nil
+}
+
+func addTable(out *types.All, relation relation, filePath string) *types.Table {
+	key := relation.schema + "." + relation.name
+
+	table, ok := out.Tables[key]
+	if !ok {
+		table = &types.Table{
+			Schema: relation.schema,
+			Name:   relation.name,
+			Files:  []string{},
+		}
+		out.Tables[key] = table
+	}
+
+	if !slices.Contains(table.Files, filePath) {
+		table.Files = append(table.Files, filePath)
+	}
+
+	return table
+}
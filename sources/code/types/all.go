@@ -0,0 +1,21 @@
+package types
+
+type (
+	All struct {
+		Tables map[string]*Table
+	}
+
+	Table struct {
+		Schema string
+		Name   string
+		Read   bool
+		Write  bool
+		Files  []string
+	}
+)
+
+func NewAll() *All {
+	return &All{
+		Tables: make(map[string]*Table),
+	}
+}
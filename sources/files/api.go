@@ -0,0 +1,25 @@
+package files
+
+import (
+	"context"
+	"vislab/sources/scm"
+)
+
+// APIProvider reads every file with its own request to the scm api.
+type APIProvider struct {
+	scm scm.Provider
+}
+
+func NewAPIProvider(scm scm.Provider) *APIProvider {
+	return &APIProvider{
+		scm: scm,
+	}
+}
+
+func (p *APIProvider) ListDir(ctx context.Context, projectId int64, ref, dir string) ([]string, error) {
+	return p.scm.ListDir(ctx, projectId, ref, dir)
+}
+
+func (p *APIProvider) Get(ctx context.Context, projectId int64, ref, filePath string) ([]byte, error) {
+	return p.scm.GetFile(ctx, projectId, ref, filePath)
+}
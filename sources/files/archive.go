@@ -0,0 +1,197 @@
+package files
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"vislab/sources/gitlab"
+	"vislab/sources/scm"
+)
+
+const defaultMaxArchives = 4
+
+// ArchiveProvider downloads one archive per listed directory of a project at
+// a commit and reads the files from it, providers which can not archive a
+// directory get one archive of the whole repository. Files outside of
+// downloaded directories are read through the files api.
+type ArchiveProvider struct {
+	scm         scm.Provider
+	api         *APIProvider
+	maxArchives int
+
+	mu       sync.Mutex
+	archives []*archive
+}
+
+type archive struct {
+	projectId int64
+	sha       string
+	dir       string
+	files     map[string][]byte
+}
+
+func NewArchiveProvider(scm scm.Provider, maxArchives int) *ArchiveProvider {
+	if maxArchives <= 0 {
+		maxArchives = defaultMaxArchives
+	}
+
+	return &ArchiveProvider{
+		scm:         scm,
+		api:         NewAPIProvider(scm),
+		maxArchives: maxArchives,
+	}
+}
+
+func (p *ArchiveProvider) ListDir(ctx context.Context, projectId int64, ref, dir string) ([]string, error) {
+	dir = strings.Trim(dir, "/")
+	sha := p.resolveRef(ctx, projectId, ref)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	archive := p.find(projectId, sha, dir)
+	if archive == nil {
+		archiveDir := dir
+		if !p.scm.ArchivesByPath() {
+			archiveDir = ""
+		}
+
+		var err error
+		archive, err = p.download(ctx, projectId, sha, archiveDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	paths := []string{}
+	for filePath := range archive.files {
+		if inDir(filePath, dir) {
+			paths = append(paths, filePath)
+		}
+	}
+	slices.Sort(paths)
+
+	return paths, nil
+}
+
+func (p *ArchiveProvider) Get(ctx context.Context, projectId int64, ref, filePath string) ([]byte, error) {
+	filePath = strings.Trim(filePath, "/")
+	sha := p.resolveRef(ctx, projectId, ref)
+
+	p.mu.Lock()
+	archive := p.find(projectId, sha, filePath)
+	p.mu.Unlock()
+
+	if archive == nil {
+		return p.api.Get(ctx, projectId, ref, filePath)
+	}
+
+	data, ok := archive.files[filePath]
+	if !ok {
+		return nil, gitlab.ErrNotFound
+	}
+
+	return data, nil
+}
+
+// resolveRef keys archives by commit, so a moved branch is downloaded again.
+// When the ref can not be resolved it is used as it is.
+func (p *ArchiveProvider) resolveRef(ctx context.Context, projectId int64, ref string) string {
+	sha, err := p.scm.ResolveRef(ctx, projectId, ref)
+	if err != nil {
+		slog.Debug("failed to resolve ref for archive", "service_id", projectId, "ref", ref, "err", err)
+		return ref
+	}
+
+	return sha
+}
+
+// find returns the downloaded archive containing the path.
+func (p *ArchiveProvider) find(projectId int64, sha, filePath string) *archive {
+	for _, archive := range p.archives {
+		if archive.projectId == projectId && archive.sha == sha && inDir(filePath, archive.dir) {
+			return archive
+		}
+	}
+
+	return nil
+}
+
+func (p *ArchiveProvider) download(ctx context.Context, projectId int64, sha, dir string) (*archive, error) {
+	slog.Debug("downloading repository archive", "service_id", projectId, "ref", sha, "path", dir)
+	data, err := p.scm.Archive(ctx, projectId, sha, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download archive: %w", err)
+	}
+
+	files, err := extract(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract archive: %w", err)
+	}
+
+	archive := &archive{
+		projectId: projectId,
+		sha:       sha,
+		dir:       dir,
+		files:     files,
+	}
+
+	p.archives = append(p.archives, archive)
+	if len(p.archives) > p.maxArchives {
+		p.archives = slices.Delete(p.archives, 0, len(p.archives)-p.maxArchives)
+	}
+
+	return archive, nil
+}
+
+// extract returns the regular files of the archive by their path in the
+// repository, the top level directory added by gitlab is dropped.
+func extract(data []byte) (map[string][]byte, error) {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer gzipReader.Close()
+
+	files := map[string][]byte{}
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		_, filePath, ok := strings.Cut(path.Clean(header.Name), "/")
+		if !ok || filePath == "" {
+			continue
+		}
+
+		content, err := io.ReadAll(tarReader)
+		if err != nil {
+			return nil, err
+		}
+
+		files[filePath] = content
+	}
+}
+
+func inDir(filePath, dir string) bool {
+	return dir == "" || filePath == dir || strings.HasPrefix(filePath, dir+"/")
+}
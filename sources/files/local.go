@@ -0,0 +1,121 @@
+package files
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"vislab/sources/scm"
+)
+
+// LocalProvider reads files from working copies checked out under root by
+// the full project path, e.g. root/group/project. The ref is not checked, the
+// working copy is expected to be at the collected ref.
+type LocalProvider struct {
+	root string
+	scm  scm.Provider
+
+	mu    sync.Mutex
+	paths map[int64]string
+}
+
+func NewLocalProvider(root string, scm scm.Provider) (*LocalProvider, error) {
+	if root == "" {
+		return nil, fmt.Errorf("local files path is empty")
+	}
+
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open local files path: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("local files path is not a directory: %s", root)
+	}
+
+	return &LocalProvider{
+		root:  root,
+		scm:   scm,
+		paths: map[int64]string{},
+	}, nil
+}
+
+func (p *LocalProvider) ListDir(ctx context.Context, projectId int64, ref, dir string) ([]string, error) {
+	projectDir, err := p.projectDir(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	dir = strings.Trim(dir, "/")
+	if dir != "" && !filepath.IsLocal(dir) {
+		return nil, fmt.Errorf("path is outside of the project: %s", dir)
+	}
+
+	paths := []string{}
+
+	err = filepath.WalkDir(filepath.Join(projectDir, dir), func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(projectDir, filePath)
+		if err != nil {
+			return err
+		}
+
+		paths = append(paths, filepath.ToSlash(relPath))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return paths, nil
+}
+
+func (p *LocalProvider) Get(ctx context.Context, projectId int64, ref, filePath string) ([]byte, error) {
+	projectDir, err := p.projectDir(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	filePath = strings.Trim(filePath, "/")
+	if !filepath.IsLocal(filePath) {
+		return nil, fmt.Errorf("path is outside of the project: %s", filePath)
+	}
+
+	return os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(filePath)))
+}
+
+func (p *LocalProvider) projectDir(ctx context.Context, projectId int64) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if projectDir, ok := p.paths[projectId]; ok {
+		return projectDir, nil
+	}
+
+	project, err := p.scm.GetProject(ctx, projectId)
+	if err != nil {
+		return "", fmt.Errorf("failed to get project: %w", err)
+	}
+	if project.PathWithGroup == nil {
+		return "", fmt.Errorf("project %d has no path", projectId)
+	}
+
+	projectDir := filepath.Join(p.root, filepath.FromSlash(*project.PathWithGroup))
+	p.paths[projectId] = projectDir
+
+	return projectDir, nil
+}
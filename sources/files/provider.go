@@ -0,0 +1,39 @@
+package files
+
+import (
+	"context"
+	"fmt"
+	"vislab/config"
+	"vislab/sources/scm"
+)
+
+const (
+	ModeAPI     = "api"
+	ModeArchive = "archive"
+	ModeLocal   = "local"
+)
+
+// Provider gives the steps the files of a project at a ref, regardless of
+// where they are read from.
+type Provider interface {
+	// ListDir returns the paths of all files under dir, recursively.
+	ListDir(ctx context.Context, projectId int64, ref, dir string) ([]string, error)
+	Get(ctx context.Context, projectId int64, ref, filePath string) ([]byte, error)
+}
+
+func NewProvider(conf *config.FilesConfig, scm scm.Provider) (Provider, error) {
+	if conf == nil {
+		return NewAPIProvider(scm), nil
+	}
+
+	switch conf.Mode {
+	case "", ModeAPI:
+		return NewAPIProvider(scm), nil
+	case ModeArchive:
+		return NewArchiveProvider(scm, conf.MaxArchives), nil
+	case ModeLocal:
+		return NewLocalProvider(conf.LocalPath, scm)
+	}
+
+	return nil, fmt.Errorf("unknown files mode: %s", conf.Mode)
+}
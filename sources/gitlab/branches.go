@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"time"
 	"vislab/sources/gitlab/types"
 )
 
@@ -35,27 +34,19 @@ func (s *BranchService) List(ctx context.Context, options *types.ListBranchesOpt
 func (s *BranchService) ListAll(ctx context.Context, options *types.ListBranchesOptions, projectId int64) ([]*types.Branch, *Response, error) {
 	options.Page = 1
 
-	ticker := time.NewTicker(s.client.rateLimit)
-	defer ticker.Stop()
-
 	var allBranches []*types.Branch
 	for {
-		select {
-		case <-ticker.C:
-			branches, resp, err := s.List(ctx, options, projectId)
-			if err != nil {
-				return nil, resp, err
-			}
+		branches, resp, err := s.List(ctx, options, projectId)
+		if err != nil {
+			return nil, resp, err
+		}
 
-			allBranches = append(allBranches, branches...)
+		allBranches = append(allBranches, branches...)
 
-			if resp.NextPage == 0 {
-				return allBranches, nil, nil
-			}
-			options.Page = int64(resp.NextPage)
-		case <-ctx.Done():
-			return nil, nil, ctx.Err()
+		if resp.NextPage == 0 {
+			return allBranches, nil, nil
 		}
+		options.Page = int64(resp.NextPage)
 	}
 }
 
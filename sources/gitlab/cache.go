@@ -0,0 +1,302 @@
+package gitlab
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	defaultCacheMaxSize = 512 << 20
+
+	// eviction frees a bit more than needed, so it does not run on every put
+	cacheEvictRatio = 0.9
+)
+
+var shaPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// fileCache keeps file contents and trees on disk. Contents at a commit never
+// change, so entries are keyed by project, commit sha and path and only
+// evicted when the cache outgrows its size, least recently used first.
+type fileCache struct {
+	dir     string
+	maxSize int64
+
+	mu   sync.Mutex
+	size int64
+	refs map[string]string
+}
+
+type cachedRef struct {
+	ETag string `json:"etag"`
+	SHA  string `json:"sha"`
+}
+
+func newFileCache(dir string, maxSize int64) (*fileCache, error) {
+	if maxSize <= 0 {
+		maxSize = defaultCacheMaxSize
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache dir: %w", err)
+	}
+
+	cache := &fileCache{
+		dir:     dir,
+		maxSize: maxSize,
+		refs:    map[string]string{},
+	}
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		cache.size += info.Size()
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache dir: %w", err)
+	}
+
+	return cache, nil
+}
+
+func (c *fileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	name := hex.EncodeToString(sum[:])
+
+	return filepath.Join(c.dir, name[:2], name)
+}
+
+func (c *fileCache) get(key string, v any) bool {
+	path := c.path(key)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		slog.Warn("failed to decode cache entry", "key", key, "err", err)
+		return false
+	}
+
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+
+	return true
+}
+
+func (c *fileCache) put(key string, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		slog.Warn("failed to encode cache entry", "key", key, "err", err)
+		return
+	}
+
+	path := c.path(key)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if info, err := os.Stat(path); err == nil {
+		c.size -= info.Size()
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		slog.Warn("failed to write cache entry", "key", key, "err", err)
+		return
+	}
+
+	// written aside and renamed, readers never see a partial entry
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		slog.Warn("failed to write cache entry", "key", key, "err", err)
+		return
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		slog.Warn("failed to write cache entry", "key", key, "err", err)
+		return
+	}
+
+	c.size += int64(len(data))
+
+	if c.size > c.maxSize {
+		c.evict()
+	}
+}
+
+type cacheEntry struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+func (c *fileCache) evict() {
+	entries := []cacheEntry{}
+
+	err := filepath.WalkDir(c.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+
+		entries = append(entries, cacheEntry{path: path, size: info.Size(), modTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		slog.Warn("failed to read cache dir", "err", err)
+		return
+	}
+
+	slices.SortFunc(entries, func(a, b cacheEntry) int {
+		return a.modTime.Compare(b.modTime)
+	})
+
+	c.size = 0
+	for _, entry := range entries {
+		c.size += entry.size
+	}
+
+	target := int64(float64(c.maxSize) * cacheEvictRatio)
+	evicted := 0
+
+	for _, entry := range entries {
+		if c.size <= target {
+			break
+		}
+
+		if err := os.Remove(entry.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+
+		c.size -= entry.size
+		evicted++
+	}
+
+	slog.Debug("evicted cache entries", "count", evicted, "size", c.size)
+}
+
+// ResetRefs forgets the resolved refs, so branches and tags moved since are
+// resolved again. The stored ETags are kept and still save the transfer.
+func (c *Client) ResetRefs() {
+	if c.cache == nil {
+		return
+	}
+
+	c.cache.mu.Lock()
+	defer c.cache.mu.Unlock()
+
+	c.cache.refs = map[string]string{}
+}
+
+// ResolveRef returns the commit sha of the ref. Refs are resolved once until
+// ResetRefs, the last answer of gitlab is kept with its ETag so reruns only
+// get a 304 when the ref did not move. Without a cache every call asks gitlab.
+func (c *Client) ResolveRef(ctx context.Context, projectId int64, ref string) (string, error) {
+	if shaPattern.MatchString(ref) {
+		return ref, nil
+	}
+
+	key := fmt.Sprintf("ref:%d:%s", projectId, ref)
+
+	if c.cache != nil {
+		c.cache.mu.Lock()
+		sha, ok := c.cache.refs[key]
+		c.cache.mu.Unlock()
+		if ok {
+			return sha, nil
+		}
+	}
+
+	req, err := c.NewRequest(ctx, http.MethodGet, fmt.Sprintf("projects/%d/repository/commits/%s", projectId, url.PathEscape(ref)), nil)
+	if err != nil {
+		return "", err
+	}
+
+	stored := &cachedRef{}
+	if c.cache != nil && c.cache.get(key, stored) && stored.ETag != "" {
+		req.Header.Set("If-None-Match", stored.ETag)
+	}
+
+	commit := &struct {
+		ID string `json:"id"`
+	}{}
+	resp, err := c.Do(req, commit)
+	if err != nil {
+		return "", err
+	}
+
+	if c.cache == nil {
+		return commit.ID, nil
+	}
+
+	sha := commit.ID
+	if resp.StatusCode == http.StatusNotModified {
+		c.stats.cacheHits.Add(1)
+		sha = stored.SHA
+	} else {
+		c.cache.put(key, &cachedRef{ETag: resp.Header.Get("ETag"), SHA: sha})
+	}
+
+	c.cache.mu.Lock()
+	c.cache.refs[key] = sha
+	c.cache.mu.Unlock()
+
+	return sha, nil
+}
+
+// withCache returns the value stored for the project, commit and key or loads
+// it at the resolved commit and stores it. Without a cache, or when the ref
+// can not be resolved, it just loads at the ref.
+func withCache[T any](ctx context.Context, c *Client, projectId int64, ref, key string, load func(ref string) (T, *Response, error)) (T, *Response, error) {
+	if c.cache == nil {
+		return load(ref)
+	}
+
+	sha, err := c.ResolveRef(ctx, projectId, ref)
+	if err != nil {
+		slog.Debug("failed to resolve ref for cache", "project_id", projectId, "ref", ref, "err", err)
+		return load(ref)
+	}
+
+	cacheKey := fmt.Sprintf("%d:%s:%s", projectId, sha, key)
+
+	var value T
+	if c.cache.get(cacheKey, &value) {
+		c.stats.cacheHits.Add(1)
+		return value, nil, nil
+	}
+
+	value, resp, err := load(sha)
+	if err != nil {
+		return value, resp, err
+	}
+
+	c.cache.put(cacheKey, value)
+
+	return value, resp, nil
+}
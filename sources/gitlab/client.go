@@ -1,11 +1,14 @@
 package gitlab
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"regexp"
@@ -18,17 +21,22 @@ import (
 
 const (
 	defaultApiPrefix = "/api/v4/"
-	defaultRateLimit = 1000 * time.Millisecond
+	defaultRateLimit = 100 * time.Millisecond
 	defaultPerPage   = 40
 	defaultTimeout   = 20 * time.Second
 )
 
 type Client struct {
-	client    *http.Client
-	token     string
-	baseURL   *url.URL
-	rateLimit time.Duration
-	perPage   int64
+	client  *http.Client
+	token   string
+	auth    authHeader
+	baseURL *url.URL
+	rawBase bool
+	perPage int64
+	limiter *limiter
+	cache   *fileCache
+	retry   *retryPolicy
+	stats   stats
 
 	Projects     *ProjectService
 	Tags         *TagService
@@ -39,16 +47,25 @@ type Client struct {
 	Groups       *GroupService
 	Search       *SearchService
 	MergeRequest *MergeRequestService
+	Hooks        *HookService
+}
+
+// authHeader is how the token is sent, gitlab takes it as is in PRIVATE-TOKEN.
+type authHeader struct {
+	name   string
+	prefix string
 }
 
 func NewClient(token, baseUrl string, options ...ClientOption) (*Client, error) {
 	client := &Client{
 		client: &http.Client{Timeout: defaultTimeout, Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, // TODO: make optional
+			TLSClientConfig: &tls.Config{MinVersion: tls.VersionTLS12},
 		}},
-		token:     token,
-		rateLimit: defaultRateLimit,
-		perPage:   defaultPerPage,
+		token:   token,
+		auth:    authHeader{name: "PRIVATE-TOKEN"},
+		perPage: defaultPerPage,
+		limiter: newLimiter(),
+		retry:   newRetryPolicy(),
 	}
 
 	if err := client.setBaseURL(baseUrl); err != nil {
@@ -61,7 +78,7 @@ func NewClient(token, baseUrl string, options ...ClientOption) (*Client, error)
 		}
 	}
 
-	if !strings.Contains(client.baseURL.Path, "/api/") {
+	if !client.rawBase && !strings.Contains(client.baseURL.Path, "/api/") {
 		if err := client.setApiPrefix(defaultApiPrefix); err != nil {
 			return nil, err
 		}
@@ -76,6 +93,7 @@ func NewClient(token, baseUrl string, options ...ClientOption) (*Client, error)
 	client.Groups = &GroupService{client: client}
 	client.Search = &SearchService{client: client}
 	client.MergeRequest = &MergeRequestService{client: client}
+	client.Hooks = &HookService{client: client}
 
 	return client, nil
 }
@@ -114,6 +132,12 @@ func GetOptions(config *config.GitLabClientConfig) []ClientOption {
 	if config.GitLabRateLimit != 0 {
 		options = append(options, WithRateLimit(config.GitLabRateLimit))
 	}
+	if config.RateBurst != 0 {
+		options = append(options, WithRateBurst(config.RateBurst))
+	}
+	for class, limit := range config.EndpointRateLimits {
+		options = append(options, WithEndpointRateLimit(class, limit.RateLimit, limit.Burst))
+	}
 	if config.GitlabPerPage != 0 {
 		options = append(options, WithPerPage(config.GitlabPerPage))
 	}
@@ -124,6 +148,15 @@ func GetOptions(config *config.GitLabClientConfig) []ClientOption {
 		timeout := time.Duration(config.GitlabTimeout) * time.Millisecond
 		options = append(options, WithTimeout(timeout))
 	}
+	if config.TLS != nil {
+		options = append(options, WithTLS(config.TLS))
+	}
+	if config.Cache != nil {
+		options = append(options, WithCache(config.Cache.Path, config.Cache.MaxSize<<20))
+	}
+	if config.Retry != nil {
+		options = append(options, WithRetry(config.Retry.MaxAttempts, config.Retry.MinBackoff, config.Retry.MaxBackoff))
+	}
 
 	return options
 }
@@ -141,7 +174,18 @@ func (c *Client) NewRequest(ctx context.Context, method, path string, opt interf
 	reqHeaders := http.Header{}
 	reqHeaders.Set("Accept", "application/json")
 
-	if opt != nil {
+	var body io.Reader
+
+	switch {
+	case opt == nil:
+	case method == http.MethodPost || method == http.MethodPut || method == http.MethodPatch:
+		data, err := json.Marshal(opt)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+		reqHeaders.Set("Content-Type", "application/json")
+	default:
 		q, err := query.Values(opt)
 		if err != nil {
 			return nil, err
@@ -149,7 +193,7 @@ func (c *Client) NewRequest(ctx context.Context, method, path string, opt interf
 		u.RawQuery = q.Encode()
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
 	if err != nil {
 		return nil, err
 	}
@@ -162,10 +206,50 @@ func (c *Client) NewRequest(ctx context.Context, method, path string, opt interf
 }
 
 func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
-	if values := req.Header.Values("PRIVATE-TOKEN"); len(values) == 0 {
-		req.Header.Set("PRIVATE-TOKEN", c.token)
+	if values := req.Header.Values(c.auth.name); len(values) == 0 && c.token != "" {
+		req.Header.Set(c.auth.name, c.auth.prefix+c.token)
 	}
 
+	attempts := c.retry.attempts(req)
+
+	for attempt := 1; ; attempt++ {
+		if err := c.limiter.wait(req.Context(), req.URL.Path); err != nil {
+			return nil, err
+		}
+
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		c.stats.requests.Add(1)
+
+		response, err := c.do(req, v)
+		if attempt == attempts || !c.retry.shouldRetry(req.Context(), response, err) {
+			if err != nil && !errors.Is(err, ErrNotFound) {
+				c.stats.failed.Add(1)
+			}
+			if attempt > 1 {
+				slog.Info("gitlab request finished after retries", "method", req.Method, "path", req.URL.Path, "attempts", attempt, "err", err)
+			}
+
+			return response, err
+		}
+
+		wait := c.retry.backoff(attempt, response, time.Now())
+		c.stats.retries.Add(1)
+
+		slog.Warn("retrying gitlab request", "method", req.Method, "path", req.URL.Path, "attempt", attempt, "wait", wait, "err", err)
+		if err := sleep(req.Context(), wait); err != nil {
+			return response, err
+		}
+	}
+}
+
+func (c *Client) do(req *http.Request, v interface{}) (*Response, error) {
 	resp, err := c.client.Do(req)
 	if err != nil {
 		return nil, err
@@ -180,6 +264,15 @@ func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
 		return response, err
 	}
 
+	if resp.StatusCode == http.StatusNotModified {
+		return response, nil
+	}
+
+	if data, ok := v.(*[]byte); ok {
+		*data, err = io.ReadAll(resp.Body)
+		return response, err
+	}
+
 	err = json.NewDecoder(resp.Body).Decode(v)
 
 	return response, err
@@ -1,8 +1,12 @@
 package gitlab
 
 import (
+	"fmt"
+	"log/slog"
 	"net/http"
 	"time"
+	"vislab/config"
+	"vislab/libs/tlsconf"
 )
 
 type ClientOption func(*Client) error
@@ -13,13 +17,48 @@ func WithAPIPrefix(apiPrefix string) ClientOption {
 	}
 }
 
+// WithAuthHeader sends the token in the header with the prefix, for apis
+// which do not take the gitlab PRIVATE-TOKEN header.
+func WithAuthHeader(name, prefix string) ClientOption {
+	return func(c *Client) error {
+		c.auth = authHeader{name: name, prefix: prefix}
+		return nil
+	}
+}
+
+// WithoutAPIPrefix uses the base url as is, for apis served from the root
+// of their host.
+func WithoutAPIPrefix() ClientOption {
+	return func(c *Client) error {
+		c.rawBase = true
+		return nil
+	}
+}
+
 func WithRateLimit(ms int64) ClientOption {
 	return func(c *Client) error {
-		c.rateLimit = time.Duration(ms) * time.Millisecond
+		c.limiter.client.interval = time.Duration(ms) * time.Millisecond
 		return nil
 	}
 }
 
+// WithRateBurst sets how many requests may be sent at once before the rate
+// limit applies.
+func WithRateBurst(burst int64) ClientOption {
+	return func(c *Client) error {
+		c.limiter.client = newTokenBucket(c.limiter.client.interval, burst)
+		return nil
+	}
+}
+
+// WithEndpointRateLimit limits an endpoint class on top of the client limit,
+// a rate limit of zero disables the class limit.
+func WithEndpointRateLimit(class string, ms int64, burst int64) ClientOption {
+	return func(c *Client) error {
+		return c.limiter.setClass(class, time.Duration(ms)*time.Millisecond, burst)
+	}
+}
+
 func WithHTTPClient(client *http.Client) ClientOption {
 	return func(c *Client) error {
 		c.client = client
@@ -40,3 +79,64 @@ func WithTimeout(timeout time.Duration) ClientOption {
 		return nil
 	}
 }
+
+// WithRetry sets the attempts of idempotent requests and the backoff bounds
+// in milliseconds, zero values keep the defaults.
+func WithRetry(maxAttempts int, minBackoffMs, maxBackoffMs int64) ClientOption {
+	return func(c *Client) error {
+		if maxAttempts > 0 {
+			c.retry.maxAttempts = maxAttempts
+		}
+		if minBackoffMs > 0 {
+			c.retry.minBackoff = time.Duration(minBackoffMs) * time.Millisecond
+		}
+		if maxBackoffMs > 0 {
+			c.retry.maxBackoff = time.Duration(maxBackoffMs) * time.Millisecond
+		}
+
+		if c.retry.minBackoff > c.retry.maxBackoff {
+			return fmt.Errorf("retry min backoff %s is greater than max backoff %s", c.retry.minBackoff, c.retry.maxBackoff)
+		}
+
+		return nil
+	}
+}
+
+// WithCache keeps file contents and trees in dir, maxSize is in bytes and
+// zero keeps the default.
+func WithCache(dir string, maxSize int64) ClientOption {
+	return func(c *Client) error {
+		if dir == "" {
+			return fmt.Errorf("cache path is empty")
+		}
+
+		cache, err := newFileCache(dir, maxSize)
+		if err != nil {
+			return err
+		}
+
+		c.cache = cache
+		return nil
+	}
+}
+
+func WithTLS(tlsConf *config.TLSConfig) ClientOption {
+	return func(c *Client) error {
+		transport, ok := c.client.Transport.(*http.Transport)
+		if !ok {
+			return fmt.Errorf("tls can not be set on a custom http transport")
+		}
+
+		tlsConfig, err := tlsconf.New(tlsConf)
+		if err != nil {
+			return fmt.Errorf("invalid gitlab tls config: %w", err)
+		}
+
+		if tlsConfig.InsecureSkipVerify {
+			slog.Warn("gitlab tls verification is disabled", "base_url", c.baseURL.String())
+		}
+
+		transport.TLSClientConfig = tlsConfig
+		return nil
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"time"
 	"vislab/sources/gitlab/types"
 )
 
@@ -34,27 +33,19 @@ func (s *CommitService) List(ctx context.Context, options *types.ListCommitsOpti
 func (s *CommitService) ListAll(ctx context.Context, options *types.ListCommitsOptions, projectId int64) ([]*types.Commit, *Response, error) {
 	options.Page = 1
 
-	ticker := time.NewTicker(s.client.rateLimit)
-	defer ticker.Stop()
-
 	var allCommits []*types.Commit
 	for {
-		select {
-		case <-ticker.C:
-			commits, resp, err := s.List(ctx, options, projectId)
-			if err != nil {
-				return nil, resp, err
-			}
-
-			allCommits = append(allCommits, commits...)
-
-			if resp.NextPage == 0 {
-				return allCommits, nil, nil
-			}
-			options.Page = int64(resp.NextPage)
-		case <-ctx.Done():
-			return nil, nil, ctx.Err()
+		commits, resp, err := s.List(ctx, options, projectId)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		allCommits = append(allCommits, commits...)
+
+		if resp.NextPage == 0 {
+			return allCommits, nil, nil
 		}
+		options.Page = int64(resp.NextPage)
 	}
 }
 
@@ -89,26 +80,18 @@ func (s *CommitService) ListRefs(ctx context.Context, sha string, projectId int6
 func (s *CommitService) ListRefsAll(ctx context.Context, sha string, projectId int64, options *types.ListCommitsOptions) ([]*types.Ref, *Response, error) {
 	options.Page = 1
 
-	ticker := time.NewTicker(s.client.rateLimit)
-	defer ticker.Stop()
-
 	var allRefs []*types.Ref
 	for {
-		select {
-		case <-ticker.C:
-			refs, resp, err := s.ListRefs(ctx, sha, projectId, options)
-			if err != nil {
-				return nil, resp, err
-			}
-
-			allRefs = append(allRefs, refs...)
-
-			if resp.NextPage == 0 {
-				return allRefs, nil, nil
-			}
-			options.Page = int64(resp.NextPage)
-		case <-ctx.Done():
-			return nil, nil, ctx.Err()
+		refs, resp, err := s.ListRefs(ctx, sha, projectId, options)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		allRefs = append(allRefs, refs...)
+
+		if resp.NextPage == 0 {
+			return allRefs, nil, nil
 		}
+		options.Page = int64(resp.NextPage)
 	}
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"time"
 	"vislab/sources/gitlab/types"
 )
 
@@ -34,26 +33,18 @@ func (s *ContributorService) List(ctx context.Context, options *types.ListContri
 func (s *ContributorService) ListAll(ctx context.Context, options *types.ListContributorsOptions, projectId int64) ([]*types.Contributor, *Response, error) {
 	options.Page = 1
 
-	ticker := time.NewTicker(s.client.rateLimit)
-	defer ticker.Stop()
-
 	var allContributors []*types.Contributor
 	for {
-		select {
-		case <-ticker.C:
-			contributors, resp, err := s.List(ctx, options, projectId)
-			if err != nil {
-				return nil, resp, err
-			}
-
-			allContributors = append(allContributors, contributors...)
-
-			if resp.NextPage == 0 {
-				return allContributors, nil, nil
-			}
-			options.Page = int64(resp.NextPage)
-		case <-ctx.Done():
-			return nil, nil, ctx.Err()
+		contributors, resp, err := s.List(ctx, options, projectId)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		allContributors = append(allContributors, contributors...)
+
+		if resp.NextPage == 0 {
+			return allContributors, nil, nil
 		}
+		options.Page = int64(resp.NextPage)
 	}
 }
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"time"
 	"vislab/libs/ptr"
 	"vislab/sources/gitlab/types"
 )
@@ -37,32 +36,29 @@ func (s *FIleService) ListAll(ctx context.Context, options *types.ListFilesOptio
 	options.Page = 1
 	options.Recursive = ptr.Ptr(true)
 
-	ticker := time.NewTicker(s.client.rateLimit)
-	defer ticker.Stop()
-
 	var allFiles []*types.ListFile
 	for {
-		select {
-		case <-ticker.C:
-			files, resp, err := s.List(ctx, options, projectId)
-			if err != nil {
-				return nil, resp, err
-			}
-
-			allFiles = append(allFiles, files...)
-
-			if resp.NextPage == 0 {
-				return allFiles, nil, nil
-			}
-			options.Page = int64(resp.NextPage)
-
-		case <-ctx.Done():
-			return nil, nil, ctx.Err()
+		files, resp, err := s.List(ctx, options, projectId)
+		if err != nil {
+			return nil, resp, err
 		}
+
+		allFiles = append(allFiles, files...)
+
+		if resp.NextPage == 0 {
+			return allFiles, nil, nil
+		}
+		options.Page = int64(resp.NextPage)
 	}
 }
 
 func (s *FIleService) Get(ctx context.Context, path string, projectId int64, ref string) (*types.File, *Response, error) {
+	return withCache(ctx, s.client, projectId, ref, "file:"+path, func(ref string) (*types.File, *Response, error) {
+		return s.get(ctx, path, projectId, ref)
+	})
+}
+
+func (s *FIleService) get(ctx context.Context, path string, projectId int64, ref string) (*types.File, *Response, error) {
 	req, err := s.client.NewRequest(ctx, http.MethodGet, fmt.Sprintf("projects/%d/repository/files/%s", projectId, url.QueryEscape(path)), &types.ListFilesOptions{Ref: &ref})
 	if err != nil {
 		return nil, nil, err
@@ -78,7 +74,31 @@ func (s *FIleService) Get(ctx context.Context, path string, projectId int64, ref
 }
 
 func (s *FIleService) ListDir(ctx context.Context, path string, projectId int64, ref string) ([]*types.ListFile, *Response, error) {
-	options := &types.ListFilesOptions{Path: &path, Ref: &ref}
+	return withCache(ctx, s.client, projectId, ref, "tree:"+path, func(ref string) ([]*types.ListFile, *Response, error) {
+		options := &types.ListFilesOptions{Path: &path, Ref: &ref}
+
+		return s.ListAll(ctx, options, projectId)
+	})
+}
+
+// Archive returns the tar.gz archive of the repository at the ref, limited to
+// the path when it is not empty.
+func (s *FIleService) Archive(ctx context.Context, projectId int64, ref, path string) ([]byte, *Response, error) {
+	options := &types.ArchiveOptions{SHA: &ref}
+	if path != "" {
+		options.Path = &path
+	}
 
-	return s.ListAll(ctx, options, projectId)
+	req, err := s.client.NewRequest(ctx, http.MethodGet, fmt.Sprintf("projects/%d/repository/archive.tar.gz", projectId), options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var p []byte
+	resp, err := s.client.Do(req, &p)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return p, resp, nil
 }
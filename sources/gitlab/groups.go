@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"time"
 	"vislab/sources/gitlab/types"
 )
 
@@ -34,27 +33,19 @@ func (s *GroupService) List(ctx context.Context, options *types.ListGroupsOption
 func (s *GroupService) ListAll(ctx context.Context, options *types.ListGroupsOptions) ([]*types.Group, *Response, error) {
 	options.Page = 1
 
-	ticker := time.NewTicker(s.client.rateLimit)
-	defer ticker.Stop()
-
 	var allGroups []*types.Group
 	for {
-		select {
-		case <-ticker.C:
-			Groups, resp, err := s.List(ctx, options)
-			if err != nil {
-				return nil, resp, err
-			}
-
-			allGroups = append(allGroups, Groups...)
-
-			if resp.NextPage == 0 {
-				return allGroups, resp, nil
-			}
-			options.Page = int64(resp.NextPage)
-		case <-ctx.Done():
-			return nil, nil, ctx.Err()
+		Groups, resp, err := s.List(ctx, options)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		allGroups = append(allGroups, Groups...)
+
+		if resp.NextPage == 0 {
+			return allGroups, resp, nil
 		}
+		options.Page = int64(resp.NextPage)
 	}
 }
 
@@ -95,26 +86,18 @@ func (s *GroupService) ListProjects(ctx context.Context, id int64, options *type
 func (s *GroupService) ListAllProjects(ctx context.Context, id int64, options *types.ListProjectsOptions) ([]*types.Project, *Response, error) {
 	options.Page = 1
 
-	ticker := time.NewTicker(s.client.rateLimit)
-	defer ticker.Stop()
-
 	var allProjects []*types.Project
 	for {
-		select {
-		case <-ticker.C:
-			projects, resp, err := s.ListProjects(ctx, id, options)
-			if err != nil {
-				return nil, resp, err
-			}
-
-			allProjects = append(allProjects, projects...)
-
-			if resp.NextPage == 0 {
-				return allProjects, nil, nil
-			}
-			options.Page = int64(resp.NextPage)
-		case <-ctx.Done():
-			return nil, nil, ctx.Err()
+		projects, resp, err := s.ListProjects(ctx, id, options)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		allProjects = append(allProjects, projects...)
+
+		if resp.NextPage == 0 {
+			return allProjects, nil, nil
 		}
+		options.Page = int64(resp.NextPage)
 	}
 }
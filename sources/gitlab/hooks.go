@@ -0,0 +1,42 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"vislab/sources/gitlab/types"
+)
+
+type HookService struct {
+	client *Client
+}
+
+func (s *HookService) List(ctx context.Context, projectId int64) ([]*types.Hook, *Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodGet, fmt.Sprintf("projects/%d/hooks", projectId), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var p []*types.Hook
+	resp, err := s.client.Do(req, &p)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return p, resp, nil
+}
+
+func (s *HookService) Add(ctx context.Context, projectId int64, options *types.AddHookOptions) (*types.Hook, *Response, error) {
+	req, err := s.client.NewRequest(ctx, http.MethodPost, fmt.Sprintf("projects/%d/hooks", projectId), options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var p *types.Hook
+	resp, err := s.client.Do(req, &p)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return p, resp, nil
+}
@@ -0,0 +1,115 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBurst = 10
+
+	EndpointClassSearch = "search"
+	EndpointClassFiles  = "files"
+
+	defaultSearchRateLimit = 2000 * time.Millisecond
+	defaultSearchBurst     = 5
+)
+
+var endpointClasses = []string{EndpointClassSearch, EndpointClassFiles}
+
+// tokenBucket refills one token per interval up to burst, an interval of zero
+// disables the limit.
+type tokenBucket struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    float64
+	tokens   float64
+	last     time.Time
+}
+
+func newTokenBucket(interval time.Duration, burst int64) *tokenBucket {
+	burst = max(burst, 1)
+
+	return &tokenBucket{
+		interval: interval,
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// reserve takes a token and returns how long the caller has to wait for it.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.interval <= 0 {
+		return 0
+	}
+
+	b.tokens = min(b.burst, b.tokens+float64(now.Sub(b.last))/float64(b.interval))
+	b.last = now
+	b.tokens--
+
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens * float64(b.interval))
+}
+
+// limiter is shared by all requests of the client, endpoint classes which are
+// limited stricter by gitlab have an own bucket on top of the client one.
+type limiter struct {
+	client  *tokenBucket
+	classes map[string]*tokenBucket
+}
+
+func newLimiter() *limiter {
+	return &limiter{
+		client: newTokenBucket(defaultRateLimit, defaultBurst),
+		classes: map[string]*tokenBucket{
+			EndpointClassSearch: newTokenBucket(defaultSearchRateLimit, defaultSearchBurst),
+		},
+	}
+}
+
+func (l *limiter) setClass(class string, interval time.Duration, burst int64) error {
+	if !slices.Contains(endpointClasses, class) {
+		return fmt.Errorf("unknown endpoint class: %s, expected one of %s", class, strings.Join(endpointClasses, ", "))
+	}
+
+	l.classes[class] = newTokenBucket(interval, burst)
+
+	return nil
+}
+
+func (l *limiter) wait(ctx context.Context, path string) error {
+	now := time.Now()
+	wait := l.client.reserve(now)
+
+	if bucket, ok := l.classes[endpointClass(path)]; ok {
+		wait = max(wait, bucket.reserve(now))
+	}
+
+	if wait == 0 {
+		return ctx.Err()
+	}
+
+	return sleep(ctx, wait)
+}
+
+func endpointClass(path string) string {
+	switch {
+	case strings.HasSuffix(path, "/search"):
+		return EndpointClassSearch
+	case strings.Contains(path, "/repository/files/"), strings.Contains(path, "/repository/archive"):
+		return EndpointClassFiles
+	}
+
+	return ""
+}
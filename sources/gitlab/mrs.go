@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"time"
 	"vislab/sources/gitlab/types"
 )
 
@@ -34,27 +33,19 @@ func (s *MergeRequestService) List(ctx context.Context, options *types.ListMerge
 func (s *MergeRequestService) ListAll(ctx context.Context, options *types.ListMergeRequestsOptions, projectId int64) ([]*types.MergeRequest, *Response, error) {
 	options.Page = 1
 
-	ticker := time.NewTicker(s.client.rateLimit)
-	defer ticker.Stop()
-
 	var allMergeRequests []*types.MergeRequest
 	for {
-		select {
-		case <-ticker.C:
-			mergeRequests, resp, err := s.List(ctx, options, projectId)
-			if err != nil {
-				return nil, resp, err
-			}
+		mergeRequests, resp, err := s.List(ctx, options, projectId)
+		if err != nil {
+			return nil, resp, err
+		}
 
-			allMergeRequests = append(allMergeRequests, mergeRequests...)
+		allMergeRequests = append(allMergeRequests, mergeRequests...)
 
-			if resp.NextPage == 0 {
-				return allMergeRequests, nil, nil
-			}
-			options.Page = int64(resp.NextPage)
-		case <-ctx.Done():
-			return nil, nil, ctx.Err()
+		if resp.NextPage == 0 {
+			return allMergeRequests, nil, nil
 		}
+		options.Page = int64(resp.NextPage)
 	}
 }
 
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"time"
 	"vislab/sources/gitlab/types"
 )
 
@@ -35,27 +34,19 @@ func (s *ProjectService) List(ctx context.Context, options *types.ListProjectsOp
 func (s *ProjectService) ListAll(ctx context.Context, options *types.ListProjectsOptions) ([]*types.Project, *Response, error) {
 	options.Page = 1
 
-	ticker := time.NewTicker(s.client.rateLimit)
-	defer ticker.Stop()
-
 	var allProjects []*types.Project
 	for {
-		select {
-		case <-ticker.C:
-			projects, resp, err := s.List(ctx, options)
-			if err != nil {
-				return nil, resp, err
-			}
-
-			allProjects = append(allProjects, projects...)
-
-			if resp.NextPage == 0 {
-				return allProjects, nil, nil
-			}
-			options.Page = int64(resp.NextPage)
-		case <-ctx.Done():
-			return nil, nil, ctx.Err()
+		projects, resp, err := s.List(ctx, options)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		allProjects = append(allProjects, projects...)
+
+		if resp.NextPage == 0 {
+			return allProjects, nil, nil
 		}
+		options.Page = int64(resp.NextPage)
 	}
 }
 
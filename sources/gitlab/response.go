@@ -35,11 +35,34 @@ func newResponse(r *http.Response) *Response {
 	}
 	if nextPage := r.Header.Get(xNextPage); nextPage != "" {
 		response.NextPage, _ = strconv.Atoi(nextPage)
+	} else if link := r.Header.Get("Link"); link != "" {
+		response.NextPage = nextLinkPage(link)
 	}
 
 	return response
 }
 
+// nextLinkPage returns the page of the rel="next" link, github and gitea
+// paginate with the Link header only.
+func nextLinkPage(header string) int {
+	for _, link := range strings.Split(header, ",") {
+		target, params, ok := strings.Cut(link, ";")
+		if !ok || !strings.Contains(params, `rel="next"`) {
+			continue
+		}
+
+		linkURL, err := url.Parse(strings.Trim(strings.TrimSpace(target), "<>"))
+		if err != nil {
+			return 0
+		}
+
+		page, _ := strconv.Atoi(linkURL.Query().Get("page"))
+		return page
+	}
+
+	return 0
+}
+
 type ErrorResponse struct {
 	Body     []byte
 	Response *http.Response
@@ -0,0 +1,137 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultMaxAttempts = 4
+	defaultMinBackoff  = 500 * time.Millisecond
+	defaultMaxBackoff  = 30 * time.Second
+
+	headerRetryAfter         = "Retry-After"
+	headerRateLimitRemaining = "RateLimit-Remaining"
+	headerRateLimitReset     = "RateLimit-Reset"
+)
+
+type retryPolicy struct {
+	maxAttempts int
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+}
+
+func newRetryPolicy() *retryPolicy {
+	return &retryPolicy{
+		maxAttempts: defaultMaxAttempts,
+		minBackoff:  defaultMinBackoff,
+		maxBackoff:  defaultMaxBackoff,
+	}
+}
+
+// attempts returns how many times the request may be sent, requests which
+// are not idempotent are never repeated.
+func (p *retryPolicy) attempts(req *http.Request) int {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return max(p.maxAttempts, 1)
+	}
+
+	return 1
+}
+
+func (p *retryPolicy) shouldRetry(ctx context.Context, resp *Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if resp == nil {
+		return err != nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	case http.StatusForbidden:
+		// github answers an exhausted rate limit with 403
+		return rateLimitHeader(resp.Header, headerRateLimitRemaining) == "0"
+	}
+
+	return false
+}
+
+// backoff prefers the wait time announced by the server, otherwise it grows
+// exponentially with the attempt and is jittered to spread parallel callers.
+// Announced waits are capped at the max backoff as well.
+func (p *retryPolicy) backoff(attempt int, resp *Response, now time.Time) time.Duration {
+	if resp != nil {
+		if wait, ok := retryAfter(resp.Header.Get(headerRetryAfter), now); ok {
+			return min(wait, p.maxBackoff)
+		}
+
+		if rateLimitHeader(resp.Header, headerRateLimitRemaining) == "0" {
+			if wait, ok := rateLimitReset(rateLimitHeader(resp.Header, headerRateLimitReset), now); ok {
+				return min(wait, p.maxBackoff)
+			}
+		}
+	}
+
+	wait := p.minBackoff << (attempt - 1)
+	if wait > p.maxBackoff || wait <= 0 {
+		wait = p.maxBackoff
+	}
+
+	return wait/2 + rand.N(wait/2+1)
+}
+
+func retryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0), true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+
+	return 0, false
+}
+
+// rateLimitHeader reads the gitlab header or the X- prefixed one github and
+// gitea send.
+func rateLimitHeader(header http.Header, name string) string {
+	if value := header.Get(name); value != "" {
+		return value
+	}
+
+	return header.Get("X-" + name)
+}
+
+func rateLimitReset(value string, now time.Time) (time.Duration, bool) {
+	reset, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return max(time.Unix(reset, 0).Sub(now), 0), true
+}
+
+func sleep(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
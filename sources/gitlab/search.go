@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"time"
 	"vislab/libs/ptr"
 	"vislab/sources/gitlab/types"
 )
@@ -41,27 +40,19 @@ func (s *SearchService) SearchFiles(ctx context.Context, query string, projectId
 func (s *SearchService) SearchAllFiles(ctx context.Context, query string, projectId int64, options *types.SearchOptions) ([]*types.SearchResult, *Response, error) {
 	options.Page = 1
 
-	ticker := time.NewTicker(s.client.rateLimit)
-	defer ticker.Stop()
-
 	var allFiles []*types.SearchResult
 	for {
-		select {
-		case <-ticker.C:
-			files, resp, err := s.SearchFiles(ctx, query, projectId, options)
-			if err != nil {
-				return nil, resp, err
-			}
+		files, resp, err := s.SearchFiles(ctx, query, projectId, options)
+		if err != nil {
+			return nil, resp, err
+		}
 
-			allFiles = append(allFiles, files...)
+		allFiles = append(allFiles, files...)
 
-			if resp.NextPage == 0 {
-				return allFiles, nil, nil
-			}
-			options.Page = int64(resp.NextPage)
-		case <-ctx.Done():
-			return nil, nil, ctx.Err()
+		if resp.NextPage == 0 {
+			return allFiles, nil, nil
 		}
+		options.Page = int64(resp.NextPage)
 	}
 }
 
@@ -2,45 +2,77 @@ package gitlab
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
+	"time"
 	"vislab/config"
 	"vislab/sources/gitlab/types"
 )
 
+// ProjectReader reads the project metadata, the scm providers implement it.
+type ProjectReader interface {
+	GetProject(ctx context.Context, projectId int64) (*types.Project, error)
+	GetLatestTag(ctx context.Context, projectId int64) (*types.Tag, error)
+	GetLanguages(ctx context.Context, projectId int64) (map[string]float64, error)
+}
+
 type Source struct {
-	gitClient *Client
-	weight    int64
+	reader ProjectReader
+	weight int64
+	status *statusThresholds
 }
 
+// NewSource reads with its own client when the config has one, otherwise
+// with the reader of the endpoint the service comes from.
 func NewSource(gitSourceConfig *config.GitSourceConfig) (*Source, error) {
-	options := GetOptions(gitSourceConfig.Client)
-
-	git, err := NewClient(gitSourceConfig.Client.Token, gitSourceConfig.Client.BaseURL, options...)
-	if err != nil {
-		return nil, err
+	s := &Source{
+		weight: gitSourceConfig.Weight,
+		status: newStatusThresholds(gitSourceConfig.Status),
 	}
 
-	s := &Source{
-		gitClient: git,
-		weight:    gitSourceConfig.Weight,
+	if gitSourceConfig.Client != nil {
+		options := GetOptions(gitSourceConfig.Client)
+
+		git, err := NewClient(gitSourceConfig.Client.Token, gitSourceConfig.Client.BaseURL, options...)
+		if err != nil {
+			return nil, err
+		}
+
+		s.reader = &clientReader{client: git}
 	}
 
 	return s, nil
 }
 
-func (s *Source) GetData(ctx context.Context, serviceId int64) (*types.All, error) {
-	project, _, err := s.gitClient.Projects.Get(ctx, serviceId)
+func (s *Source) GetData(ctx context.Context, reader ProjectReader, serviceId int64) (*types.All, error) {
+	if s.reader != nil {
+		reader = s.reader
+	}
+	if reader == nil {
+		return nil, fmt.Errorf("no project reader set")
+	}
+
+	project, err := reader.GetProject(ctx, serviceId)
 	if err != nil {
 		return nil, err
 	}
 
-	latestTag, _, err := s.gitClient.Tags.GetLatest(ctx, serviceId)
+	latestTag, err := reader.GetLatestTag(ctx, serviceId)
 	if err != nil {
 		return nil, err
 	}
 
+	languages, err := reader.GetLanguages(ctx, serviceId)
+	if err != nil {
+		slog.Warn("failed to get project languages", "project_id", serviceId, "err", err)
+	}
+
 	all := &types.All{
 		Project:   project,
 		LatestTag: latestTag,
+		Languages: languages,
+		Language:  dominantLanguage(languages),
+		Status:    s.status.projectStatus(project, time.Now()),
 	}
 
 	return all, nil
@@ -49,3 +81,22 @@ func (s *Source) GetData(ctx context.Context, serviceId int64) (*types.All, erro
 func (s *Source) Weight() int64 {
 	return s.weight
 }
+
+type clientReader struct {
+	client *Client
+}
+
+func (r *clientReader) GetProject(ctx context.Context, projectId int64) (*types.Project, error) {
+	project, _, err := r.client.Projects.Get(ctx, projectId)
+	return project, err
+}
+
+func (r *clientReader) GetLatestTag(ctx context.Context, projectId int64) (*types.Tag, error) {
+	tag, _, err := r.client.Tags.GetLatest(ctx, projectId)
+	return tag, err
+}
+
+func (r *clientReader) GetLanguages(ctx context.Context, projectId int64) (map[string]float64, error) {
+	languages, _, err := r.client.Projects.GetLanguages(ctx, projectId)
+	return languages, err
+}
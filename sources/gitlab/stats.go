@@ -0,0 +1,27 @@
+package gitlab
+
+import "sync/atomic"
+
+// Stats are the request counters of the client since it was created.
+type Stats struct {
+	Requests  int64
+	Retries   int64
+	Failed    int64
+	CacheHits int64
+}
+
+type stats struct {
+	requests  atomic.Int64
+	retries   atomic.Int64
+	failed    atomic.Int64
+	cacheHits atomic.Int64
+}
+
+func (c *Client) Stats() Stats {
+	return Stats{
+		Requests:  c.stats.requests.Load(),
+		Retries:   c.stats.retries.Load(),
+		Failed:    c.stats.failed.Load(),
+		CacheHits: c.stats.cacheHits.Load(),
+	}
+}
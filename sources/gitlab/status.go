@@ -0,0 +1,80 @@
+package gitlab
+
+import (
+	"time"
+	"vislab/config"
+	"vislab/sources/gitlab/types"
+)
+
+const (
+	StatusActive   = "active"
+	StatusStale    = "stale"
+	StatusArchived = "archived"
+
+	defaultStaleAfterDays = 180
+)
+
+type statusThresholds struct {
+	staleAfter    time.Duration
+	archivedAfter time.Duration
+}
+
+func newStatusThresholds(config *config.ServiceStatusConfig) *statusThresholds {
+	t := &statusThresholds{
+		staleAfter: defaultStaleAfterDays * 24 * time.Hour,
+	}
+
+	if config == nil {
+		return t
+	}
+
+	if config.StaleAfterDays > 0 {
+		t.staleAfter = time.Duration(config.StaleAfterDays) * 24 * time.Hour
+	}
+	if config.ArchivedAfterDays > 0 {
+		t.archivedAfter = time.Duration(config.ArchivedAfterDays) * 24 * time.Hour
+	}
+
+	return t
+}
+
+// projectStatus treats archived projects as archived and empty repositories as
+// stale, others are rated by the time since the last activity.
+func (t *statusThresholds) projectStatus(project *types.Project, now time.Time) string {
+	if project.Archived != nil && *project.Archived {
+		return StatusArchived
+	}
+
+	if project.EmptyRepo != nil && *project.EmptyRepo {
+		return StatusStale
+	}
+
+	if project.LastActivityAt == nil {
+		return StatusActive
+	}
+
+	inactive := now.Sub(*project.LastActivityAt)
+
+	if t.archivedAfter > 0 && inactive > t.archivedAfter {
+		return StatusArchived
+	}
+
+	if inactive > t.staleAfter {
+		return StatusStale
+	}
+
+	return StatusActive
+}
+
+// dominantLanguage returns the language with the biggest share.
+func dominantLanguage(languages map[string]float64) string {
+	dominant := ""
+
+	for language, share := range languages {
+		if dominant == "" || share > languages[dominant] || (share == languages[dominant] && language < dominant) {
+			dominant = language
+		}
+	}
+
+	return dominant
+}
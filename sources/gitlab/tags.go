@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"time"
 	"vislab/libs/ptr"
 	"vislab/sources/gitlab/types"
 )
@@ -35,27 +34,19 @@ func (s *TagService) List(ctx context.Context, options *types.ListTagsOptions, p
 func (s *TagService) ListAll(ctx context.Context, options *types.ListTagsOptions, projectId int64) ([]*types.Tag, *Response, error) {
 	options.Page = 1
 
-	ticker := time.NewTicker(s.client.rateLimit)
-	defer ticker.Stop()
-
 	var allTags []*types.Tag
 	for {
-		select {
-		case <-ticker.C:
-			tags, resp, err := s.List(ctx, options, projectId)
-			if err != nil {
-				return nil, resp, err
-			}
-
-			allTags = append(allTags, tags...)
-
-			if resp.NextPage == 0 {
-				return allTags, nil, nil
-			}
-			options.Page = int64(resp.NextPage)
-		case <-ctx.Done():
-			return nil, nil, ctx.Err()
+		tags, resp, err := s.List(ctx, options, projectId)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		allTags = append(allTags, tags...)
+
+		if resp.NextPage == 0 {
+			return allTags, nil, nil
 		}
+		options.Page = int64(resp.NextPage)
 	}
 }
 
@@ -4,5 +4,8 @@ type (
 	All struct {
 		Project   *Project
 		LatestTag *Tag
+		Language  string
+		Languages map[string]float64
+		Status    string
 	}
 )
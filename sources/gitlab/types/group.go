@@ -9,5 +9,6 @@ type (
 		FullName    string `json:"full_name"`
 		FullPath    string `json:"full_path"`
 		WebURL      string `json:"web_url"`
+		ParentID    *int64 `json:"parent_id"`
 	}
 )
@@ -0,0 +1,19 @@
+package types
+
+type (
+	Hook struct {
+		ID                    int64  `json:"id"`
+		URL                   string `json:"url"`
+		PushEvents            bool   `json:"push_events"`
+		TagPushEvents         bool   `json:"tag_push_events"`
+		EnableSSLVerification bool   `json:"enable_ssl_verification"`
+	}
+
+	AddHookOptions struct {
+		URL                   *string `json:"url,omitempty"`
+		Token                 *string `json:"token,omitempty"`
+		PushEvents            *bool   `json:"push_events,omitempty"`
+		TagPushEvents         *bool   `json:"tag_push_events,omitempty"`
+		EnableSSLVerification *bool   `json:"enable_ssl_verification,omitempty"`
+	}
+)
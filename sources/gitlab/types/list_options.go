@@ -35,6 +35,11 @@ type (
 		Recursive *bool   `url:"recursive,omitempty" json:"recursive,omitempty"`
 	}
 
+	ArchiveOptions struct {
+		SHA  *string `url:"sha,omitempty" json:"sha,omitempty"`
+		Path *string `url:"path,omitempty" json:"path,omitempty"`
+	}
+
 	ListGroupsOptions struct {
 		ListOptions
 		Search  *string `url:"search,omitempty" json:"search,omitempty"`
@@ -61,6 +66,8 @@ type (
 	ListProjectsOptions struct {
 		ListOptions
 		Archived           *bool      `url:"archived,omitempty" json:"archived,omitempty"`
+		IncludeSubGroups   *bool      `url:"include_subgroups,omitempty" json:"include_subgroups,omitempty"`
+		WithShared         *bool      `url:"with_shared,omitempty" json:"with_shared,omitempty"`
 		LastActivityAfter  *time.Time `url:"last_activity_after,omitempty" json:"last_activity_after,omitempty"`
 		LastActivityBefore *time.Time `url:"last_activity_before,omitempty" json:"last_activity_before,omitempty"`
 		Search             *string    `url:"search,omitempty" json:"search,omitempty"`
@@ -16,6 +16,7 @@ type (
 		Group          *Namespace `json:"namespace,omitempty"`
 		EmptyRepo      *bool      `json:"empty_repo"`
 		Archived       *bool      `json:"archived"`
+		Topics         []string   `json:"topics"`
 		Owner          *Owner     `json:"owner,omitempty"`
 	}
 
@@ -0,0 +1,210 @@
+package kubernetes
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"vislab/sources/kubernetes/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Parser struct {
+}
+
+func NewParser() (*Parser, error) {
+	p := &Parser{}
+
+	return p, nil
+}
+
+// Parse reads every document of a manifest file, files rendered by helm
+// template keep all objects in one stream separated by ---.
+func (p *Parser) Parse(filePath string, in []byte, out *types.All) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(in))
+
+	for {
+		manifest := types.Manifest{}
+
+		err := decoder.Decode(&manifest)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to decode manifest: %w", err)
+		}
+
+		if err := p.parseManifest(filePath, &manifest, out); err != nil {
+			slog.Warn("failed to parse manifest", "path", filePath, "kind", manifest.Kind, "name", manifest.Metadata.Name, "err", err)
+		}
+	}
+}
+
+func (p *Parser) parseManifest(filePath string, manifest *types.Manifest, out *types.All) error {
+	switch manifest.Kind {
+	case "List":
+		for _, item := range manifest.Items {
+			if err := p.parseManifest(filePath, &item, out); err != nil {
+				return err
+			}
+		}
+	case "Deployment", "StatefulSet", "DaemonSet":
+		workload, err := parseWorkload(filePath, manifest)
+		if err != nil {
+			return err
+		}
+
+		out.Deployments = append(out.Deployments, workload)
+	case "CronJob":
+		workload, err := parseWorkload(filePath, manifest)
+		if err != nil {
+			return err
+		}
+
+		out.CronJobs = append(out.CronJobs, workload)
+	case "Job":
+		workload, err := parseWorkload(filePath, manifest)
+		if err != nil {
+			return err
+		}
+
+		out.Jobs = append(out.Jobs, workload)
+	case "Service":
+		service, err := parseService(filePath, manifest)
+		if err != nil {
+			return err
+		}
+
+		out.Services = append(out.Services, service)
+	case "Ingress":
+		ingress, err := parseIngress(filePath, manifest)
+		if err != nil {
+			return err
+		}
+
+		out.Ingresses = append(out.Ingresses, ingress)
+	}
+
+	return nil
+}
+
+func parseWorkload(filePath string, manifest *types.Manifest) (*types.Workload, error) {
+	spec := types.WorkloadSpec{}
+	if err := manifest.Spec.Decode(&spec); err != nil {
+		return nil, err
+	}
+
+	workload := &types.Workload{
+		Name:      manifest.Metadata.Name,
+		Namespace: manifest.Metadata.Namespace,
+		Path:      filePath,
+		Replicas:  spec.Replicas,
+		Schedule:  spec.Schedule,
+		Images:    []string{},
+		Ports:     []int64{},
+	}
+
+	podSpec := spec.Template.Spec
+	if spec.JobTemplate != nil {
+		podSpec = spec.JobTemplate.Spec.Template.Spec
+	}
+
+	for _, container := range slices.Concat(podSpec.InitContainers, podSpec.Containers) {
+		if container.Image != "" && !slices.Contains(workload.Images, container.Image) {
+			workload.Images = append(workload.Images, container.Image)
+		}
+
+		for _, port := range container.Ports {
+			if !slices.Contains(workload.Ports, port.ContainerPort) {
+				workload.Ports = append(workload.Ports, port.ContainerPort)
+			}
+		}
+	}
+
+	return workload, nil
+}
+
+func parseService(filePath string, manifest *types.Manifest) (*types.Service, error) {
+	spec := types.ServiceSpec{}
+	if err := manifest.Spec.Decode(&spec); err != nil {
+		return nil, err
+	}
+
+	service := &types.Service{
+		Name:      manifest.Metadata.Name,
+		Namespace: manifest.Metadata.Namespace,
+		Path:      filePath,
+		Type:      spec.Type,
+		Ports:     []int64{},
+	}
+
+	if service.Type == "" {
+		service.Type = "ClusterIP"
+	}
+
+	for _, port := range spec.Ports {
+		service.Ports = append(service.Ports, port.Port)
+	}
+
+	return service, nil
+}
+
+func parseIngress(filePath string, manifest *types.Manifest) (*types.Ingress, error) {
+	spec := types.IngressSpec{}
+	if err := manifest.Spec.Decode(&spec); err != nil {
+		return nil, err
+	}
+
+	ingress := &types.Ingress{
+		Name:      manifest.Metadata.Name,
+		Namespace: manifest.Metadata.Namespace,
+		Path:      filePath,
+		Hosts:     []string{},
+		Backends:  []string{},
+	}
+
+	addBackend := func(backend *types.IngressBackend) {
+		if backend == nil {
+			return
+		}
+
+		name := backend.ServiceName
+		if backend.Service != nil {
+			name = backend.Service.Name
+		}
+
+		if name != "" && !slices.Contains(ingress.Backends, name) {
+			ingress.Backends = append(ingress.Backends, name)
+		}
+	}
+
+	addBackend(spec.DefaultBackend)
+	addBackend(spec.Backend)
+
+	for _, rule := range spec.Rules {
+		if rule.Host != "" && !slices.Contains(ingress.Hosts, rule.Host) {
+			ingress.Hosts = append(ingress.Hosts, rule.Host)
+		}
+
+		if rule.HTTP == nil {
+			continue
+		}
+
+		for _, path := range rule.HTTP.Paths {
+			addBackend(&path.Backend)
+		}
+	}
+
+	for _, tls := range spec.TLS {
+		for _, host := range tls.Hosts {
+			if !slices.Contains(ingress.Hosts, host) {
+				ingress.Hosts = append(ingress.Hosts, host)
+			}
+		}
+	}
+
+	return ingress, nil
+}
@@ -0,0 +1,43 @@
+package kubernetes
+
+import (
+	"context"
+	"path"
+	"strings"
+	"vislab/config"
+	"vislab/sources/kubernetes/types"
+)
+
+type Source struct {
+	parser *Parser
+	weight int64
+}
+
+func NewSource(config *config.KubernetesSourceConfig) (*Source, error) {
+	parser, err := NewParser()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Source{
+		parser: parser,
+		weight: config.Weight,
+	}
+
+	return s, nil
+}
+
+func (s *Source) Weight() int64 {
+	return s.weight
+}
+
+// Match reports whether the file can hold manifests.
+func (s *Source) Match(filePath string) bool {
+	extension := strings.ToLower(path.Ext(filePath))
+
+	return extension == ".yaml" || extension == ".yml"
+}
+
+func (s *Source) GetData(ctx context.Context, filePath string, in []byte, out *types.All) error {
+	return s.parser.Parse(filePath, in, out)
+}
@@ -0,0 +1,37 @@
+package types
+
+type (
+	All struct {
+		Deployments []*Workload
+		CronJobs    []*Workload
+		Jobs        []*Workload
+		Services    []*Service
+		Ingresses   []*Ingress
+	}
+
+	Workload struct {
+		Name      string
+		Namespace string
+		Path      string
+		Replicas  *int64
+		Schedule  string
+		Images    []string
+		Ports     []int64
+	}
+
+	Service struct {
+		Name      string
+		Namespace string
+		Path      string
+		Type      string
+		Ports     []int64
+	}
+
+	Ingress struct {
+		Name      string
+		Namespace string
+		Path      string
+		Hosts     []string
+		Backends  []string
+	}
+)
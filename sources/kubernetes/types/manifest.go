@@ -0,0 +1,86 @@
+package types
+
+import "gopkg.in/yaml.v3"
+
+type (
+	Manifest struct {
+		APIVersion string     `yaml:"apiVersion"`
+		Kind       string     `yaml:"kind"`
+		Metadata   Metadata   `yaml:"metadata"`
+		Spec       yaml.Node  `yaml:"spec"`
+		Items      []Manifest `yaml:"items"`
+	}
+
+	Metadata struct {
+		Name      string `yaml:"name"`
+		Namespace string `yaml:"namespace"`
+	}
+
+	WorkloadSpec struct {
+		Replicas    *int64       `yaml:"replicas"`
+		Schedule    string       `yaml:"schedule"`
+		Template    PodTemplate  `yaml:"template"`
+		JobTemplate *JobTemplate `yaml:"jobTemplate"`
+	}
+
+	JobTemplate struct {
+		Spec WorkloadSpec `yaml:"spec"`
+	}
+
+	PodTemplate struct {
+		Spec PodSpec `yaml:"spec"`
+	}
+
+	PodSpec struct {
+		Containers     []Container `yaml:"containers"`
+		InitContainers []Container `yaml:"initContainers"`
+	}
+
+	Container struct {
+		Name  string          `yaml:"name"`
+		Image string          `yaml:"image"`
+		Ports []ContainerPort `yaml:"ports"`
+	}
+
+	ContainerPort struct {
+		ContainerPort int64 `yaml:"containerPort"`
+	}
+
+	ServiceSpec struct {
+		Type  string        `yaml:"type"`
+		Ports []ServicePort `yaml:"ports"`
+	}
+
+	ServicePort struct {
+		Port int64 `yaml:"port"`
+	}
+
+	IngressSpec struct {
+		Rules          []IngressRule   `yaml:"rules"`
+		TLS            []IngressTLS    `yaml:"tls"`
+		DefaultBackend *IngressBackend `yaml:"defaultBackend"`
+		Backend        *IngressBackend `yaml:"backend"`
+	}
+
+	IngressRule struct {
+		Host string `yaml:"host"`
+		HTTP *struct {
+			Paths []struct {
+				Backend IngressBackend `yaml:"backend"`
+			} `yaml:"paths"`
+		} `yaml:"http"`
+	}
+
+	IngressTLS struct {
+		Hosts []string `yaml:"hosts"`
+	}
+
+	// IngressBackend covers networking.k8s.io/v1 and the older
+	// extensions/v1beta1 backend formats
+	IngressBackend struct {
+		Service *struct {
+			Name string `yaml:"name"`
+		} `yaml:"service"`
+		ServiceName string `yaml:"serviceName"`
+	}
+)
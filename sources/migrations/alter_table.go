@@ -2,20 +2,27 @@ package migrations
 
 import (
 	"fmt"
-	"log/slog"
 	"slices"
+	"strings"
 	"vislab/sources/migrations/types"
 
 	pg_query "github.com/pganalyze/pg_query_go/v5"
 )
 
-func parseAlterTable(stmt *pg_query.Node_AlterTableStmt, tables map[string]*types.Table) (*types.Table, error) {
-	table, ok := tables[stmt.AlterTableStmt.Relation.Relname]
+// parseAlterTable applies the supported subcommands and reports the rest as
+// unsupported, so one unknown subcommand does not hide the others.
+func parseAlterTable(stmt *pg_query.Node_AlterTableStmt, tables map[string]*types.Table) (*types.Table, types.DiagnosticStatus, error) {
+	table, ok := tables[relationKey(stmt.AlterTableStmt.Relation)]
 	if !ok {
-		slog.Error("table not found in existing list", "table", stmt.AlterTableStmt.Relation.Relname)
-		return nil, fmt.Errorf("table not found in existing list: %s", stmt.AlterTableStmt.Relation.Relname)
+		if stmt.AlterTableStmt.MissingOk {
+			return nil, types.DiagnosticSkipped, fmt.Errorf("table %s does not exist", relationKey(stmt.AlterTableStmt.Relation))
+		}
+
+		return nil, types.DiagnosticError, fmt.Errorf("table not found in existing list: %s", stmt.AlterTableStmt.Relation.Relname)
 	}
 
+	unsupported := []string{}
+
 	for _, cmd := range stmt.AlterTableStmt.Cmds {
 		switch cNode := cmd.Node.(type) {
 		case *pg_query.Node_AlterTableCmd:
@@ -23,6 +30,23 @@ func parseAlterTable(stmt *pg_query.Node_AlterTableStmt, tables map[string]*type
 			case pg_query.AlterTableType_AT_AddConstraint:
 				switch def := cNode.AlterTableCmd.Def.Node.(type) {
 				case *pg_query.Node_Constraint:
+					if def.Constraint.Contype == pg_query.ConstrType_CONSTR_FOREIGN {
+						fk, err := parseForeignKey(def.Constraint, table, nil)
+						if err != nil {
+							return nil, types.DiagnosticError, err
+						}
+
+						addForeignKey(table, fk)
+
+						for _, column := range table.Columns {
+							if slices.Contains(fk.Columns, column.Name) {
+								column.Constraints = append(column.Constraints, def.Constraint.Contype.String())
+							}
+						}
+
+						continue
+					}
+
 					for _, key := range def.Constraint.Keys {
 						switch kNode := key.Node.(type) {
 						case *pg_query.Node_String_:
@@ -32,79 +56,118 @@ func parseAlterTable(stmt *pg_query.Node_AlterTableStmt, tables map[string]*type
 								}
 							}
 						default:
-							slog.Error("unimplemented constraint key type", "type", fmt.Sprintf("%T", kNode))
+							unsupported = append(unsupported, fmt.Sprintf("constraint key %T", kNode))
 						}
 					}
 				default:
-					slog.Error("unimplemented constraint type", "type", fmt.Sprintf("%T", def))
+					unsupported = append(unsupported, fmt.Sprintf("constraint %T", def))
 				}
 			case pg_query.AlterTableType_AT_AddColumn:
 				switch def := cNode.AlterTableCmd.Def.Node.(type) {
 				case *pg_query.Node_ColumnDef:
 					column, err := parseColumn(def)
 					if err != nil {
-						return nil, err
+						return nil, types.DiagnosticError, err
 					}
 
 					if slices.ContainsFunc(table.Columns, func(c *types.Column) bool {
 						return c.Name == column.Name
 					}) {
-						return nil, fmt.Errorf("column already exists")
+						return nil, types.DiagnosticError, fmt.Errorf("column already exists")
 					}
 
 					table.Columns = append(table.Columns, column)
+
+					for _, fk := range parseColumnForeignKeys(def.ColumnDef, table) {
+						addForeignKey(table, fk)
+					}
 				default:
-					slog.Error("unimplemented add column type", "type", fmt.Sprintf("%T", def))
+					unsupported = append(unsupported, fmt.Sprintf("add column %T", def))
 				}
 			case pg_query.AlterTableType_AT_AlterColumnType:
 				switch def := cNode.AlterTableCmd.Def.Node.(type) {
 				case *pg_query.Node_ColumnDef:
 					column, err := parseColumn(def)
 					if err != nil {
-						return nil, err
+						return nil, types.DiagnosticError, err
 					}
 
 					for _, v := range table.Columns {
-						if v.Name == column.Name {
-							v.Name = cNode.AlterTableCmd.Name
+						if v.Name == cNode.AlterTableCmd.Name {
+							v.Type = column.Type
 						}
 					}
 				default:
-					slog.Error("unimplemented alter column type", "type", fmt.Sprintf("%T", def))
+					unsupported = append(unsupported, fmt.Sprintf("alter column %T", def))
 				}
 			case pg_query.AlterTableType_AT_DropConstraint:
-				fmt.Printf("drop_constraint: %v\n", cNode.AlterTableCmd.Name)
+				dropForeignKey(table, cNode.AlterTableCmd.Name)
 			case pg_query.AlterTableType_AT_DropColumn:
 				for i, column := range table.Columns {
 					if column.Name == cNode.AlterTableCmd.Name {
 						table.Columns = append(table.Columns[:i], table.Columns[i+1:]...)
 					}
 				}
+
+				table.ForeignKeys = slices.DeleteFunc(table.ForeignKeys, func(fk *types.ForeignKey) bool {
+					return slices.Contains(fk.Columns, cNode.AlterTableCmd.Name)
+				})
 			case pg_query.AlterTableType_AT_ColumnDefault:
-				fmt.Printf("field default set, skipping: %v\n", cNode) // TODO add set default handler
+				if cNode.AlterTableCmd.Def != nil {
+					setColumnConstraint(table, cNode.AlterTableCmd.Name, pg_query.ConstrType_CONSTR_DEFAULT)
+				} else {
+					dropColumnConstraint(table, cNode.AlterTableCmd.Name, pg_query.ConstrType_CONSTR_DEFAULT)
+				}
+			case pg_query.AlterTableType_AT_SetNotNull:
+				setColumnConstraint(table, cNode.AlterTableCmd.Name, pg_query.ConstrType_CONSTR_NOTNULL)
 			case pg_query.AlterTableType_AT_DropNotNull:
-				fmt.Printf("not null field dropping, skipping: %v\n", cNode)
+				dropColumnConstraint(table, cNode.AlterTableCmd.Name, pg_query.ConstrType_CONSTR_NOTNULL)
+			case pg_query.AlterTableType_AT_ChangeOwner:
+				if cNode.AlterTableCmd.Newowner != nil {
+					table.Owner = roleSpecName(cNode.AlterTableCmd.Newowner)
+				}
 			case pg_query.AlterTableType_AT_AttachPartition:
 				switch def := cNode.AlterTableCmd.Def.Node.(type) {
 				case *pg_query.Node_PartitionCmd:
 					table.Type = "partitioned"
-					partTable, ok := tables[def.PartitionCmd.Name.Relname]
+					partTable, ok := tables[relationKey(def.PartitionCmd.Name)]
 					if !ok {
-						slog.Error("table not found in existing list", "table", def.PartitionCmd.Name.Relname)
-						return nil, fmt.Errorf("table not found in existing list: %s", def.PartitionCmd.Name.Relname)
+						return nil, types.DiagnosticError, fmt.Errorf("table not found in existing list: %s", def.PartitionCmd.Name.Relname)
 					}
 
 					partTable.Type = "partition"
 				default:
-					slog.Error("unimplemented attach partition type", "type", fmt.Sprintf("%T", def))
+					unsupported = append(unsupported, fmt.Sprintf("attach partition %T", def))
 				}
 			default:
-				slog.Error("unimplemented alter table type", "type", cNode.AlterTableCmd.Subtype)
+				unsupported = append(unsupported, cNode.AlterTableCmd.Subtype.String())
 			}
 		default:
-			slog.Error("unimplemented alter table command type", "type", fmt.Sprintf("%T", cNode))
+			unsupported = append(unsupported, fmt.Sprintf("command %T", cNode))
 		}
 	}
 
-	return table, nil
+	if len(unsupported) > 0 {
+		return table, types.DiagnosticUnsupported, fmt.Errorf("unsupported alter table commands: %s", strings.Join(unsupported, ", "))
+	}
+
+	return table, types.DiagnosticOK, nil
+}
+
+func setColumnConstraint(table *types.Table, columnName string, constraint pg_query.ConstrType) {
+	for _, column := range table.Columns {
+		if column.Name == columnName && !slices.Contains(column.Constraints, constraint.String()) {
+			column.Constraints = append(column.Constraints, constraint.String())
+		}
+	}
+}
+
+func dropColumnConstraint(table *types.Table, columnName string, constraint pg_query.ConstrType) {
+	for _, column := range table.Columns {
+		if column.Name == columnName {
+			column.Constraints = slices.DeleteFunc(column.Constraints, func(c string) bool {
+				return c == constraint.String()
+			})
+		}
+	}
 }
@@ -3,27 +3,57 @@ package migrations
 import (
 	"fmt"
 	"log/slog"
+	"slices"
 	"vislab/sources/migrations/types"
 
 	pg_query "github.com/pganalyze/pg_query_go/v5"
 )
 
 func parseAlterType(stmt *pg_query.Node_AlterEnumStmt, types map[string]*types.Type) (*types.Type, error) {
-	for _, typeName := range stmt.AlterEnumStmt.TypeName {
-		switch node := typeName.Node.(type) {
-		case *pg_query.Node_String_:
-			typ, ok := types[node.String_.Sval]
-			if !ok {
-				return nil, fmt.Errorf("type %s not found", node.String_.Sval)
-			}
-			delete(types, node.String_.Sval)
-
-			typ.Name = node.String_.Sval
-
-			return typ, nil
-		default:
-			slog.Error("unimplemented alter_type type", "type", fmt.Sprintf("%T", node))
+	if len(stmt.AlterEnumStmt.TypeName) == 0 {
+		return nil, fmt.Errorf("type not found")
+	}
+
+	switch node := stmt.AlterEnumStmt.TypeName[len(stmt.AlterEnumStmt.TypeName)-1].Node.(type) {
+	case *pg_query.Node_String_:
+		typ, ok := types[node.String_.Sval]
+		if !ok {
+			return nil, fmt.Errorf("type %s not found", node.String_.Sval)
 		}
+
+		alterEnumValues(stmt, typ)
+
+		return typ, nil
+	default:
+		slog.Error("unimplemented alter_type type", "type", fmt.Sprintf("%T", node))
 	}
+
 	return nil, fmt.Errorf("type not found")
 }
+
+func alterEnumValues(stmt *pg_query.Node_AlterEnumStmt, typ *types.Type) {
+	newVal := stmt.AlterEnumStmt.NewVal
+
+	if oldVal := stmt.AlterEnumStmt.OldVal; oldVal != "" {
+		if i := slices.Index(typ.Values, oldVal); i >= 0 {
+			typ.Values[i] = newVal
+		}
+		return
+	}
+
+	if newVal == "" || slices.Contains(typ.Values, newVal) {
+		return
+	}
+
+	i := slices.Index(typ.Values, stmt.AlterEnumStmt.NewValNeighbor)
+	if i < 0 {
+		typ.Values = append(typ.Values, newVal)
+		return
+	}
+
+	if stmt.AlterEnumStmt.NewValIsAfter {
+		i++
+	}
+
+	typ.Values = slices.Insert(typ.Values, i, newVal)
+}
@@ -9,11 +9,18 @@ import (
 )
 
 func parseCreateFunc(stmt *pg_query.Node_CreateFunctionStmt, funcs map[string][]*types.Func) (*types.Func, error) {
-	function := &types.Func{}
+	function := &types.Func{
+		Schema: "public",
+	}
 
-	for _, funcName := range stmt.CreateFunctionStmt.Funcname {
+	for i, funcName := range stmt.CreateFunctionStmt.Funcname {
 		switch fNode := funcName.Node.(type) {
 		case *pg_query.Node_String_:
+			if i < len(stmt.CreateFunctionStmt.Funcname)-1 {
+				function.Schema = fNode.String_.Sval
+				continue
+			}
+
 			function.Name = fNode.String_.Sval
 		default:
 			slog.Error("unimplemented create function name type", "type", fmt.Sprintf("%T", fNode))
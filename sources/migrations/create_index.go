@@ -10,8 +10,10 @@ import (
 
 func parseCreateIndex(stmt *pg_query.Node_IndexStmt, indexes map[string]*types.Index) (*types.Index, error) {
 	index := &types.Index{
-		Name:  stmt.IndexStmt.Idxname,
-		Table: stmt.IndexStmt.Relation.Relname,
+		Name:   stmt.IndexStmt.Idxname,
+		Schema: stmt.IndexStmt.Relation.Schemaname,
+		Table:  stmt.IndexStmt.Relation.Relname,
+		Unique: stmt.IndexStmt.Unique,
 	}
 
 	for _, column := range stmt.IndexStmt.IndexParams {
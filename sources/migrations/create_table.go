@@ -32,7 +32,7 @@ func parseCreateTable(stmt *pg_query.Node_CreateStmt, tables map[string]*types.T
 		for _, rel := range stmt.CreateStmt.InhRelations {
 			switch rNode := rel.Node.(type) {
 			case *pg_query.Node_RangeVar:
-				tmpTable, ok := tables[rNode.RangeVar.Relname]
+				tmpTable, ok := tables[relationKey(rNode.RangeVar)]
 				if !ok {
 					slog.Error("table not found in existing list", "table", table)
 					continue
@@ -57,22 +57,29 @@ func parseCreateTable(stmt *pg_query.Node_CreateStmt, tables map[string]*types.T
 			}
 
 			table.Columns = append(table.Columns, column)
+
+			for _, fk := range parseColumnForeignKeys(eNode.ColumnDef, table) {
+				addForeignKey(table, fk)
+			}
 		case *pg_query.Node_Constraint:
 			switch eNode.Constraint.Contype {
-			case pg_query.ConstrType_CONSTR_UNIQUE:
-				for _, key := range eNode.Constraint.Keys {
-					switch kNode := key.Node.(type) {
-					case *pg_query.Node_String_:
-						fmt.Printf("constraint_key: %v\n", kNode.String_.Sval)
-					default:
-						slog.Error("unimplemented constraint key type", "type", fmt.Sprintf("%T", kNode))
-					}
+			case pg_query.ConstrType_CONSTR_FOREIGN:
+				fk, err := parseForeignKey(eNode.Constraint, table, nil)
+				if err != nil {
+					slog.Error("failed to parse foreign key", "table", table.Name, "error", err)
+					continue
+				}
+
+				addForeignKey(table, fk)
+			case pg_query.ConstrType_CONSTR_UNIQUE, pg_query.ConstrType_CONSTR_PRIMARY:
+				for _, key := range constraintKeys(eNode.Constraint.Keys) {
+					setColumnConstraint(table, key, eNode.Constraint.Contype)
 				}
 			default:
 				slog.Error("unimplemented constraint type", "type", eNode.Constraint.Contype)
 			}
 		case *pg_query.Node_TableLikeClause:
-			tmpTable, ok := tables[eNode.TableLikeClause.Relation.Relname]
+			tmpTable, ok := tables[relationKey(eNode.TableLikeClause.Relation)]
 			if !ok {
 				slog.Error("table not found in existing list", "table", table)
 				continue
@@ -29,13 +29,13 @@ func parseCreateTableAs(stmt *pg_query.Node_CreateTableAsStmt, tables map[string
 		}
 
 		for _, sTable := range sel.Tables {
-			tmpTable, ok := tables[sTable]
+			tmpTable, ok := types.FindTable(tables, sTable)
 			if !ok {
 				slog.Error("table not found in existing list", "table", table)
 				continue
 			}
 
-			if sel.Columns[0] == "*" {
+			if len(sel.Columns) > 0 && sel.Columns[0] == "*" {
 				table.Columns = append(table.Columns, tmpTable.Columns...)
 				continue
 			}
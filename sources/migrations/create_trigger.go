@@ -10,8 +10,9 @@ import (
 
 func parseCreateTrigger(stmt *pg_query.Node_CreateTrigStmt, triggers map[string]*types.Trigger) (*types.Trigger, error) {
 	trigger := &types.Trigger{
-		Name:  stmt.CreateTrigStmt.Trigname,
-		Table: stmt.CreateTrigStmt.Relation.Relname,
+		Name:   stmt.CreateTrigStmt.Trigname,
+		Schema: stmt.CreateTrigStmt.Relation.Schemaname,
+		Table:  stmt.CreateTrigStmt.Relation.Relname,
 	}
 
 	for _, fName := range stmt.CreateTrigStmt.Funcname {
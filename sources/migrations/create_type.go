@@ -9,16 +9,33 @@ import (
 )
 
 func parseCreateType(stmt *pg_query.Node_CreateEnumStmt, typs map[string]*types.Type) (*types.Type, error) {
-	typ := &types.Type{}
+	typ := &types.Type{
+		Schema: "public",
+		Values: []string{},
+	}
 
-	for _, typeName := range stmt.CreateEnumStmt.TypeName {
+	for i, typeName := range stmt.CreateEnumStmt.TypeName {
 		switch node := typeName.Node.(type) {
 		case *pg_query.Node_String_:
+			if i < len(stmt.CreateEnumStmt.TypeName)-1 {
+				typ.Schema = node.String_.Sval
+				continue
+			}
+
 			typ.Name = node.String_.Sval
 		default:
 			slog.Error("unimplemented create_type type", "type", fmt.Sprintf("%T", node))
 		}
 	}
 
+	for _, val := range stmt.CreateEnumStmt.Vals {
+		switch node := val.Node.(type) {
+		case *pg_query.Node_String_:
+			typ.Values = append(typ.Values, node.String_.Sval)
+		default:
+			slog.Error("unimplemented create_type value type", "type", fmt.Sprintf("%T", node))
+		}
+	}
+
 	return typ, nil
 }
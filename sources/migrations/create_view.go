@@ -0,0 +1,95 @@
+package migrations
+
+import (
+	"fmt"
+	"vislab/sources/migrations/types"
+
+	pg_query "github.com/pganalyze/pg_query_go/v5"
+)
+
+func parseCreateView(stmt *pg_query.Node_ViewStmt, tables map[string]*types.Table) (*types.View, error) {
+	return parseView(stmt.ViewStmt.View, stmt.ViewStmt.Query, false, tables)
+}
+
+func parseCreateMatView(stmt *pg_query.Node_CreateTableAsStmt, tables map[string]*types.Table) (*types.View, error) {
+	if stmt.CreateTableAsStmt.Into == nil {
+		return nil, fmt.Errorf("materialized view without name")
+	}
+
+	return parseView(stmt.CreateTableAsStmt.Into.Rel, stmt.CreateTableAsStmt.Query, true, tables)
+}
+
+func parseView(rel *pg_query.RangeVar, query *pg_query.Node, materialized bool, tables map[string]*types.Table) (*types.View, error) {
+	if rel == nil {
+		return nil, fmt.Errorf("view without name")
+	}
+
+	view := &types.View{
+		Name:         rel.Relname,
+		Schema:       rel.Schemaname,
+		Materialized: materialized,
+		Columns:      []string{},
+		Tables:       []string{},
+	}
+
+	if view.Schema == "" {
+		view.Schema = "public"
+	}
+
+	if query == nil {
+		return nil, fmt.Errorf("view %s without query", view.Name)
+	}
+
+	switch qNode := query.Node.(type) {
+	case *pg_query.Node_SelectStmt:
+		sel, err := parseSelect(qNode, tables)
+		if err != nil {
+			return nil, err
+		}
+
+		view.Columns = sel.Columns
+		view.Tables = sel.Tables
+	default:
+		return nil, fmt.Errorf("unimplemented view query type: %T", qNode)
+	}
+
+	return view, nil
+}
+
+func parseDropView(stmt *pg_query.Node_DropStmt, views map[string]*types.View) error {
+	for _, object := range stmt.DropStmt.Objects {
+		switch oNode := object.Node.(type) {
+		case *pg_query.Node_List:
+			if len(oNode.List.Items) == 0 {
+				continue
+			}
+
+			nameNode, ok := oNode.List.Items[len(oNode.List.Items)-1].Node.(*pg_query.Node_String_)
+			if !ok {
+				return fmt.Errorf("unimplemented drop view name type: %T", oNode.List.Items[len(oNode.List.Items)-1].Node)
+			}
+
+			if _, ok := views[nameNode.String_.Sval]; !ok && !stmt.DropStmt.MissingOk {
+				return fmt.Errorf("view %s not found", nameNode.String_.Sval)
+			}
+
+			delete(views, nameNode.String_.Sval)
+		default:
+			return fmt.Errorf("unimplemented drop view object type: %T", oNode)
+		}
+	}
+
+	return nil
+}
+
+func parseRenameView(stmt *pg_query.Node_RenameStmt, views map[string]*types.View) (*types.View, error) {
+	view, ok := views[stmt.RenameStmt.Relation.Relname]
+	if !ok {
+		return nil, fmt.Errorf("view %s not found", stmt.RenameStmt.Relation.Relname)
+	}
+
+	delete(views, view.Name)
+	view.Name = stmt.RenameStmt.Newname
+
+	return view, nil
+}
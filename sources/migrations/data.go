@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"fmt"
+	"vislab/sources/migrations/types"
+
+	pg_query "github.com/pganalyze/pg_query_go/v5"
+)
+
+// data statements do not change the schema, they are only checked against
+// the known tables
+func parseDataStmt(relation *pg_query.RangeVar, tables map[string]*types.Table) (types.DiagnosticStatus, error) {
+	if relation == nil {
+		return types.DiagnosticSkipped, fmt.Errorf("data statement without table")
+	}
+
+	if _, ok := tables[relationKey(relation)]; !ok {
+		return types.DiagnosticSkipped, fmt.Errorf("data statement on unknown table %s", relation.Relname)
+	}
+
+	return types.DiagnosticSkipped, nil
+}
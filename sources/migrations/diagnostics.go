@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"sync"
+	"vislab/sources/migrations/types"
+)
+
+type Diagnostics struct {
+	mu    sync.Mutex
+	items []*types.Diagnostic
+}
+
+func NewDiagnostics() *Diagnostics {
+	return &Diagnostics{
+		items: []*types.Diagnostic{},
+	}
+}
+
+func (d *Diagnostics) Add(diagnostics ...*types.Diagnostic) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.items = append(d.items, diagnostics...)
+}
+
+func (d *Diagnostics) Items() []*types.Diagnostic {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	items := make([]*types.Diagnostic, len(d.items))
+	copy(items, d.items)
+
+	return items
+}
+
+func (d *Diagnostics) Counts() map[types.DiagnosticStatus]int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	counts := map[types.DiagnosticStatus]int{}
+	for _, item := range d.items {
+		counts[item.Status]++
+	}
+
+	return counts
+}
+
+func (d *Diagnostics) Reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.items = []*types.Diagnostic{}
+}
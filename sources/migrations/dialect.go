@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"errors"
+	"path"
+	"strings"
+	"vislab/sources/migrations/types"
+)
+
+var ErrNotMigration = errors.New("file is not a migration")
+
+type Dialect interface {
+	Name() string
+	Detect(filePath string, in []byte) bool
+	Split(filePath string, in []byte) (*types.Migration, error)
+}
+
+func defaultDialects() []Dialect {
+	return []Dialect{
+		&GooseDialect{},
+		&GolangMigrateDialect{},
+		&FlywayDialect{},
+		&PlainDialect{},
+	}
+}
+
+func isSQLFile(filePath string) bool {
+	return strings.EqualFold(path.Ext(filePath), ".sql")
+}
@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"vislab/sources/migrations/types"
+)
+
+var flywayFileRe = regexp.MustCompile(`^([VUR])([0-9]+(?:[._][0-9]+)*)?__(.+)\.sql$`)
+
+type FlywayDialect struct{}
+
+func (d *FlywayDialect) Name() string {
+	return "flyway"
+}
+
+func (d *FlywayDialect) Detect(filePath string, in []byte) bool {
+	return flywayFileRe.MatchString(path.Base(filePath))
+}
+
+func (d *FlywayDialect) Split(filePath string, in []byte) (*types.Migration, error) {
+	match := flywayFileRe.FindStringSubmatch(path.Base(filePath))
+	if match == nil {
+		return nil, fmt.Errorf("invalid flyway file name: %s", path.Base(filePath))
+	}
+
+	migration := &types.Migration{
+		Version:     strings.ReplaceAll(match[2], "_", "."),
+		Description: strings.ReplaceAll(match[3], "_", " "),
+	}
+
+	switch match[1] {
+	case "V", "R":
+		migration.Up = string(in)
+	case "U":
+		migration.Down = string(in)
+	}
+
+	return migration, nil
+}
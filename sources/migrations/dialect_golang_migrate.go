@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"vislab/sources/migrations/types"
+)
+
+var golangMigrateFileRe = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+type GolangMigrateDialect struct{}
+
+func (d *GolangMigrateDialect) Name() string {
+	return "golang-migrate"
+}
+
+func (d *GolangMigrateDialect) Detect(filePath string, in []byte) bool {
+	return golangMigrateFileRe.MatchString(path.Base(filePath))
+}
+
+func (d *GolangMigrateDialect) Split(filePath string, in []byte) (*types.Migration, error) {
+	match := golangMigrateFileRe.FindStringSubmatch(path.Base(filePath))
+	if match == nil {
+		return nil, fmt.Errorf("invalid golang-migrate file name: %s", path.Base(filePath))
+	}
+
+	migration := &types.Migration{
+		Version:     match[1],
+		Description: match[2],
+	}
+
+	if match[3] == "up" {
+		migration.Up = string(in)
+	} else {
+		migration.Down = string(in)
+	}
+
+	return migration, nil
+}
@@ -0,0 +1,69 @@
+package migrations
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"vislab/sources/migrations/types"
+)
+
+var gooseFileRe = regexp.MustCompile(`^(\d+)_(.+)\.sql$`)
+
+type GooseDialect struct{}
+
+func (d *GooseDialect) Name() string {
+	return "goose"
+}
+
+func (d *GooseDialect) Detect(filePath string, in []byte) bool {
+	return isSQLFile(filePath) && bytes.Contains(bytes.ToLower(in), []byte("-- +goose up"))
+}
+
+func (d *GooseDialect) Split(filePath string, in []byte) (*types.Migration, error) {
+	migration := &types.Migration{}
+
+	if match := gooseFileRe.FindStringSubmatch(path.Base(filePath)); match != nil {
+		migration.Version = match[1]
+		migration.Description = match[2]
+	}
+
+	var up, down strings.Builder
+	var section *strings.Builder
+
+	scanner := bufio.NewScanner(bytes.NewReader(in))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(in)+1)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		directive, ok := strings.CutPrefix(strings.TrimSpace(line), "-- +goose ")
+		if ok {
+			switch strings.ToLower(strings.TrimSpace(directive)) {
+			case "up":
+				section = &up
+			case "down":
+				section = &down
+			}
+			continue
+		}
+
+		if section == nil {
+			continue
+		}
+
+		section.WriteString(line)
+		section.WriteString("\n")
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read goose migration: %w", err)
+	}
+
+	migration.Up = up.String()
+	migration.Down = down.String()
+
+	return migration, nil
+}
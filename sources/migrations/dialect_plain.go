@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"path"
+	"strings"
+	"vislab/sources/migrations/types"
+)
+
+type PlainDialect struct{}
+
+func (d *PlainDialect) Name() string {
+	return "plain"
+}
+
+func (d *PlainDialect) Detect(filePath string, in []byte) bool {
+	return isSQLFile(filePath)
+}
+
+func (d *PlainDialect) Split(filePath string, in []byte) (*types.Migration, error) {
+	migration := &types.Migration{
+		Description: strings.TrimSuffix(path.Base(filePath), path.Ext(filePath)),
+		Up:          string(in),
+	}
+
+	return migration, nil
+}
@@ -0,0 +1,81 @@
+package migrations
+
+import (
+	"slices"
+	"vislab/sources/migrations/types"
+)
+
+func DiffSchemas(from, to *types.All) *types.SchemaDiff {
+	diff := &types.SchemaDiff{
+		ChangedTables: map[string]*types.TableDiff{},
+	}
+
+	diff.AddedTables, diff.DroppedTables = diffKeys(from.Tables, to.Tables)
+	diff.AddedIndexes, diff.DroppedIndexes = diffKeys(from.Indexes, to.Indexes)
+	diff.AddedTypes, diff.DroppedTypes = diffKeys(from.Types, to.Types)
+
+	for name, toTable := range to.Tables {
+		fromTable, ok := from.Tables[name]
+		if !ok {
+			continue
+		}
+
+		if tableDiff := diffTable(fromTable, toTable); tableDiff != nil {
+			diff.ChangedTables[name] = tableDiff
+		}
+	}
+
+	return diff
+}
+
+func diffTable(from, to *types.Table) *types.TableDiff {
+	fromColumns := map[string]*types.Column{}
+	for _, column := range from.Columns {
+		fromColumns[column.Name] = column
+	}
+
+	toColumns := map[string]*types.Column{}
+	for _, column := range to.Columns {
+		toColumns[column.Name] = column
+	}
+
+	tableDiff := &types.TableDiff{}
+	tableDiff.AddedColumns, tableDiff.DroppedColumns = diffKeys(fromColumns, toColumns)
+
+	for name, toColumn := range toColumns {
+		fromColumn, ok := fromColumns[name]
+		if !ok {
+			continue
+		}
+
+		if fromColumn.Type != toColumn.Type || !slices.Equal(fromColumn.Constraints, toColumn.Constraints) {
+			tableDiff.ChangedColumns = append(tableDiff.ChangedColumns, name)
+		}
+	}
+	slices.Sort(tableDiff.ChangedColumns)
+
+	if len(tableDiff.AddedColumns) == 0 && len(tableDiff.DroppedColumns) == 0 && len(tableDiff.ChangedColumns) == 0 {
+		return nil
+	}
+
+	return tableDiff
+}
+
+func diffKeys[T any](from, to map[string]T) (added, dropped []string) {
+	for name := range to {
+		if _, ok := from[name]; !ok {
+			added = append(added, name)
+		}
+	}
+
+	for name := range from {
+		if _, ok := to[name]; !ok {
+			dropped = append(dropped, name)
+		}
+	}
+
+	slices.Sort(added)
+	slices.Sort(dropped)
+
+	return added, dropped
+}
@@ -2,44 +2,53 @@ package migrations
 
 import (
 	"fmt"
-	"log/slog"
+	"strings"
 	"vislab/sources/migrations/types"
 
 	pg_query "github.com/pganalyze/pg_query_go/v5"
 )
 
-func parseDropTable(stmt *pg_query.Node_DropStmt, tables map[string]*types.Table, indexes map[string]*types.Index, triggers map[string]*types.Trigger) error {
+func parseDropTable(stmt *pg_query.Node_DropStmt, tables map[string]*types.Table, indexes map[string]*types.Index, triggers map[string]*types.Trigger) (types.DiagnosticStatus, error) {
+	missing := []string{}
+
 	for _, object := range stmt.DropStmt.Objects {
 		switch oNode := object.Node.(type) {
 		case *pg_query.Node_List:
-			for _, item := range oNode.List.Items {
-				switch iNode := item.Node.(type) {
-				case *pg_query.Node_String_:
-					if _, ok := tables[iNode.String_.Sval]; !ok {
-						slog.Error("table not found in existing list", "table", iNode.String_.Sval)
-						continue
-					}
-
-					delete(tables, iNode.String_.Sval)
-
-					for _, index := range indexes {
-						if index.Table == iNode.String_.Sval {
-							delete(indexes, index.Name)
-						}
-					}
-
-					for _, trigger := range triggers {
-						if trigger.Table == iNode.String_.Sval {
-							delete(triggers, trigger.Name)
-						}
-					}
-				default:
-					slog.Error("unimplemented drop table item type", "type", fmt.Sprintf("%T", iNode))
+			key, err := listKey(oNode.List.Items)
+			if err != nil {
+				return types.DiagnosticError, fmt.Errorf("failed to read dropped table name: %w", err)
+			}
+
+			if _, ok := tables[key]; !ok {
+				if !stmt.DropStmt.MissingOk {
+					return types.DiagnosticError, fmt.Errorf("table not found in existing list: %s", key)
+				}
+
+				missing = append(missing, key)
+				continue
+			}
+
+			delete(tables, key)
+
+			for _, index := range indexes {
+				if types.TableKey(index.Schema, index.Table) == key {
+					delete(indexes, index.Name)
+				}
+			}
+
+			for _, trigger := range triggers {
+				if types.TableKey(trigger.Schema, trigger.Table) == key {
+					delete(triggers, trigger.Name)
 				}
 			}
 		default:
-			slog.Error("unimplemented drop table type", "type", fmt.Sprintf("%T", oNode))
+			return types.DiagnosticUnsupported, fmt.Errorf("unimplemented drop table object type: %T", oNode)
 		}
 	}
-	return nil
+
+	if len(missing) > 0 {
+		return types.DiagnosticSkipped, fmt.Errorf("tables do not exist: %s", strings.Join(missing, ", "))
+	}
+
+	return types.DiagnosticOK, nil
 }
@@ -0,0 +1,45 @@
+package migrations
+
+import (
+	"fmt"
+	"vislab/sources/migrations/types"
+
+	pg_query "github.com/pganalyze/pg_query_go/v5"
+)
+
+func parseCreateExtension(stmt *pg_query.Node_CreateExtensionStmt, extensions map[string]*types.Extension) (*types.Extension, error) {
+	extension := &types.Extension{
+		Name:   stmt.CreateExtensionStmt.Extname,
+		Schema: "public",
+	}
+
+	for _, option := range stmt.CreateExtensionStmt.Options {
+		defElem, ok := option.Node.(*pg_query.Node_DefElem)
+		if !ok || defElem.DefElem.Defname != "schema" {
+			continue
+		}
+
+		if arg, ok := defElem.DefElem.Arg.GetNode().(*pg_query.Node_String_); ok {
+			extension.Schema = arg.String_.Sval
+		}
+	}
+
+	return extension, nil
+}
+
+func parseDropExtension(stmt *pg_query.Node_DropStmt, extensions map[string]*types.Extension) error {
+	for _, object := range stmt.DropStmt.Objects {
+		nameNode, ok := object.Node.(*pg_query.Node_String_)
+		if !ok {
+			return fmt.Errorf("unimplemented drop extension object type: %T", object.Node)
+		}
+
+		if _, ok := extensions[nameNode.String_.Sval]; !ok && !stmt.DropStmt.MissingOk {
+			return fmt.Errorf("extension %s not found", nameNode.String_.Sval)
+		}
+
+		delete(extensions, nameNode.String_.Sval)
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package migrations
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"vislab/sources/migrations/types"
+
+	pg_query "github.com/pganalyze/pg_query_go/v5"
+)
+
+func parseForeignKey(constraint *pg_query.Constraint, table *types.Table, columns []string) (*types.ForeignKey, error) {
+	if constraint.Pktable == nil {
+		return nil, fmt.Errorf("foreign key without referenced table")
+	}
+
+	fk := &types.ForeignKey{
+		Name:       constraint.Conname,
+		Columns:    columns,
+		RefSchema:  constraint.Pktable.Schemaname,
+		RefTable:   constraint.Pktable.Relname,
+		RefColumns: []string{},
+	}
+
+	if fk.RefSchema == "" {
+		fk.RefSchema = "public"
+	}
+
+	if len(constraint.FkAttrs) > 0 {
+		fk.Columns = constraintKeys(constraint.FkAttrs)
+	}
+
+	fk.RefColumns = constraintKeys(constraint.PkAttrs)
+
+	if fk.Name == "" {
+		fk.Name = fmt.Sprintf("%s_%s_fkey", table.Name, strings.Join(fk.Columns, "_"))
+	}
+
+	return fk, nil
+}
+
+func parseColumnForeignKeys(def *pg_query.ColumnDef, table *types.Table) []*types.ForeignKey {
+	fks := []*types.ForeignKey{}
+
+	for _, constr := range def.Constraints {
+		cNode, ok := constr.Node.(*pg_query.Node_Constraint)
+		if !ok || cNode.Constraint.Contype != pg_query.ConstrType_CONSTR_FOREIGN {
+			continue
+		}
+
+		fk, err := parseForeignKey(cNode.Constraint, table, []string{def.Colname})
+		if err != nil {
+			slog.Error("failed to parse foreign key", "table", table.Name, "column", def.Colname, "error", err)
+			continue
+		}
+
+		fks = append(fks, fk)
+	}
+
+	return fks
+}
+
+func addForeignKey(table *types.Table, fk *types.ForeignKey) {
+	for i, existing := range table.ForeignKeys {
+		if existing.Name == fk.Name {
+			table.ForeignKeys[i] = fk
+			return
+		}
+	}
+
+	table.ForeignKeys = append(table.ForeignKeys, fk)
+}
+
+func dropForeignKey(table *types.Table, name string) {
+	for i, fk := range table.ForeignKeys {
+		if fk.Name == name {
+			table.ForeignKeys = append(table.ForeignKeys[:i], table.ForeignKeys[i+1:]...)
+			return
+		}
+	}
+}
+
+func constraintKeys(keys []*pg_query.Node) []string {
+	columns := []string{}
+
+	for _, key := range keys {
+		switch kNode := key.Node.(type) {
+		case *pg_query.Node_String_:
+			columns = append(columns, kNode.String_.Sval)
+		default:
+			slog.Error("unimplemented constraint key type", "type", fmt.Sprintf("%T", kNode))
+		}
+	}
+
+	return columns
+}
@@ -2,7 +2,6 @@ package migrations
 
 import (
 	"fmt"
-	"log/slog"
 	"slices"
 	"strings"
 	"vislab/sources/migrations/types"
@@ -19,186 +18,284 @@ func NewParser() (*Parser, error) {
 	return p, nil
 }
 
-func (p *Parser) Parse(in []byte, out *types.All) error {
-	str := strings.ReplaceAll(string(in), "-- SQL in this section is executed when the migration is rolled back.\n", "")
-	str = strings.ReplaceAll(str, "-- SQL in this section is executed when the migration is applied.\n", "")
-	str = strings.ReplaceAll(str, "-- +goose StatementBegin\n", "")
-	str = strings.ReplaceAll(str, "-- +goose StatementEnd\n", "")
+// Parse applies every statement of in to out. Problems with single statements
+// are reported as diagnostics and do not stop parsing, the returned error is
+// only set when the SQL cannot be parsed at all.
+func (p *Parser) Parse(file string, in []byte, out *types.All) ([]*types.Diagnostic, error) {
+	stmts, err := pg_query.Parse(string(in))
+	if err != nil {
+		return []*types.Diagnostic{{
+			File:    file,
+			Status:  types.DiagnosticError,
+			Message: err.Error(),
+		}}, err
+	}
 
-	str = strings.TrimPrefix(str, "-- +goose Up\n")
-	parts := strings.SplitN(str, "-- +goose Down\n", 2)
+	diagnostics := make([]*types.Diagnostic, 0, len(stmts.Stmts))
 
-	if len(parts) != 2 {
-		return fmt.Errorf("file does not contain up and down migrations")
-	}
+	for i, stmt := range stmts.Stmts {
+		status, err := p.parseStmt(stmt.Stmt, out)
 
-	stmt, err := pg_query.Parse(parts[0])
-	if err != nil {
-		return err
-	}
+		diagnostic := &types.Diagnostic{
+			File:      file,
+			Index:     i,
+			Offset:    stmt.StmtLocation,
+			Statement: stmtName(stmt.Stmt),
+			Status:    status,
+		}
 
-	for _, stmt := range stmt.Stmts {
-		switch stmt := stmt.Stmt.Node.(type) {
-		case *pg_query.Node_CreateStmt:
-			table, err := parseCreateTable(stmt, out.Tables)
-			if err != nil {
-				return err
-			}
+		if err != nil {
+			diagnostic.Message = err.Error()
+		}
 
-			out.Tables[table.Name] = table
-
-		case *pg_query.Node_DropStmt:
-			switch stmt.DropStmt.RemoveType {
-			case pg_query.ObjectType_OBJECT_TABLE:
-				if err := parseDropTable(stmt, out.Tables, out.Indexes, out.Triggers); err != nil {
-					return err
-				}
-			case pg_query.ObjectType_OBJECT_INDEX:
-				if err := parseDropIndex(stmt, out.Indexes); err != nil {
-					return err
-				}
-			case pg_query.ObjectType_OBJECT_FUNCTION:
-				if err := parseDropFunc(stmt, out.Funcs); err != nil {
-					return err
-				}
-			case pg_query.ObjectType_OBJECT_TRIGGER:
-				if err := parseDropTrigger(stmt, out.Triggers); err != nil {
-					return err
-				} // TODO: add support for triggers ("on table" reads as another trigger)
-			case pg_query.ObjectType_OBJECT_TYPE:
-				if err := parseDropType(stmt, out.Types); err != nil {
-					return err
-				}
-			default:
-				slog.Error("unimplemented drop type", "type", stmt.DropStmt.RemoveType)
-			}
-		case *pg_query.Node_CreateFunctionStmt:
-			function, err := parseCreateFunc(stmt, out.Funcs)
-			if err != nil {
-				return err
-			}
+		diagnostics = append(diagnostics, diagnostic)
+	}
 
-			fs, ok := out.Funcs[function.Name]
-			if !ok {
-				out.Funcs[function.Name] = []*types.Func{function}
-			} else {
-				if !slices.ContainsFunc(fs, func(f *types.Func) bool {
-					return f.Equal(function)
-				}) {
-					out.Funcs[function.Name] = append(fs, function)
-				}
-			}
-		case *pg_query.Node_IndexStmt:
-			index, err := parseCreateIndex(stmt, out.Indexes)
-			if err != nil {
-				return err
-			}
+	return diagnostics, nil
+}
 
-			out.Indexes[index.Name] = index
-		case *pg_query.Node_AlterTableStmt:
-			table, err := parseAlterTable(stmt, out.Tables)
-			if err != nil {
-				return err
-			}
+func (p *Parser) parseStmt(node *pg_query.Node, out *types.All) (types.DiagnosticStatus, error) {
+	switch stmt := node.Node.(type) {
+	case *pg_query.Node_CreateStmt:
+		table, err := parseCreateTable(stmt, out.Tables)
+		if err != nil {
+			return types.DiagnosticError, err
+		}
 
-			fmt.Printf("alter_table: %v\n", table.Name)
-		case *pg_query.Node_CreateTrigStmt:
-			trigger, err := parseCreateTrigger(stmt, out.Triggers)
-			if err != nil {
-				return err
+		out.Tables[table.Key()] = table
+	case *pg_query.Node_DropStmt:
+		switch stmt.DropStmt.RemoveType {
+		case pg_query.ObjectType_OBJECT_TABLE:
+			return parseDropTable(stmt, out.Tables, out.Indexes, out.Triggers)
+		case pg_query.ObjectType_OBJECT_INDEX:
+			if err := parseDropIndex(stmt, out.Indexes); err != nil {
+				return types.DiagnosticError, err
 			}
-
-			out.Triggers[trigger.Name] = trigger
-		case *pg_query.Node_CommentStmt:
-			var commentType string
-			switch stmt.CommentStmt.Objtype {
-			case pg_query.ObjectType_OBJECT_TABLE:
-				commentType = "table"
-			case pg_query.ObjectType_OBJECT_INDEX:
-				commentType = "index"
-			case pg_query.ObjectType_OBJECT_COLUMN:
-				commentType = "column"
-			default:
-				slog.Error("unimplemented comment type", "type", commentType)
+		case pg_query.ObjectType_OBJECT_FUNCTION:
+			if err := parseDropFunc(stmt, out.Funcs); err != nil {
+				return types.DiagnosticError, err
+			}
+		case pg_query.ObjectType_OBJECT_TRIGGER:
+			if err := parseDropTrigger(stmt, out.Triggers); err != nil {
+				return types.DiagnosticError, err
+			} // TODO: add support for triggers ("on table" reads as another trigger)
+		case pg_query.ObjectType_OBJECT_TYPE:
+			if err := parseDropType(stmt, out.Types); err != nil {
+				return types.DiagnosticError, err
 			}
-			switch node := stmt.CommentStmt.Object.Node.(type) {
-			case *pg_query.Node_List:
-				objects := []string{}
-
-				for _, item := range node.List.Items {
-					switch itemNode := item.Node.(type) {
-					case *pg_query.Node_String_:
-						objects = append(objects, itemNode.String_.Sval)
-					default:
-						slog.Error("unimplemented comment item type", "type", fmt.Sprintf("%T", itemNode))
-					}
-				}
-			default:
-				slog.Error("unimplemented comment object type", "type", fmt.Sprintf("%T", node))
+		case pg_query.ObjectType_OBJECT_VIEW, pg_query.ObjectType_OBJECT_MATVIEW:
+			if err := parseDropView(stmt, out.Views); err != nil {
+				return types.DiagnosticError, err
 			}
-		case *pg_query.Node_RenameStmt:
-			switch stmt.RenameStmt.RenameType {
-			case pg_query.ObjectType_OBJECT_TABLE:
-				table, err := parseRenameTable(stmt, out.Tables)
-				if err != nil {
-					return err
-				}
-
-				out.Tables[table.Name] = table
-			case pg_query.ObjectType_OBJECT_INDEX:
-				index, err := parseRenameIndex(stmt, out.Indexes)
-				if err != nil {
-					return err
-				}
-
-				out.Indexes[index.Name] = index
-			case pg_query.ObjectType_OBJECT_COLUMN:
-				column, err := parseRenameColumn(stmt, out.Tables)
-				if err != nil {
-					return err
-				}
-
-				fmt.Printf("rename_column_in_table: %v\n", column)
-			default:
-				slog.Error("unimplemented rename type", "type", stmt.RenameStmt.RenameType)
+		case pg_query.ObjectType_OBJECT_EXTENSION:
+			if err := parseDropExtension(stmt, out.Extensions); err != nil {
+				return types.DiagnosticError, err
 			}
-		case *pg_query.Node_UpdateStmt:
-			if err := parseUpdate(stmt, out.Tables); err != nil {
-				return err
+		case pg_query.ObjectType_OBJECT_SEQUENCE:
+			if err := parseDropSequence(stmt, out.Sequences); err != nil {
+				return types.DiagnosticError, err
 			}
-		case *pg_query.Node_InsertStmt:
-			if err := parseInsert(stmt, out.Tables); err != nil {
-				return err
+		default:
+			return types.DiagnosticUnsupported, fmt.Errorf("unsupported drop type: %s", stmt.DropStmt.RemoveType)
+		}
+	case *pg_query.Node_CreateFunctionStmt:
+		function, err := parseCreateFunc(stmt, out.Funcs)
+		if err != nil {
+			return types.DiagnosticError, err
+		}
+
+		fs, ok := out.Funcs[function.Name]
+		if !ok {
+			out.Funcs[function.Name] = []*types.Func{function}
+		} else {
+			if !slices.ContainsFunc(fs, func(f *types.Func) bool {
+				return f.Equal(function)
+			}) {
+				out.Funcs[function.Name] = append(fs, function)
 			}
-		case *pg_query.Node_DeleteStmt:
-			if err := parseDelete(stmt, out.Tables); err != nil {
-				return err
+		}
+	case *pg_query.Node_IndexStmt:
+		index, err := parseCreateIndex(stmt, out.Indexes)
+		if err != nil {
+			return types.DiagnosticError, err
+		}
+
+		out.Indexes[index.Name] = index
+	case *pg_query.Node_AlterTableStmt:
+		if stmt.AlterTableStmt.Objtype != pg_query.ObjectType_OBJECT_TABLE {
+			return types.DiagnosticSkipped, fmt.Errorf("alter %s is not stored", stmt.AlterTableStmt.Objtype)
+		}
+
+		_, status, err := parseAlterTable(stmt, out.Tables)
+		return status, err
+	case *pg_query.Node_CreateTrigStmt:
+		trigger, err := parseCreateTrigger(stmt, out.Triggers)
+		if err != nil {
+			return types.DiagnosticError, err
+		}
+
+		out.Triggers[trigger.Name] = trigger
+	case *pg_query.Node_CommentStmt:
+		return types.DiagnosticSkipped, fmt.Errorf("comments are not stored")
+	case *pg_query.Node_RenameStmt:
+		switch stmt.RenameStmt.RenameType {
+		case pg_query.ObjectType_OBJECT_TABLE:
+			if _, err := parseRenameTable(stmt, out.Tables, out.Indexes, out.Triggers); err != nil {
+				return types.DiagnosticError, err
 			}
-		case *pg_query.Node_CreateEnumStmt:
-			ty, err := parseCreateType(stmt, out.Types)
+		case pg_query.ObjectType_OBJECT_INDEX:
+			index, err := parseRenameIndex(stmt, out.Indexes)
 			if err != nil {
-				return err
+				return types.DiagnosticError, err
 			}
 
-			out.Types[ty.Name] = ty
-		case *pg_query.Node_AlterEnumStmt:
-			ty, err := parseAlterType(stmt, out.Types)
+			out.Indexes[index.Name] = index
+		case pg_query.ObjectType_OBJECT_VIEW, pg_query.ObjectType_OBJECT_MATVIEW:
+			view, err := parseRenameView(stmt, out.Views)
 			if err != nil {
-				return err
+				return types.DiagnosticError, err
+			}
+
+			out.Views[view.Name] = view
+		case pg_query.ObjectType_OBJECT_COLUMN:
+			if _, err := parseRenameColumn(stmt, out.Tables, out.Indexes); err != nil {
+				return types.DiagnosticError, err
 			}
+		default:
+			return types.DiagnosticUnsupported, fmt.Errorf("unsupported rename type: %s", stmt.RenameStmt.RenameType)
+		}
+	case *pg_query.Node_AlterObjectSchemaStmt:
+		if stmt.AlterObjectSchemaStmt.ObjectType != pg_query.ObjectType_OBJECT_TABLE {
+			return types.DiagnosticUnsupported, fmt.Errorf("unsupported set schema type: %s", stmt.AlterObjectSchemaStmt.ObjectType)
+		}
+
+		if _, err := parseAlterTableSchema(stmt, out.Tables, out.Indexes, out.Triggers); err != nil {
+			return types.DiagnosticError, err
+		}
+	case *pg_query.Node_UpdateStmt:
+		return parseUpdate(stmt, out.Tables)
+	case *pg_query.Node_InsertStmt:
+		return parseInsert(stmt, out.Tables)
+	case *pg_query.Node_DeleteStmt:
+		return parseDelete(stmt, out.Tables)
+	case *pg_query.Node_CreateEnumStmt:
+		ty, err := parseCreateType(stmt, out.Types)
+		if err != nil {
+			return types.DiagnosticError, err
+		}
 
-			out.Types[ty.Name] = ty
-		case *pg_query.Node_CreateTableAsStmt:
-			table, err := parseCreateTableAs(stmt, out.Tables)
+		out.Types[ty.Name] = ty
+	case *pg_query.Node_AlterEnumStmt:
+		ty, err := parseAlterType(stmt, out.Types)
+		if err != nil {
+			return types.DiagnosticError, err
+		}
+
+		out.Types[ty.Name] = ty
+	case *pg_query.Node_ViewStmt:
+		view, err := parseCreateView(stmt, out.Tables)
+		if err != nil {
+			return types.DiagnosticError, err
+		}
+
+		out.Views[view.Name] = view
+	case *pg_query.Node_CreateTableAsStmt:
+		if stmt.CreateTableAsStmt.Objtype == pg_query.ObjectType_OBJECT_MATVIEW {
+			view, err := parseCreateMatView(stmt, out.Tables)
 			if err != nil {
-				return err
+				return types.DiagnosticError, err
 			}
 
-			out.Tables[table.Name] = table
+			out.Views[view.Name] = view
+			break
+		}
+
+		table, err := parseCreateTableAs(stmt, out.Tables)
+		if err != nil {
+			return types.DiagnosticError, err
+		}
+
+		out.Tables[table.Key()] = table
+	case *pg_query.Node_CreateRoleStmt:
+		role, err := parseCreateRole(stmt, out.Roles)
+		if err != nil {
+			return types.DiagnosticError, err
+		}
+
+		out.Roles[role.Name] = role
+	case *pg_query.Node_DropRoleStmt:
+		if err := parseDropRole(stmt, out.Roles, out.Grants); err != nil {
+			return types.DiagnosticError, err
+		}
+	case *pg_query.Node_GrantRoleStmt:
+		if err := parseGrantRole(stmt, out.Roles); err != nil {
+			return types.DiagnosticError, err
+		}
+	case *pg_query.Node_GrantStmt:
+		return parseGrant(stmt, out.Grants)
+	case *pg_query.Node_CreateExtensionStmt:
+		extension, err := parseCreateExtension(stmt, out.Extensions)
+		if err != nil {
+			return types.DiagnosticError, err
+		}
+
+		out.Extensions[extension.Name] = extension
+	case *pg_query.Node_CreateSeqStmt:
+		sequence, err := parseCreateSequence(stmt, out.Sequences)
+		if err != nil {
+			return types.DiagnosticError, err
+		}
+
+		out.Sequences[sequence.Name] = sequence
+	case *pg_query.Node_AlterOwnerStmt:
+		return types.DiagnosticSkipped, fmt.Errorf("owners of %s are not stored", stmt.AlterOwnerStmt.ObjectType)
+	case *pg_query.Node_TransactionStmt, *pg_query.Node_VariableSetStmt:
+		return types.DiagnosticSkipped, nil
+	default:
+		return types.DiagnosticUnsupported, fmt.Errorf("unsupported statement: %s", stmtName(node))
+	}
+
+	return types.DiagnosticOK, nil
+}
+
+func stmtName(node *pg_query.Node) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", node.Node), "*pg_query.Node_")
+}
+
+func relationKey(rel *pg_query.RangeVar) string {
+	return types.TableKey(rel.Schemaname, rel.Relname)
+}
+
+// relationName keeps the schema only when the statement names it, so bare
+// names can still be matched against ctes
+func relationName(rel *pg_query.RangeVar) string {
+	if rel.Schemaname == "" {
+		return rel.Relname
+	}
+
+	return rel.Schemaname + "." + rel.Relname
+}
+
+// listKey builds the table key of a qualified name list like the objects of a
+// drop statement
+func listKey(items []*pg_query.Node) (string, error) {
+	names := make([]string, 0, len(items))
+
+	for _, item := range items {
+		switch iNode := item.Node.(type) {
+		case *pg_query.Node_String_:
+			names = append(names, iNode.String_.Sval)
 		default:
-			slog.Error("unimplemented type", "type", fmt.Sprintf("%T", stmt))
+			return "", fmt.Errorf("unimplemented name item type: %T", iNode)
 		}
 	}
 
-	return nil
+	switch len(names) {
+	case 1:
+		return types.TableKey("", names[0]), nil
+	case 2:
+		return types.TableKey(names[0], names[1]), nil
+	default:
+		return "", fmt.Errorf("unsupported name: %s", strings.Join(names, "."))
+	}
 }
@@ -7,17 +7,51 @@ import (
 	pg_query "github.com/pganalyze/pg_query_go/v5"
 )
 
-func parseRenameColumn(stmt *pg_query.Node_RenameStmt, tables map[string]*types.Table) (*types.Table, error) {
-	table, ok := tables[stmt.RenameStmt.Relation.Relname]
+func parseRenameColumn(stmt *pg_query.Node_RenameStmt, tables map[string]*types.Table, indexes map[string]*types.Index) (*types.Table, error) {
+	table, ok := tables[relationKey(stmt.RenameStmt.Relation)]
 	if !ok {
 		return nil, fmt.Errorf("table %s not found", stmt.RenameStmt.Relation.Relname)
 	}
 
+	oldName, newName := stmt.RenameStmt.Subname, stmt.RenameStmt.Newname
+
+	found := false
 	for _, column := range table.Columns {
-		if column.Name == stmt.RenameStmt.Subname {
-			column.Name = stmt.RenameStmt.Newname
+		if column.Name == oldName {
+			column.Name = newName
+			found = true
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("column %s not found in table %s", oldName, table.Name)
+	}
+
+	for _, fk := range table.ForeignKeys {
+		renameInList(fk.Columns, oldName, newName)
+	}
+
+	for _, otherTable := range tables {
+		for _, fk := range otherTable.ForeignKeys {
+			if fk.RefTable == table.Name && fk.RefSchema == table.Schema {
+				renameInList(fk.RefColumns, oldName, newName)
+			}
+		}
+	}
+
+	for _, index := range indexes {
+		if types.TableKey(index.Schema, index.Table) == table.Key() {
+			renameInList(index.Columns, oldName, newName)
 		}
 	}
 
 	return table, nil
 }
+
+func renameInList(list []string, oldName, newName string) {
+	for i, name := range list {
+		if name == oldName {
+			list[i] = newName
+		}
+	}
+}
@@ -7,14 +7,58 @@ import (
 	pg_query "github.com/pganalyze/pg_query_go/v5"
 )
 
-func parseRenameTable(stmt *pg_query.Node_RenameStmt, tables map[string]*types.Table) (*types.Table, error) {
-	table, ok := tables[stmt.RenameStmt.Relation.Relname]
+func parseRenameTable(stmt *pg_query.Node_RenameStmt, tables map[string]*types.Table, indexes map[string]*types.Index, triggers map[string]*types.Trigger) (*types.Table, error) {
+	table, ok := tables[relationKey(stmt.RenameStmt.Relation)]
 	if !ok {
 		return nil, fmt.Errorf("table %s not found", stmt.RenameStmt.Relation.Relname)
 	}
-	delete(tables, stmt.RenameStmt.Relation.Relname)
 
-	table.Name = stmt.RenameStmt.Newname
+	moveTable(table, table.Schema, stmt.RenameStmt.Newname, tables, indexes, triggers)
 
 	return table, nil
 }
+
+func parseAlterTableSchema(stmt *pg_query.Node_AlterObjectSchemaStmt, tables map[string]*types.Table, indexes map[string]*types.Index, triggers map[string]*types.Trigger) (*types.Table, error) {
+	if stmt.AlterObjectSchemaStmt.Relation == nil {
+		return nil, fmt.Errorf("set schema without table")
+	}
+
+	table, ok := tables[relationKey(stmt.AlterObjectSchemaStmt.Relation)]
+	if !ok {
+		return nil, fmt.Errorf("table %s not found", stmt.AlterObjectSchemaStmt.Relation.Relname)
+	}
+
+	moveTable(table, stmt.AlterObjectSchemaStmt.Newschema, table.Name, tables, indexes, triggers)
+
+	return table, nil
+}
+
+// moveTable re-keys the table and points its indexes, triggers and the foreign
+// keys referencing it to the new name, they are looked up by it later on.
+func moveTable(table *types.Table, schema, name string, tables map[string]*types.Table, indexes map[string]*types.Index, triggers map[string]*types.Trigger) {
+	oldKey := table.Key()
+
+	for _, index := range indexes {
+		if types.TableKey(index.Schema, index.Table) == oldKey {
+			index.Schema, index.Table = schema, name
+		}
+	}
+
+	for _, trigger := range triggers {
+		if types.TableKey(trigger.Schema, trigger.Table) == oldKey {
+			trigger.Schema, trigger.Table = schema, name
+		}
+	}
+
+	for _, other := range tables {
+		for _, fk := range other.ForeignKeys {
+			if types.TableKey(fk.RefSchema, fk.RefTable) == oldKey {
+				fk.RefSchema, fk.RefTable = schema, name
+			}
+		}
+	}
+
+	delete(tables, oldKey)
+	table.Schema, table.Name = schema, name
+	tables[table.Key()] = table
+}
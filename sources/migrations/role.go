@@ -0,0 +1,240 @@
+package migrations
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"vislab/sources/migrations/types"
+
+	pg_query "github.com/pganalyze/pg_query_go/v5"
+)
+
+const allPrivileges = "ALL"
+
+func parseCreateRole(stmt *pg_query.Node_CreateRoleStmt, roles map[string]*types.Role) (*types.Role, error) {
+	role := &types.Role{
+		Name:     stmt.CreateRoleStmt.Role,
+		Login:    stmt.CreateRoleStmt.StmtType == pg_query.RoleStmtType_ROLESTMT_USER,
+		MemberOf: []string{},
+	}
+
+	for _, option := range stmt.CreateRoleStmt.Options {
+		defElem, ok := option.Node.(*pg_query.Node_DefElem)
+		if !ok {
+			continue
+		}
+
+		switch defElem.DefElem.Defname {
+		case "canlogin":
+			if arg, ok := defElem.DefElem.Arg.GetNode().(*pg_query.Node_Boolean); ok {
+				role.Login = arg.Boolean.Boolval
+			}
+		case "addroleto":
+			list, ok := defElem.DefElem.Arg.GetNode().(*pg_query.Node_List)
+			if !ok {
+				continue
+			}
+
+			for _, item := range list.List.Items {
+				name, err := roleName(item)
+				if err != nil {
+					return nil, err
+				}
+
+				role.MemberOf = append(role.MemberOf, name)
+			}
+		}
+	}
+
+	return role, nil
+}
+
+func parseDropRole(stmt *pg_query.Node_DropRoleStmt, roles map[string]*types.Role, grants map[string]*types.Grant) error {
+	for _, roleNode := range stmt.DropRoleStmt.Roles {
+		name, err := roleName(roleNode)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := roles[name]; !ok && !stmt.DropRoleStmt.MissingOk {
+			return fmt.Errorf("role %s not found", name)
+		}
+
+		delete(roles, name)
+
+		for key, grant := range grants {
+			if grant.Role == name {
+				delete(grants, key)
+			}
+		}
+	}
+
+	return nil
+}
+
+func parseGrantRole(stmt *pg_query.Node_GrantRoleStmt, roles map[string]*types.Role) error {
+	granted := []string{}
+
+	for _, grantedRole := range stmt.GrantRoleStmt.GrantedRoles {
+		priv, ok := grantedRole.Node.(*pg_query.Node_AccessPriv)
+		if !ok {
+			return fmt.Errorf("unimplemented granted role type: %T", grantedRole.Node)
+		}
+
+		granted = append(granted, priv.AccessPriv.PrivName)
+	}
+
+	for _, granteeNode := range stmt.GrantRoleStmt.GranteeRoles {
+		name, err := roleName(granteeNode)
+		if err != nil {
+			return err
+		}
+
+		role, ok := roles[name]
+		if !ok {
+			role = &types.Role{Name: name, MemberOf: []string{}}
+			roles[name] = role
+		}
+
+		for _, grantedRole := range granted {
+			if stmt.GrantRoleStmt.IsGrant {
+				if !slices.Contains(role.MemberOf, grantedRole) {
+					role.MemberOf = append(role.MemberOf, grantedRole)
+				}
+				continue
+			}
+
+			role.MemberOf = slices.DeleteFunc(role.MemberOf, func(r string) bool {
+				return r == grantedRole
+			})
+		}
+	}
+
+	return nil
+}
+
+// parseGrant handles GRANT and REVOKE on tables. Schema wide grants are kept
+// with an empty table name and expanded to the schema tables later.
+func parseGrant(stmt *pg_query.Node_GrantStmt, grants map[string]*types.Grant) (types.DiagnosticStatus, error) {
+	if stmt.GrantStmt.Objtype != pg_query.ObjectType_OBJECT_TABLE {
+		return types.DiagnosticSkipped, fmt.Errorf("grants on %s are not stored", stmt.GrantStmt.Objtype)
+	}
+
+	privileges := []string{}
+	for _, privNode := range stmt.GrantStmt.Privileges {
+		priv, ok := privNode.Node.(*pg_query.Node_AccessPriv)
+		if !ok {
+			return types.DiagnosticError, fmt.Errorf("unimplemented privilege type: %T", privNode.Node)
+		}
+
+		privileges = append(privileges, strings.ToUpper(priv.AccessPriv.PrivName))
+	}
+
+	// no privileges means ALL PRIVILEGES
+	if len(privileges) == 0 {
+		privileges = append(privileges, allPrivileges)
+	}
+
+	targets := [][2]string{}
+
+	switch stmt.GrantStmt.Targtype {
+	case pg_query.GrantTargetType_ACL_TARGET_OBJECT:
+		for _, object := range stmt.GrantStmt.Objects {
+			rangeVar, ok := object.Node.(*pg_query.Node_RangeVar)
+			if !ok {
+				return types.DiagnosticError, fmt.Errorf("unimplemented grant object type: %T", object.Node)
+			}
+
+			schema := rangeVar.RangeVar.Schemaname
+			if schema == "" {
+				schema = "public"
+			}
+
+			targets = append(targets, [2]string{schema, rangeVar.RangeVar.Relname})
+		}
+	case pg_query.GrantTargetType_ACL_TARGET_ALL_IN_SCHEMA:
+		for _, object := range stmt.GrantStmt.Objects {
+			schemaNode, ok := object.Node.(*pg_query.Node_String_)
+			if !ok {
+				return types.DiagnosticError, fmt.Errorf("unimplemented grant schema type: %T", object.Node)
+			}
+
+			targets = append(targets, [2]string{schemaNode.String_.Sval, ""})
+		}
+	default:
+		return types.DiagnosticSkipped, fmt.Errorf("default privileges are not stored")
+	}
+
+	for _, granteeNode := range stmt.GrantStmt.Grantees {
+		role, err := roleName(granteeNode)
+		if err != nil {
+			return types.DiagnosticError, err
+		}
+
+		for _, target := range targets {
+			key := grantKey(role, target[0], target[1])
+
+			grant, ok := grants[key]
+			if !ok {
+				if !stmt.GrantStmt.IsGrant {
+					continue
+				}
+
+				grant = &types.Grant{
+					Role:       role,
+					Schema:     target[0],
+					Table:      target[1],
+					Privileges: []string{},
+				}
+				grants[key] = grant
+			}
+
+			if stmt.GrantStmt.IsGrant {
+				for _, privilege := range privileges {
+					if !slices.Contains(grant.Privileges, privilege) {
+						grant.Privileges = append(grant.Privileges, privilege)
+					}
+				}
+				continue
+			}
+
+			if slices.Contains(privileges, allPrivileges) {
+				delete(grants, key)
+				continue
+			}
+
+			grant.Privileges = slices.DeleteFunc(grant.Privileges, func(p string) bool {
+				return slices.Contains(privileges, p)
+			})
+			if len(grant.Privileges) == 0 {
+				delete(grants, key)
+			}
+		}
+	}
+
+	return types.DiagnosticOK, nil
+}
+
+func grantKey(role, schema, table string) string {
+	return role + ":" + schema + "." + table
+}
+
+func roleName(node *pg_query.Node) (string, error) {
+	roleSpec, ok := node.Node.(*pg_query.Node_RoleSpec)
+	if !ok {
+		return "", fmt.Errorf("unimplemented role type: %T", node.Node)
+	}
+
+	return roleSpecName(roleSpec.RoleSpec), nil
+}
+
+func roleSpecName(roleSpec *pg_query.RoleSpec) string {
+	switch roleSpec.Roletype {
+	case pg_query.RoleSpecType_ROLESPEC_PUBLIC:
+		return "public"
+	case pg_query.RoleSpecType_ROLESPEC_CURRENT_USER, pg_query.RoleSpecType_ROLESPEC_SESSION_USER, pg_query.RoleSpecType_ROLESPEC_CURRENT_ROLE:
+		return "current_user"
+	default:
+		return roleSpec.Rolename
+	}
+}
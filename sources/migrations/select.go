@@ -3,6 +3,7 @@ package migrations
 import (
 	"fmt"
 	"log/slog"
+	"slices"
 	"vislab/sources/migrations/types"
 
 	pg_query "github.com/pganalyze/pg_query_go/v5"
@@ -14,37 +15,169 @@ func parseSelect(stmt *pg_query.Node_SelectStmt, tables map[string]*types.Table)
 		Tables:  []string{},
 	}
 
-	for _, target := range stmt.SelectStmt.TargetList {
+	ctes := []string{}
+	collectSelect(stmt.SelectStmt, selectSt, &ctes, true)
+
+	selectSt.Tables = slices.DeleteFunc(selectSt.Tables, func(table string) bool {
+		return slices.Contains(ctes, table)
+	})
+
+	return selectSt, nil
+}
+
+func collectSelect(stmt *pg_query.SelectStmt, selectSt *types.Select, ctes *[]string, topLevel bool) {
+	if stmt == nil {
+		return
+	}
+
+	if stmt.WithClause != nil {
+		for _, cte := range stmt.WithClause.Ctes {
+			cteNode, ok := cte.Node.(*pg_query.Node_CommonTableExpr)
+			if !ok {
+				slog.Error("unimplemented with clause type", "type", fmt.Sprintf("%T", cte.Node))
+				continue
+			}
+
+			*ctes = append(*ctes, cteNode.CommonTableExpr.Ctename)
+			collectSubquery(cteNode.CommonTableExpr.Ctequery, selectSt, ctes)
+		}
+	}
+
+	// UNION, INTERSECT and EXCEPT keep their parts in larg and rarg
+	if stmt.Op != pg_query.SetOperation_SETOP_NONE {
+		collectSelect(stmt.Larg, selectSt, ctes, topLevel)
+		collectSelect(stmt.Rarg, selectSt, ctes, false)
+		return
+	}
+
+	for _, target := range stmt.TargetList {
 		switch targetNode := target.Node.(type) {
 		case *pg_query.Node_ResTarget:
-			switch valNode := targetNode.ResTarget.Val.Node.(type) {
-			case *pg_query.Node_ColumnRef:
-				for _, field := range valNode.ColumnRef.Fields {
-					switch fieldNode := field.Node.(type) {
-					case *pg_query.Node_String_:
-						selectSt.Columns = append(selectSt.Columns, fieldNode.String_.Sval)
-					case *pg_query.Node_AStar:
-						selectSt.Columns = append(selectSt.Columns, "*")
-					default:
-						slog.Error("unimplemented column type", "type", fmt.Sprintf("%T", fieldNode))
-					}
-				}
-			default:
-				slog.Error("unimplemented column type", "type", fmt.Sprintf("%T", valNode))
+			if topLevel {
+				collectColumn(targetNode.ResTarget, selectSt)
 			}
+
+			collectExpr(targetNode.ResTarget.Val, selectSt, ctes)
 		default:
 			slog.Error("unimplemented column type", "type", fmt.Sprintf("%T", targetNode))
 		}
 	}
 
-	for _, fromClause := range stmt.SelectStmt.FromClause {
-		switch node := fromClause.Node.(type) {
-		case *pg_query.Node_RangeVar:
-			selectSt.Tables = append(selectSt.Tables, node.RangeVar.Relname)
+	for _, fromClause := range stmt.FromClause {
+		collectFrom(fromClause, selectSt, ctes)
+	}
+
+	collectExpr(stmt.WhereClause, selectSt, ctes)
+	collectExpr(stmt.HavingClause, selectSt, ctes)
+}
+
+func collectColumn(target *pg_query.ResTarget, selectSt *types.Select) {
+	if target.Name != "" {
+		selectSt.Columns = append(selectSt.Columns, target.Name)
+		return
+	}
+
+	if target.Val == nil {
+		return
+	}
+
+	switch valNode := target.Val.Node.(type) {
+	case *pg_query.Node_ColumnRef:
+		if len(valNode.ColumnRef.Fields) == 0 {
+			return
+		}
+
+		switch fieldNode := valNode.ColumnRef.Fields[len(valNode.ColumnRef.Fields)-1].Node.(type) {
+		case *pg_query.Node_String_:
+			selectSt.Columns = append(selectSt.Columns, fieldNode.String_.Sval)
+		case *pg_query.Node_AStar:
+			selectSt.Columns = append(selectSt.Columns, "*")
 		default:
-			slog.Error("unimplemented from clause type", "type", fmt.Sprintf("%T", node))
+			slog.Error("unimplemented column type", "type", fmt.Sprintf("%T", fieldNode))
 		}
+	default:
+		slog.Debug("unnamed select expression", "type", fmt.Sprintf("%T", valNode))
 	}
+}
 
-	return selectSt, nil
+func collectFrom(node *pg_query.Node, selectSt *types.Select, ctes *[]string) {
+	if node == nil {
+		return
+	}
+
+	switch fromNode := node.Node.(type) {
+	case *pg_query.Node_RangeVar:
+		if name := relationName(fromNode.RangeVar); !slices.Contains(selectSt.Tables, name) {
+			selectSt.Tables = append(selectSt.Tables, name)
+		}
+	case *pg_query.Node_JoinExpr:
+		collectFrom(fromNode.JoinExpr.Larg, selectSt, ctes)
+		collectFrom(fromNode.JoinExpr.Rarg, selectSt, ctes)
+		collectExpr(fromNode.JoinExpr.Quals, selectSt, ctes)
+	case *pg_query.Node_RangeSubselect:
+		collectSubquery(fromNode.RangeSubselect.Subquery, selectSt, ctes)
+	case *pg_query.Node_RangeFunction:
+		slog.Debug("skipping function in from clause")
+	default:
+		slog.Error("unimplemented from clause type", "type", fmt.Sprintf("%T", fromNode))
+	}
+}
+
+// subqueries can hide in any expression, so the walk only descends into the
+// nodes that can hold one
+func collectExpr(node *pg_query.Node, selectSt *types.Select, ctes *[]string) {
+	if node == nil {
+		return
+	}
+
+	switch exprNode := node.Node.(type) {
+	case *pg_query.Node_SubLink:
+		collectSubquery(exprNode.SubLink.Subselect, selectSt, ctes)
+		collectExpr(exprNode.SubLink.Testexpr, selectSt, ctes)
+	case *pg_query.Node_BoolExpr:
+		for _, arg := range exprNode.BoolExpr.Args {
+			collectExpr(arg, selectSt, ctes)
+		}
+	case *pg_query.Node_AExpr:
+		collectExpr(exprNode.AExpr.Lexpr, selectSt, ctes)
+		collectExpr(exprNode.AExpr.Rexpr, selectSt, ctes)
+	case *pg_query.Node_FuncCall:
+		for _, arg := range exprNode.FuncCall.Args {
+			collectExpr(arg, selectSt, ctes)
+		}
+	case *pg_query.Node_CoalesceExpr:
+		for _, arg := range exprNode.CoalesceExpr.Args {
+			collectExpr(arg, selectSt, ctes)
+		}
+	case *pg_query.Node_CaseExpr:
+		collectExpr(exprNode.CaseExpr.Arg, selectSt, ctes)
+		collectExpr(exprNode.CaseExpr.Defresult, selectSt, ctes)
+		for _, when := range exprNode.CaseExpr.Args {
+			if whenNode, ok := when.Node.(*pg_query.Node_CaseWhen); ok {
+				collectExpr(whenNode.CaseWhen.Expr, selectSt, ctes)
+				collectExpr(whenNode.CaseWhen.Result, selectSt, ctes)
+			}
+		}
+	case *pg_query.Node_TypeCast:
+		collectExpr(exprNode.TypeCast.Arg, selectSt, ctes)
+	case *pg_query.Node_NullTest:
+		collectExpr(exprNode.NullTest.Arg, selectSt, ctes)
+	case *pg_query.Node_List:
+		for _, item := range exprNode.List.Items {
+			collectExpr(item, selectSt, ctes)
+		}
+	}
+}
+
+func collectSubquery(node *pg_query.Node, selectSt *types.Select, ctes *[]string) {
+	if node == nil {
+		return
+	}
+
+	switch queryNode := node.Node.(type) {
+	case *pg_query.Node_SelectStmt:
+		collectSelect(queryNode.SelectStmt, selectSt, ctes, false)
+	default:
+		slog.Error("unimplemented subquery type", "type", fmt.Sprintf("%T", queryNode))
+	}
 }
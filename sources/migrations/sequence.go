@@ -0,0 +1,47 @@
+package migrations
+
+import (
+	"fmt"
+	"vislab/sources/migrations/types"
+
+	pg_query "github.com/pganalyze/pg_query_go/v5"
+)
+
+func parseCreateSequence(stmt *pg_query.Node_CreateSeqStmt, sequences map[string]*types.Sequence) (*types.Sequence, error) {
+	if stmt.CreateSeqStmt.Sequence == nil {
+		return nil, fmt.Errorf("sequence without name")
+	}
+
+	sequence := &types.Sequence{
+		Name:   stmt.CreateSeqStmt.Sequence.Relname,
+		Schema: stmt.CreateSeqStmt.Sequence.Schemaname,
+	}
+
+	if sequence.Schema == "" {
+		sequence.Schema = "public"
+	}
+
+	return sequence, nil
+}
+
+func parseDropSequence(stmt *pg_query.Node_DropStmt, sequences map[string]*types.Sequence) error {
+	for _, object := range stmt.DropStmt.Objects {
+		list, ok := object.Node.(*pg_query.Node_List)
+		if !ok || len(list.List.Items) == 0 {
+			return fmt.Errorf("unimplemented drop sequence object type: %T", object.Node)
+		}
+
+		nameNode, ok := list.List.Items[len(list.List.Items)-1].Node.(*pg_query.Node_String_)
+		if !ok {
+			return fmt.Errorf("unimplemented drop sequence name type: %T", list.List.Items[len(list.List.Items)-1].Node)
+		}
+
+		if _, ok := sequences[nameNode.String_.Sval]; !ok && !stmt.DropStmt.MissingOk {
+			return fmt.Errorf("sequence %s not found", nameNode.String_.Sval)
+		}
+
+		delete(sequences, nameNode.String_.Sval)
+	}
+
+	return nil
+}
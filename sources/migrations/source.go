@@ -2,13 +2,24 @@ package migrations
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
 	"vislab/config"
 	"vislab/sources/migrations/types"
 )
 
+const autoDialect = "auto"
+
 type Source struct {
-	parser *Parser
-	weight int64
+	parser       *Parser
+	weight       int64
+	dialects     []Dialect
+	dialect      Dialect
+	pathDialects map[string]Dialect
+	version      string
+	diagnostics  *Diagnostics
 }
 
 func NewSource(config *config.MigrationSourceConfig) (*Source, error) {
@@ -18,21 +29,152 @@ func NewSource(config *config.MigrationSourceConfig) (*Source, error) {
 	}
 
 	s := &Source{
-		parser: parser,
-		weight: config.Weight,
+		parser:       parser,
+		weight:       config.Weight,
+		dialects:     defaultDialects(),
+		pathDialects: map[string]Dialect{},
+		version:      config.Version,
+		diagnostics:  NewDiagnostics(),
+	}
+
+	if config.Dialect != "" && config.Dialect != autoDialect {
+		dialect, err := s.getDialect(config.Dialect)
+		if err != nil {
+			return nil, err
+		}
+
+		s.dialect = dialect
+	}
+
+	for migrationPath, name := range config.PathDialects {
+		if name == autoDialect {
+			continue
+		}
+
+		dialect, err := s.getDialect(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set dialect for path %s: %w", migrationPath, err)
+		}
+
+		s.pathDialects[path.Clean(migrationPath)] = dialect
 	}
 
 	return s, nil
 }
 
-func (s *Source) GetData(ctx context.Context, in []byte, out *types.All) error {
-	if err := s.parser.Parse(in, out); err != nil {
+func (s *Source) Version() string {
+	return s.version
+}
+
+func (s *Source) Diagnostics() *Diagnostics {
+	return s.diagnostics
+}
+
+func (s *Source) RegisterDialect(dialect Dialect) {
+	s.dialects = append([]Dialect{dialect}, s.dialects...)
+}
+
+func (s *Source) GetData(ctx context.Context, migrationPath, filePath string, in []byte, out *types.All) error {
+	migration, err := s.Load(migrationPath, filePath, in)
+	if err != nil {
 		return err
 	}
 
-	return nil
+	return s.parseUp(migration, out)
+}
+
+func (s *Source) Load(migrationPath, filePath string, in []byte) (*types.Migration, error) {
+	dialect, err := s.detectDialect(migrationPath, filePath, in)
+	if err != nil {
+		return nil, err
+	}
+
+	migration, err := dialect.Split(filePath, in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to split %s migration: %w", dialect.Name(), err)
+	}
+
+	migration.Path = filePath
+
+	return migration, nil
+}
+
+// Apply applies up migrations in version order, stopping after the given
+// version. An empty version applies everything.
+func (s *Source) Apply(ctx context.Context, migrations []*types.Migration, version string, out *types.All) error {
+	migrations = MergeMigrations(migrations)
+	SortMigrations(migrations)
+
+	errs := []error{}
+
+	for _, migration := range migrations {
+		if version != "" && CompareVersions(migration.Version, version) > 0 {
+			break
+		}
+
+		if err := s.parseUp(migration, out); err != nil {
+			errs = append(errs, fmt.Errorf("failed to apply %s: %w", migration.Path, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// SchemaAt reconstructs the schema as of the given version by applying the up
+// migrations up to it. An empty version applies everything.
+func (s *Source) SchemaAt(ctx context.Context, migrations []*types.Migration, version string) (*types.All, error) {
+	out := types.NewAll()
+
+	if err := s.Apply(ctx, migrations, version, out); err != nil {
+		return out, err
+	}
+
+	return out, nil
 }
 
 func (s *Source) Weight() int64 {
 	return s.weight
 }
+
+func (s *Source) detectDialect(migrationPath, filePath string, in []byte) (Dialect, error) {
+	if dialect, ok := s.pathDialects[path.Clean(migrationPath)]; ok {
+		return dialect, nil
+	}
+
+	if s.dialect != nil {
+		return s.dialect, nil
+	}
+
+	for _, dialect := range s.dialects {
+		if dialect.Detect(filePath, in) {
+			return dialect, nil
+		}
+	}
+
+	return nil, ErrNotMigration
+}
+
+func (s *Source) getDialect(name string) (Dialect, error) {
+	for _, dialect := range s.dialects {
+		if dialect.Name() == name {
+			return dialect, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown migration dialect: %s", name)
+}
+
+func (s *Source) parseUp(migration *types.Migration, out *types.All) error {
+	if strings.TrimSpace(migration.Up) == "" {
+		return nil
+	}
+
+	return s.parse(migration.Path, migration.Up, out)
+}
+
+func (s *Source) parse(file, sql string, out *types.All) error {
+	diagnostics, err := s.parser.Parse(file, []byte(sql), out)
+	s.diagnostics.Add(diagnostics...)
+
+	return err
+}
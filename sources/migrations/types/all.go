@@ -1,11 +1,54 @@
 package types
 
+import "strings"
+
 type (
 	All struct {
-		Tables   map[string]*Table
-		Funcs    map[string][]*Func
-		Indexes  map[string]*Index
-		Triggers map[string]*Trigger
-		Types    map[string]*Type
+		Tables     map[string]*Table
+		Funcs      map[string][]*Func
+		Indexes    map[string]*Index
+		Triggers   map[string]*Trigger
+		Types      map[string]*Type
+		Views      map[string]*View
+		Roles      map[string]*Role
+		Grants     map[string]*Grant
+		Extensions map[string]*Extension
+		Sequences  map[string]*Sequence
 	}
 )
+
+func NewAll() *All {
+	return &All{
+		Tables:     make(map[string]*Table),
+		Funcs:      make(map[string][]*Func),
+		Indexes:    make(map[string]*Index),
+		Triggers:   make(map[string]*Trigger),
+		Types:      make(map[string]*Type),
+		Views:      make(map[string]*View),
+		Roles:      make(map[string]*Role),
+		Grants:     make(map[string]*Grant),
+		Extensions: make(map[string]*Extension),
+		Sequences:  make(map[string]*Sequence),
+	}
+}
+
+// FindTable finds a table by a schema qualified or a bare name. Bare names are
+// looked up in the public schema first and then in any other schema.
+func FindTable(tables map[string]*Table, name string) (*Table, bool) {
+	if schema, table, ok := strings.Cut(name, "."); ok {
+		t, ok := tables[TableKey(schema, table)]
+		return t, ok
+	}
+
+	if t, ok := tables[TableKey("", name)]; ok {
+		return t, true
+	}
+
+	for _, t := range tables {
+		if t.Name == name {
+			return t, true
+		}
+	}
+
+	return nil, false
+}
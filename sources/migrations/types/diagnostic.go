@@ -0,0 +1,21 @@
+package types
+
+type (
+	DiagnosticStatus string
+
+	Diagnostic struct {
+		File      string           `json:"file"`
+		Index     int              `json:"index"`
+		Offset    int32            `json:"offset"`
+		Statement string           `json:"statement"`
+		Status    DiagnosticStatus `json:"status"`
+		Message   string           `json:"message,omitempty"`
+	}
+)
+
+const (
+	DiagnosticOK          DiagnosticStatus = "ok"
+	DiagnosticSkipped     DiagnosticStatus = "skipped"
+	DiagnosticUnsupported DiagnosticStatus = "unsupported"
+	DiagnosticError       DiagnosticStatus = "error"
+)
@@ -0,0 +1,29 @@
+package types
+
+type (
+	SchemaDiff struct {
+		AddedTables    []string              `json:"added_tables,omitempty"`
+		DroppedTables  []string              `json:"dropped_tables,omitempty"`
+		ChangedTables  map[string]*TableDiff `json:"changed_tables,omitempty"`
+		AddedIndexes   []string              `json:"added_indexes,omitempty"`
+		DroppedIndexes []string              `json:"dropped_indexes,omitempty"`
+		AddedTypes     []string              `json:"added_types,omitempty"`
+		DroppedTypes   []string              `json:"dropped_types,omitempty"`
+	}
+
+	TableDiff struct {
+		AddedColumns   []string `json:"added_columns,omitempty"`
+		DroppedColumns []string `json:"dropped_columns,omitempty"`
+		ChangedColumns []string `json:"changed_columns,omitempty"`
+	}
+)
+
+func (d *SchemaDiff) Empty() bool {
+	return len(d.AddedTables) == 0 &&
+		len(d.DroppedTables) == 0 &&
+		len(d.ChangedTables) == 0 &&
+		len(d.AddedIndexes) == 0 &&
+		len(d.DroppedIndexes) == 0 &&
+		len(d.AddedTypes) == 0 &&
+		len(d.DroppedTypes) == 0
+}
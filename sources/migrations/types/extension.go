@@ -0,0 +1,8 @@
+package types
+
+type (
+	Extension struct {
+		Name   string
+		Schema string
+	}
+)
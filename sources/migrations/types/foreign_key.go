@@ -0,0 +1,11 @@
+package types
+
+type (
+	ForeignKey struct {
+		Name       string
+		Columns    []string
+		RefSchema  string
+		RefTable   string
+		RefColumns []string
+	}
+)
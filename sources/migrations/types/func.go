@@ -4,8 +4,9 @@ import "slices"
 
 type (
 	Func struct {
-		Name string
-		Args []string
+		Name   string
+		Schema string
+		Args   []string
 	}
 )
 
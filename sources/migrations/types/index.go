@@ -3,7 +3,9 @@ package types
 type (
 	Index struct {
 		Name    string
+		Schema  string
 		Table   string
 		Columns []string
+		Unique  bool
 	}
 )
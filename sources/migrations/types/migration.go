@@ -0,0 +1,11 @@
+package types
+
+type (
+	Migration struct {
+		Version     string
+		Description string
+		Path        string
+		Up          string
+		Down        string
+	}
+)
@@ -0,0 +1,16 @@
+package types
+
+type (
+	Role struct {
+		Name     string
+		Login    bool
+		MemberOf []string
+	}
+
+	Grant struct {
+		Role       string
+		Schema     string
+		Table      string
+		Privileges []string
+	}
+)
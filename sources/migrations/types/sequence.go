@@ -0,0 +1,8 @@
+package types
+
+type (
+	Sequence struct {
+		Name   string
+		Schema string
+	}
+)
@@ -2,9 +2,25 @@ package types
 
 type (
 	Table struct {
-		Name    string
-		Schema  string
-		Columns []*Column
-		Type    string
+		Name        string
+		Schema      string
+		Columns     []*Column
+		Type        string
+		ForeignKeys []*ForeignKey
+		Owner       string
 	}
 )
+
+// TableKey is the key of a table in All.Tables, tables of different schemas
+// may share a name.
+func TableKey(schema, name string) string {
+	if schema == "" {
+		schema = "public"
+	}
+
+	return schema + "." + name
+}
+
+func (t *Table) Key() string {
+	return TableKey(t.Schema, t.Name)
+}
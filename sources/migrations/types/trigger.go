@@ -3,6 +3,7 @@ package types
 type (
 	Trigger struct {
 		Name    string
+		Schema  string
 		Table   string
 		Columns []string
 		Func    string
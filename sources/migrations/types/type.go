@@ -2,6 +2,8 @@ package types
 
 type (
 	Type struct {
-		Name string
+		Name   string
+		Schema string
+		Values []string
 	}
 )
@@ -0,0 +1,11 @@
+package types
+
+type (
+	View struct {
+		Name         string
+		Schema       string
+		Materialized bool
+		Columns      []string
+		Tables       []string
+	}
+)
@@ -1,14 +1,11 @@
 package migrations
 
 import (
-	"fmt"
 	"vislab/sources/migrations/types"
 
 	pg_query "github.com/pganalyze/pg_query_go/v5"
 )
 
-func parseUpdate(stmt *pg_query.Node_UpdateStmt, tables map[string]*types.Table) error {
-	fmt.Printf("update_table: %s\n", stmt.UpdateStmt.Relation.Relname)
-
-	return nil
+func parseUpdate(stmt *pg_query.Node_UpdateStmt, tables map[string]*types.Table) (types.DiagnosticStatus, error) {
+	return parseDataStmt(stmt.UpdateStmt.Relation, tables)
 }
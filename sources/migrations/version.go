@@ -0,0 +1,92 @@
+package migrations
+
+import (
+	"slices"
+	"strings"
+	"vislab/sources/migrations/types"
+)
+
+// CompareVersions compares migration versions segment by segment, so that
+// goose timestamps, numeric prefixes and dotted flyway versions order
+// numerically. Unversioned migrations (flyway repeatable, plain) sort last.
+func CompareVersions(a, b string) int {
+	if a == b {
+		return 0
+	}
+	if a == "" {
+		return 1
+	}
+	if b == "" {
+		return -1
+	}
+
+	aParts := splitVersion(a)
+	bParts := splitVersion(b)
+
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		if c := compareNumeric(aParts[i], bParts[i]); c != 0 {
+			return c
+		}
+	}
+
+	return len(aParts) - len(bParts)
+}
+
+func SortMigrations(migrations []*types.Migration) {
+	slices.SortStableFunc(migrations, func(a, b *types.Migration) int {
+		if c := CompareVersions(a.Version, b.Version); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.Description, b.Description)
+	})
+}
+
+// MergeMigrations joins migrations split across files (golang-migrate up and
+// down files) into one migration per version and description.
+func MergeMigrations(migrations []*types.Migration) []*types.Migration {
+	merged := []*types.Migration{}
+	byKey := map[string]*types.Migration{}
+
+	for _, migration := range migrations {
+		if migration.Version == "" {
+			merged = append(merged, migration)
+			continue
+		}
+
+		key := migration.Version + "_" + migration.Description
+
+		existing, ok := byKey[key]
+		if !ok {
+			byKey[key] = migration
+			merged = append(merged, migration)
+			continue
+		}
+
+		if existing.Up == "" {
+			existing.Up = migration.Up
+		}
+		if existing.Down == "" {
+			existing.Down = migration.Down
+		}
+	}
+
+	return merged
+}
+
+func splitVersion(version string) []string {
+	return strings.FieldsFunc(version, func(r rune) bool {
+		return r == '.' || r == '_' || r == '-'
+	})
+}
+
+func compareNumeric(a, b string) int {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+
+	if len(a) != len(b) {
+		return len(a) - len(b)
+	}
+
+	return strings.Compare(a, b)
+}
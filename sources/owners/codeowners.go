@@ -0,0 +1,86 @@
+package owners
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+)
+
+type codeownerKind int
+
+const (
+	codeownerUser codeownerKind = iota
+	codeownerGroup
+	codeownerEmail
+)
+
+type codeowner struct {
+	kind  codeownerKind
+	value string
+}
+
+// parseCodeowners returns the unique owners of all rules and section
+// defaults in order of appearance.
+func parseCodeowners(in []byte) []*codeowner {
+	owners := []*codeowner{}
+	seen := map[string]bool{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(in))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if before, _, ok := strings.Cut(line, " #"); ok {
+			line = before
+		}
+
+		var fields []string
+		if strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			// section header with optional approvals count and default owners
+			_, after, ok := strings.Cut(line, "]")
+			if !ok {
+				continue
+			}
+			if strings.HasPrefix(after, "[") {
+				_, after, _ = strings.Cut(after, "]")
+			}
+			fields = strings.Fields(after)
+		} else {
+			fields = strings.Fields(strings.ReplaceAll(line, `\ `, "_"))
+			if len(fields) == 0 {
+				continue
+			}
+			fields = fields[1:]
+		}
+
+		for _, field := range fields {
+			owner := newCodeowner(field)
+			if owner == nil || seen[owner.value] {
+				continue
+			}
+
+			seen[owner.value] = true
+			owners = append(owners, owner)
+		}
+	}
+
+	return owners
+}
+
+func newCodeowner(field string) *codeowner {
+	switch {
+	case strings.HasPrefix(field, "@@"):
+		// role based owners like @@developer do not name anyone
+		return nil
+	case strings.HasPrefix(field, "@") && strings.Contains(field, "/"):
+		return &codeowner{kind: codeownerGroup, value: strings.ToLower(strings.TrimPrefix(field, "@"))}
+	case strings.HasPrefix(field, "@"):
+		return &codeowner{kind: codeownerUser, value: strings.TrimPrefix(field, "@")}
+	case strings.Contains(field, "@"):
+		return &codeowner{kind: codeownerEmail, value: strings.ToLower(field)}
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package owners
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"vislab/sources/owners/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+// mapping is the central team file shared by all projects.
+type mapping struct {
+	teamDevs     map[string][]*types.MappingDev
+	devTeams     map[string]string
+	devs         map[string]*types.MappingDev
+	groupTeams   map[string]string
+	serviceTeams map[string][]string
+}
+
+func newMapping(teams map[string]*types.MappingTeam) *mapping {
+	m := &mapping{
+		teamDevs:     map[string][]*types.MappingDev{},
+		devTeams:     map[string]string{},
+		devs:         map[string]*types.MappingDev{},
+		groupTeams:   map[string]string{},
+		serviceTeams: map[string][]string{},
+	}
+
+	names := make([]string, 0, len(teams))
+	for name := range teams {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		team := teams[name]
+		if team == nil {
+			continue
+		}
+
+		m.teamDevs[name] = team.Devs
+
+		for _, dev := range team.Devs {
+			for _, key := range devKeys(dev.Name, dev.Username, dev.Email) {
+				m.devs[key] = dev
+				if _, ok := m.devTeams[key]; !ok {
+					m.devTeams[key] = name
+				}
+			}
+		}
+
+		for _, group := range team.Groups {
+			m.groupTeams[strings.ToLower(strings.TrimPrefix(group, "@"))] = name
+		}
+
+		for _, service := range team.Services {
+			key := strings.ToLower(service)
+			m.serviceTeams[key] = append(m.serviceTeams[key], name)
+		}
+	}
+
+	return m
+}
+
+func loadMapping(path string) (*mapping, error) {
+	if path == "" {
+		return newMapping(nil), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	teamMapping := &types.Mapping{}
+	if err := yaml.Unmarshal(data, teamMapping); err != nil {
+		return nil, fmt.Errorf("failed to parse team mapping file: %w", err)
+	}
+
+	return newMapping(teamMapping.Teams), nil
+}
+
+func (m *mapping) devTeam(dev *types.Dev) (string, bool) {
+	for _, key := range devKeys(dev.Name, dev.Username, dev.Email) {
+		if team, ok := m.devTeams[key]; ok {
+			return team, true
+		}
+	}
+
+	return "", false
+}
+
+// enrichDev fills the fields missing in the dev from the mapping.
+func (m *mapping) enrichDev(dev *types.Dev) {
+	for _, key := range devKeys(dev.Name, dev.Username, dev.Email) {
+		mappingDev, ok := m.devs[key]
+		if !ok {
+			continue
+		}
+
+		if dev.Name == "" {
+			dev.Name = mappingDev.Name
+		}
+		if dev.Username == "" {
+			dev.Username = mappingDev.Username
+		}
+		if dev.Email == "" {
+			dev.Email = mappingDev.Email
+		}
+		if mappingDev.Role != "" {
+			dev.Role = mappingDev.Role
+		}
+
+		return
+	}
+}
+
+func (m *mapping) groupTeam(group string) (string, bool) {
+	team, ok := m.groupTeams[group]
+	return team, ok
+}
+
+func (m *mapping) serviceTeamNames(names ...string) []string {
+	teams := []string{}
+
+	for _, name := range names {
+		for _, team := range m.serviceTeams[strings.ToLower(name)] {
+			if !slices.Contains(teams, team) {
+				teams = append(teams, team)
+			}
+		}
+	}
+
+	return teams
+}
+
+func (m *mapping) devsOf(team string) []*types.Dev {
+	devs := []*types.Dev{}
+
+	for _, mappingDev := range m.teamDevs[team] {
+		devs = append(devs, newDev(mappingDev, ""))
+	}
+
+	return devs
+}
+
+func newDev(mappingDev *types.MappingDev, defaultRole string) *types.Dev {
+	dev := &types.Dev{
+		Name:     mappingDev.Name,
+		Username: strings.TrimPrefix(mappingDev.Username, "@"),
+		Email:    mappingDev.Email,
+		Role:     mappingDev.Role,
+	}
+	if dev.Role == "" {
+		dev.Role = defaultRole
+	}
+
+	return dev
+}
+
+func devKeys(values ...string) []string {
+	keys := []string{}
+
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(value), "@"))
+		if value != "" {
+			keys = append(keys, value)
+		}
+	}
+
+	return keys
+}
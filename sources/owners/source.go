@@ -0,0 +1,217 @@
+package owners
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"vislab/config"
+	"vislab/sources/owners/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultOwnersPath      = "owners.yaml"
+	defaultTopContributors = 3
+
+	roleCodeowner   = "codeowner"
+	roleContributor = "contributor"
+)
+
+// CodeownersPaths are the locations gitlab looks for CODEOWNERS, the first
+// existing file is used.
+var CodeownersPaths = []string{"CODEOWNERS", "docs/CODEOWNERS", ".gitlab/CODEOWNERS"}
+
+type Source struct {
+	mapping         *mapping
+	ownersPath      string
+	topContributors int
+	weight          int64
+}
+
+func NewSource(config *config.OwnersSourceConfig) (*Source, error) {
+	mapping, err := loadMapping(config.MappingPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load team mapping: %w", err)
+	}
+
+	s := &Source{
+		mapping:         mapping,
+		ownersPath:      config.OwnersPath,
+		topContributors: config.TopContributors,
+		weight:          config.Weight,
+	}
+
+	if s.ownersPath == "" {
+		s.ownersPath = defaultOwnersPath
+	}
+	if s.topContributors <= 0 {
+		s.topContributors = defaultTopContributors
+	}
+
+	return s, nil
+}
+
+func (s *Source) Weight() int64 {
+	return s.weight
+}
+
+func (s *Source) OwnersPath() string {
+	return s.ownersPath
+}
+
+func (s *Source) TopContributors() int {
+	return s.topContributors
+}
+
+// GetData builds the owning teams from owners.yaml and CODEOWNERS, then from
+// the services listed in the central mapping and, when nothing names an
+// owner, from the top contributors.
+func (s *Source) GetData(ctx context.Context, files *types.Files, out *types.All) error {
+	b := newTeamBuilder(s.mapping)
+
+	if files.Owners != nil {
+		if err := b.addOwnersFile(files.Owners); err != nil {
+			slog.Warn("failed to parse owners file", "service", files.ServiceFullName, "err", err)
+		}
+	}
+
+	if files.Codeowners != nil {
+		b.addCodeowners(files.Codeowners)
+	}
+
+	if b.empty() {
+		for _, team := range s.mapping.serviceTeamNames(files.ServiceName, files.ServiceFullName) {
+			b.addTeam(team, s.mapping.devsOf(team))
+		}
+	}
+
+	if b.empty() {
+		for i, contributor := range files.Contributors {
+			if i == s.topContributors {
+				break
+			}
+
+			contributor.Role = roleContributor
+			b.addDev(contributor)
+		}
+	}
+
+	out.Teams = append(out.Teams, b.teams...)
+
+	return nil
+}
+
+type teamBuilder struct {
+	mapping *mapping
+	teams   []*types.Team
+}
+
+func newTeamBuilder(mapping *mapping) *teamBuilder {
+	return &teamBuilder{
+		mapping: mapping,
+	}
+}
+
+func (b *teamBuilder) empty() bool {
+	return len(b.teams) == 0
+}
+
+func (b *teamBuilder) team(name string) *types.Team {
+	for _, team := range b.teams {
+		if team.Name == name {
+			return team
+		}
+	}
+
+	team := &types.Team{
+		Name: name,
+	}
+	b.teams = append(b.teams, team)
+
+	return team
+}
+
+func (b *teamBuilder) addTeam(name string, devs []*types.Dev) {
+	team := b.team(name)
+
+	for _, dev := range devs {
+		b.mapping.enrichDev(dev)
+		addTeamDev(team, dev)
+	}
+}
+
+// addDev puts a dev without explicit team into the team of the mapping.
+func (b *teamBuilder) addDev(dev *types.Dev) {
+	b.mapping.enrichDev(dev)
+
+	name, _ := b.mapping.devTeam(dev)
+	addTeamDev(b.team(name), dev)
+}
+
+func (b *teamBuilder) addOwnersFile(in []byte) error {
+	ownersFile := &types.OwnersFile{}
+	if err := yaml.Unmarshal(in, ownersFile); err != nil {
+		return err
+	}
+
+	if ownersFile.Team != "" || len(ownersFile.Devs) != 0 {
+		ownersFile.Teams = append([]*types.OwnersTeam{{Name: ownersFile.Team, Devs: ownersFile.Devs}}, ownersFile.Teams...)
+	}
+
+	for _, ownersTeam := range ownersFile.Teams {
+		devs := []*types.Dev{}
+		for _, ownersDev := range ownersTeam.Devs {
+			devs = append(devs, newDev(ownersDev, ""))
+		}
+
+		if ownersTeam.Name == "" {
+			for _, dev := range devs {
+				b.addDev(dev)
+			}
+			continue
+		}
+
+		if len(devs) == 0 {
+			devs = b.mapping.devsOf(ownersTeam.Name)
+		}
+
+		b.addTeam(ownersTeam.Name, devs)
+	}
+
+	return nil
+}
+
+func (b *teamBuilder) addCodeowners(in []byte) {
+	for _, owner := range parseCodeowners(in) {
+		switch owner.kind {
+		case codeownerGroup:
+			name, ok := b.mapping.groupTeam(owner.value)
+			if !ok {
+				b.addTeam(owner.value, nil)
+				continue
+			}
+
+			b.addTeam(name, b.mapping.devsOf(name))
+		case codeownerUser:
+			b.addDev(&types.Dev{Username: owner.value, Role: roleCodeowner})
+		case codeownerEmail:
+			b.addDev(&types.Dev{Email: owner.value, Role: roleCodeowner})
+		}
+	}
+}
+
+func addTeamDev(team *types.Team, dev *types.Dev) {
+	key := devKeys(dev.Username, dev.Email, dev.Name)
+	if len(key) == 0 {
+		return
+	}
+
+	for _, existing := range team.Devs {
+		if existingKey := devKeys(existing.Username, existing.Email, existing.Name); len(existingKey) != 0 && existingKey[0] == key[0] {
+			return
+		}
+	}
+
+	team.Devs = append(team.Devs, dev)
+}
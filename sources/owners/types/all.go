@@ -0,0 +1,30 @@
+package types
+
+type (
+	All struct {
+		Teams []*Team
+	}
+
+	// Team with an empty name is resolved to the service group by the
+	// aggregator.
+	Team struct {
+		Name string
+		Devs []*Dev
+	}
+
+	Dev struct {
+		Name     string
+		Username string
+		Email    string
+		Role     string
+	}
+
+	// Files are the ownership inputs of one project, missing files are nil.
+	Files struct {
+		ServiceName     string
+		ServiceFullName string
+		Codeowners      []byte
+		Owners          []byte
+		Contributors    []*Dev
+	}
+)
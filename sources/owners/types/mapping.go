@@ -0,0 +1,33 @@
+package types
+
+type (
+	Mapping struct {
+		Teams map[string]*MappingTeam `yaml:"teams"`
+	}
+
+	MappingTeam struct {
+		Devs     []*MappingDev `yaml:"devs"`
+		Groups   []string      `yaml:"groups"`
+		Services []string      `yaml:"services"`
+	}
+
+	MappingDev struct {
+		Name     string `yaml:"name"`
+		Username string `yaml:"username"`
+		Email    string `yaml:"email"`
+		Role     string `yaml:"role"`
+	}
+
+	// OwnersFile is owners.yaml in the project repository, it holds either
+	// one team or a list of them.
+	OwnersFile struct {
+		Team  string        `yaml:"team"`
+		Devs  []*MappingDev `yaml:"devs"`
+		Teams []*OwnersTeam `yaml:"teams"`
+	}
+
+	OwnersTeam struct {
+		Name string        `yaml:"name"`
+		Devs []*MappingDev `yaml:"devs"`
+	}
+)
@@ -0,0 +1,435 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+	"vislab/sources/pipeline/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileLoader returns the content of a CI file. Project and ref are empty for
+// files of the collected project itself.
+type FileLoader func(ctx context.Context, project, ref, filePath string) ([]byte, error)
+
+const (
+	defaultMaxIncludes = 150
+	maxExtendsDepth    = 11
+	defaultStage       = "test"
+)
+
+var (
+	defaultStages = []string{".pre", "build", "test", "deploy", ".post"}
+
+	reservedKeys = []string{
+		"default", "include", "stages", "variables", "workflow", "image",
+		"services", "cache", "before_script", "after_script", "types",
+	}
+
+	deployPattern  = regexp.MustCompile(`\b(helm\s+(upgrade|install)|kubectl\s+(apply|rollout|set\s+image)|werf\s+converge|argocd\s+app\s+sync)\b`)
+	migratePattern = regexp.MustCompile(`(?i)\b(migrate|goose|flyway|liquibase|alembic\s+upgrade)\b`)
+	publishPattern = regexp.MustCompile(`docker\s+push|docker\s+buildx\s+build[^\n]*--push|kaniko/executor|buildah\s+push|podman\s+push|crane\s+push|werf\s+build`)
+)
+
+type (
+	Parser struct {
+		maxIncludes int
+	}
+
+	ciFile struct {
+		project  string
+		ref      string
+		path     string
+		template string
+	}
+
+	include struct {
+		local     string
+		project   string
+		ref       string
+		files     []string
+		remote    string
+		template  string
+		component string
+	}
+
+	definition struct {
+		body     map[string]any
+		path     string
+		template string
+	}
+
+	parseState struct {
+		load        FileLoader
+		included    map[string]bool
+		definitions map[string]*definition
+		order       []string
+		stages      []string
+		image       string
+		templates   []*types.Template
+	}
+)
+
+func NewParser(maxIncludes int) (*Parser, error) {
+	if maxIncludes <= 0 {
+		maxIncludes = defaultMaxIncludes
+	}
+
+	p := &Parser{
+		maxIncludes: maxIncludes,
+	}
+
+	return p, nil
+}
+
+// Parse reads the CI file with all its includes and returns the jobs with
+// extends already applied. Hidden jobs are only used as extends parents.
+func (p *Parser) Parse(ctx context.Context, filePath string, load FileLoader, out *types.All) error {
+	state := &parseState{
+		load:        load,
+		included:    map[string]bool{},
+		definitions: map[string]*definition{},
+	}
+
+	if err := p.parseFile(ctx, state, &ciFile{path: filePath}); err != nil {
+		return err
+	}
+
+	out.Path = filePath
+	out.Stages = state.stages
+	if len(out.Stages) == 0 {
+		out.Stages = slices.Clone(defaultStages)
+	}
+	out.Templates = state.templates
+
+	for _, name := range state.order {
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+
+		body, template, err := state.resolve(name, 0)
+		if err != nil {
+			slog.Warn("failed to resolve pipeline job", "path", filePath, "job", name, "err", err)
+			continue
+		}
+
+		out.Jobs = append(out.Jobs, state.newJob(name, body, template))
+	}
+
+	return nil
+}
+
+func (p *Parser) parseFile(ctx context.Context, state *parseState, file *ciFile) error {
+	key := file.project + ":" + file.ref + ":" + file.path
+	if state.included[key] {
+		return nil
+	}
+	if len(state.included) >= p.maxIncludes {
+		return fmt.Errorf("too many included files, limit is %d", p.maxIncludes)
+	}
+	state.included[key] = true
+
+	data, err := state.load(ctx, file.project, file.ref, file.path)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", file.path, err)
+	}
+
+	root := yaml.Node{}
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", file.path, err)
+	}
+
+	if len(root.Content) == 0 {
+		return nil
+	}
+
+	doc := root.Content[0]
+	if doc.Kind != yaml.MappingNode {
+		return fmt.Errorf("failed to decode %s: top level is not a mapping", file.path)
+	}
+
+	// included files go first, keys of the including file override them
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		if doc.Content[i].Value != "include" {
+			continue
+		}
+
+		var value any
+		if err := doc.Content[i+1].Decode(&value); err != nil {
+			return fmt.Errorf("failed to decode includes of %s: %w", file.path, err)
+		}
+
+		for _, include := range parseIncludes(value) {
+			if err := p.parseInclude(ctx, state, file, include); err != nil {
+				slog.Warn("failed to include pipeline file", "path", file.path, "err", err)
+			}
+		}
+	}
+
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		name := doc.Content[i].Value
+
+		var value any
+		if err := doc.Content[i+1].Decode(&value); err != nil {
+			slog.Warn("failed to decode pipeline key", "path", file.path, "key", name, "err", err)
+			continue
+		}
+
+		switch name {
+		case "stages":
+			state.stages = stringList(value)
+		case "image":
+			state.image = imageName(value)
+		case "default":
+			if defaults, ok := value.(map[string]any); ok && defaults["image"] != nil {
+				state.image = imageName(defaults["image"])
+			}
+		}
+
+		if slices.Contains(reservedKeys, name) {
+			continue
+		}
+
+		body, ok := value.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		state.define(name, body, file)
+	}
+
+	return nil
+}
+
+func (p *Parser) parseInclude(ctx context.Context, state *parseState, parent *ciFile, include *include) error {
+	switch {
+	case include.local != "":
+		if strings.Contains(include.local, "*") {
+			return fmt.Errorf("wildcard includes are not supported: %s", include.local)
+		}
+
+		return p.parseFile(ctx, state, &ciFile{
+			project:  parent.project,
+			ref:      parent.ref,
+			path:     strings.TrimPrefix(include.local, "/"),
+			template: parent.template,
+		})
+	case include.project != "":
+		for _, file := range include.files {
+			template := &types.Template{
+				Name:    templateName(include.project, include.ref, file),
+				Project: include.project,
+				Ref:     include.ref,
+				File:    strings.TrimPrefix(file, "/"),
+			}
+			state.addTemplate(template)
+
+			err := p.parseFile(ctx, state, &ciFile{
+				project:  template.Project,
+				ref:      template.Ref,
+				path:     template.File,
+				template: template.Name,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	case include.remote != "":
+		state.addTemplate(&types.Template{Name: include.remote})
+	case include.template != "":
+		state.addTemplate(&types.Template{Name: "gitlab:" + include.template})
+	case include.component != "":
+		state.addTemplate(&types.Template{Name: include.component})
+	}
+
+	return nil
+}
+
+func (s *parseState) define(name string, body map[string]any, file *ciFile) {
+	def := &definition{
+		body:     body,
+		template: file.template,
+	}
+	if file.project == "" {
+		def.path = file.path
+	}
+
+	// a job defined again in the including file is merged into the included one
+	if existing, ok := s.definitions[name]; ok {
+		def.body = mergeMaps(existing.body, body)
+		if def.template == "" {
+			def.template = existing.template
+		}
+	} else {
+		s.order = append(s.order, name)
+	}
+
+	s.definitions[name] = def
+}
+
+func (s *parseState) addTemplate(template *types.Template) {
+	if slices.ContainsFunc(s.templates, func(t *types.Template) bool {
+		return t.Name == template.Name
+	}) {
+		return
+	}
+
+	s.templates = append(s.templates, template)
+}
+
+// resolve returns the job body with its extends chain merged in and the
+// template the job or one of its parents comes from.
+func (s *parseState) resolve(name string, depth int) (map[string]any, string, error) {
+	if depth > maxExtendsDepth {
+		return nil, "", fmt.Errorf("extends nesting is deeper than %d levels", maxExtendsDepth)
+	}
+
+	def, ok := s.definitions[name]
+	if !ok {
+		return nil, "", fmt.Errorf("unknown job to extend: %s", name)
+	}
+
+	body := map[string]any{}
+	template := def.template
+
+	for _, parent := range stringList(def.body["extends"]) {
+		parentBody, parentTemplate, err := s.resolve(parent, depth+1)
+		if err != nil {
+			return nil, "", err
+		}
+
+		body = mergeMaps(body, parentBody)
+		if template == "" {
+			template = parentTemplate
+		}
+	}
+
+	body = mergeMaps(body, def.body)
+	delete(body, "extends")
+
+	return body, template, nil
+}
+
+func (s *parseState) newJob(name string, body map[string]any, template string) *types.Job {
+	job := &types.Job{
+		Name:     name,
+		Stage:    defaultStage,
+		Path:     s.definitions[name].path,
+		Image:    s.image,
+		Template: template,
+	}
+
+	if stage, ok := body["stage"].(string); ok {
+		job.Stage = stage
+	}
+	if body["image"] != nil {
+		job.Image = imageName(body["image"])
+	}
+
+	action := ""
+	switch environment := body["environment"].(type) {
+	case string:
+		job.Environment = environment
+	case map[string]any:
+		job.Environment, _ = environment["name"].(string)
+		action, _ = environment["action"].(string)
+	}
+
+	script := strings.Join(append(stringList(body["before_script"]), stringList(body["script"])...), "\n")
+
+	job.Deploys = (job.Environment != "" && (action == "" || action == "start")) || deployPattern.MatchString(script)
+	job.Migrates = strings.Contains(strings.ToLower(name), "migrat") || migratePattern.MatchString(script)
+	job.PublishesImage = publishPattern.MatchString(script) || strings.Contains(job.Image, "kaniko")
+
+	return job
+}
+
+func parseIncludes(value any) []*include {
+	includes := []*include{}
+
+	switch v := value.(type) {
+	case string:
+		if strings.HasPrefix(v, "http://") || strings.HasPrefix(v, "https://") {
+			includes = append(includes, &include{remote: v})
+		} else {
+			includes = append(includes, &include{local: v})
+		}
+	case []any:
+		for _, item := range v {
+			includes = append(includes, parseIncludes(item)...)
+		}
+	case map[string]any:
+		include := &include{}
+		include.local, _ = v["local"].(string)
+		include.project, _ = v["project"].(string)
+		include.ref, _ = v["ref"].(string)
+		include.files = stringList(v["file"])
+		include.remote, _ = v["remote"].(string)
+		include.template, _ = v["template"].(string)
+		include.component, _ = v["component"].(string)
+
+		includes = append(includes, include)
+	}
+
+	return includes
+}
+
+func templateName(project, ref, file string) string {
+	name := project + "/" + strings.TrimPrefix(file, "/")
+	if ref != "" {
+		name += "@" + ref
+	}
+
+	return name
+}
+
+func mergeMaps(dst, src map[string]any) map[string]any {
+	merged := make(map[string]any, len(dst)+len(src))
+	for key, value := range dst {
+		merged[key] = value
+	}
+
+	for key, value := range src {
+		srcMap, srcOk := value.(map[string]any)
+		dstMap, dstOk := merged[key].(map[string]any)
+		if srcOk && dstOk {
+			merged[key] = mergeMaps(dstMap, srcMap)
+			continue
+		}
+
+		merged[key] = value
+	}
+
+	return merged
+}
+
+func stringList(value any) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		list := []string{}
+		for _, item := range v {
+			list = append(list, stringList(item)...)
+		}
+		return list
+	}
+
+	return nil
+}
+
+func imageName(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]any:
+		name, _ := v["name"].(string)
+		return name
+	}
+
+	return ""
+}
@@ -0,0 +1,34 @@
+package pipeline
+
+import (
+	"context"
+	"vislab/config"
+	"vislab/sources/pipeline/types"
+)
+
+type Source struct {
+	parser *Parser
+	weight int64
+}
+
+func NewSource(config *config.PipelineSourceConfig) (*Source, error) {
+	parser, err := NewParser(config.MaxIncludes)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Source{
+		parser: parser,
+		weight: config.Weight,
+	}
+
+	return s, nil
+}
+
+func (s *Source) Weight() int64 {
+	return s.weight
+}
+
+func (s *Source) GetData(ctx context.Context, filePath string, load FileLoader, out *types.All) error {
+	return s.parser.Parse(ctx, filePath, load, out)
+}
@@ -0,0 +1,31 @@
+package types
+
+type (
+	All struct {
+		Path      string
+		Stages    []string
+		Jobs      []*Job
+		Templates []*Template
+	}
+
+	Job struct {
+		Name           string
+		Stage          string
+		Path           string
+		Image          string
+		Environment    string
+		Template       string
+		Deploys        bool
+		Migrates       bool
+		PublishesImage bool
+	}
+
+	// Template is a CI file which is not part of the project itself, it can
+	// be included by many projects.
+	Template struct {
+		Name    string
+		Project string
+		Ref     string
+		File    string
+	}
+)
@@ -0,0 +1,174 @@
+package scm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"vislab/config"
+	"vislab/sources/gitlab"
+	gitlabTypes "vislab/sources/gitlab/types"
+)
+
+const giteaAPIPrefix = "/api/v1/"
+
+type (
+	Gitea struct {
+		*restProvider
+	}
+
+	giteaRelease struct {
+		TagName string `json:"tag_name"`
+	}
+
+	giteaTag struct {
+		Name   string `json:"name"`
+		Commit struct {
+			SHA     string     `json:"sha"`
+			Created *time.Time `json:"created"`
+		} `json:"commit"`
+	}
+)
+
+// NewGitea takes the url of the instance, the api prefix is added unless the
+// url already points to the api.
+func NewGitea(conf *config.GitLabClientConfig) (*Gitea, error) {
+	baseURL := strings.TrimSuffix(conf.BaseURL, "/")
+
+	options := append(gitlab.GetOptions(conf), gitlab.WithAuthHeader("Authorization", "token "))
+	if conf.GitlabAPIPrefix == "" && !strings.Contains(baseURL, "/api/") {
+		options = append(options, gitlab.WithAPIPrefix(giteaAPIPrefix))
+	}
+
+	client, err := gitlab.NewClient(conf.Token, baseURL, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Gitea{
+		restProvider: newRestProvider(client, true, conf.UseArchived),
+	}, nil
+}
+
+func (g *Gitea) Kind() string {
+	return KindGitea
+}
+
+func (g *Gitea) ListGroups(ctx context.Context) ([]*gitlabTypes.Group, error) {
+	return g.listGroups(ctx)
+}
+
+func (g *Gitea) ListProjects(ctx context.Context, group *gitlabTypes.Group, subgroups bool) ([]*gitlabTypes.Project, error) {
+	return g.listProjects(ctx, group)
+}
+
+func (g *Gitea) GetProject(ctx context.Context, projectId int64) (*gitlabTypes.Project, error) {
+	return g.getProject(ctx, projectId)
+}
+
+func (g *Gitea) GetProjectByPath(ctx context.Context, path string) (*gitlabTypes.Project, error) {
+	return g.getProjectByPath(ctx, path)
+}
+
+func (g *Gitea) GetFile(ctx context.Context, projectId int64, ref, filePath string) ([]byte, error) {
+	repoPath, err := g.repoPath(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("repos/%s/raw/%s", repoPath, strings.TrimPrefix(filePath, "/"))
+
+	return g.getRaw(ctx, path, &pageOptions{Ref: ref}, "")
+}
+
+func (g *Gitea) ListDir(ctx context.Context, projectId int64, ref, dir string) ([]string, error) {
+	return g.listDir(ctx, projectId, ref, dir)
+}
+
+func (g *Gitea) Archive(ctx context.Context, projectId int64, ref, dir string) ([]byte, error) {
+	repoPath, err := g.repoPath(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.getRaw(ctx, fmt.Sprintf("repos/%s/archive/%s.tar.gz", repoPath, url.PathEscape(ref)), nil, "")
+}
+
+// GetLatestTag prefers the latest release, otherwise it takes the tag of the
+// newest commit, tags are not ordered by date.
+func (g *Gitea) GetLatestTag(ctx context.Context, projectId int64) (*gitlabTypes.Tag, error) {
+	repoPath, err := g.repoPath(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	release := &giteaRelease{}
+	_, err = g.get(ctx, fmt.Sprintf("repos/%s/releases/latest", repoPath), nil, release)
+	if err == nil {
+		return &gitlabTypes.Tag{Name: &release.TagName}, nil
+	}
+	if !errors.Is(err, gitlab.ErrNotFound) {
+		return nil, err
+	}
+
+	tags, err := listAll[*giteaTag](ctx, g.restProvider, fmt.Sprintf("repos/%s/tags", repoPath))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tags) == 0 {
+		return nil, fmt.Errorf("tags not found")
+	}
+
+	// older gitea versions do not send the commit date, the first tag is taken then
+	latest := tags[0]
+	for _, tag := range tags {
+		if tag.Commit.Created == nil {
+			continue
+		}
+		if latest.Commit.Created == nil || tag.Commit.Created.After(*latest.Commit.Created) {
+			latest = tag
+		}
+	}
+
+	return &gitlabTypes.Tag{Name: &latest.Name, Target: &latest.Commit.SHA}, nil
+}
+
+func (g *Gitea) GetLanguages(ctx context.Context, projectId int64) (map[string]float64, error) {
+	return g.getLanguages(ctx, projectId)
+}
+
+func (g *Gitea) ListContributors(ctx context.Context, projectId int64) ([]*gitlabTypes.Contributor, error) {
+	return nil, fmt.Errorf("failed to list contributors: %w", ErrUnsupported)
+}
+
+func (g *Gitea) Compare(ctx context.Context, projectId int64, from, to string) (*gitlabTypes.CompareResult, error) {
+	return g.compare(ctx, projectId, from, to)
+}
+
+func (g *Gitea) EnsureHook(ctx context.Context, projectId int64, hook *Hook) error {
+	return g.ensureHook(ctx, projectId, hook, &restHook{Type: "gitea"})
+}
+
+func (g *Gitea) Stats() gitlab.Stats {
+	return g.client.Stats()
+}
+
+func (g *Gitea) ResolveRef(ctx context.Context, projectId int64, ref string) (string, error) {
+	return g.resolveRef(ctx, projectId, ref)
+}
+
+func (g *Gitea) ResetRefs() {
+	g.client.ResetRefs()
+	g.resetRefs()
+}
+
+func (g *Gitea) FileURL(projectURL, ref, filePath string) string {
+	return fmt.Sprintf("%s/src/%s/%s", projectURL, ref, filePath)
+}
+
+func (g *Gitea) ArchivesByPath() bool {
+	return false
+}
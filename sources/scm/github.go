@@ -0,0 +1,184 @@
+package scm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"vislab/config"
+	"vislab/sources/gitlab"
+	gitlabTypes "vislab/sources/gitlab/types"
+)
+
+type (
+	GitHub struct {
+		*restProvider
+	}
+
+	githubRelease struct {
+		TagName string `json:"tag_name"`
+	}
+
+	githubTag struct {
+		Name   string `json:"name"`
+		Commit struct {
+			SHA string `json:"sha"`
+		} `json:"commit"`
+	}
+
+	githubContributor struct {
+		Login         string `json:"login"`
+		Contributions int64  `json:"contributions"`
+	}
+)
+
+// NewGitHub takes https://api.github.com or the https://host/api/v3 url of
+// github enterprise as the base url.
+func NewGitHub(conf *config.GitLabClientConfig) (*GitHub, error) {
+	baseURL := conf.BaseURL
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+
+	options := append(gitlab.GetOptions(conf),
+		gitlab.WithAuthHeader("Authorization", "Bearer "),
+		gitlab.WithoutAPIPrefix(),
+	)
+
+	client, err := gitlab.NewClient(conf.Token, baseURL, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GitHub{
+		restProvider: newRestProvider(client, false, conf.UseArchived),
+	}, nil
+}
+
+func (g *GitHub) Kind() string {
+	return KindGitHub
+}
+
+func (g *GitHub) ListGroups(ctx context.Context) ([]*gitlabTypes.Group, error) {
+	return g.listGroups(ctx)
+}
+
+func (g *GitHub) ListProjects(ctx context.Context, group *gitlabTypes.Group, subgroups bool) ([]*gitlabTypes.Project, error) {
+	return g.listProjects(ctx, group)
+}
+
+func (g *GitHub) GetProject(ctx context.Context, projectId int64) (*gitlabTypes.Project, error) {
+	return g.getProject(ctx, projectId)
+}
+
+func (g *GitHub) GetProjectByPath(ctx context.Context, path string) (*gitlabTypes.Project, error) {
+	return g.getProjectByPath(ctx, path)
+}
+
+func (g *GitHub) GetFile(ctx context.Context, projectId int64, ref, filePath string) ([]byte, error) {
+	repoPath, err := g.repoPath(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("repos/%s/contents/%s", repoPath, strings.TrimPrefix(filePath, "/"))
+
+	return g.getRaw(ctx, path, &pageOptions{Ref: ref}, "application/vnd.github.raw")
+}
+
+func (g *GitHub) ListDir(ctx context.Context, projectId int64, ref, dir string) ([]string, error) {
+	return g.listDir(ctx, projectId, ref, dir)
+}
+
+func (g *GitHub) Archive(ctx context.Context, projectId int64, ref, dir string) ([]byte, error) {
+	repoPath, err := g.repoPath(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.getRaw(ctx, fmt.Sprintf("repos/%s/tarball/%s", repoPath, url.PathEscape(ref)), nil, "")
+}
+
+// GetLatestTag prefers the latest release, tags are not ordered by date.
+func (g *GitHub) GetLatestTag(ctx context.Context, projectId int64) (*gitlabTypes.Tag, error) {
+	repoPath, err := g.repoPath(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	release := &githubRelease{}
+	_, err = g.get(ctx, fmt.Sprintf("repos/%s/releases/latest", repoPath), nil, release)
+	if err == nil {
+		return &gitlabTypes.Tag{Name: &release.TagName}, nil
+	}
+	if !errors.Is(err, gitlab.ErrNotFound) {
+		return nil, err
+	}
+
+	tags := []*githubTag{}
+	if _, err := g.get(ctx, fmt.Sprintf("repos/%s/tags", repoPath), &pageOptions{PerPage: 1}, &tags); err != nil {
+		return nil, err
+	}
+
+	if len(tags) == 0 {
+		return nil, fmt.Errorf("tags not found")
+	}
+
+	return &gitlabTypes.Tag{Name: &tags[0].Name, Target: &tags[0].Commit.SHA}, nil
+}
+
+func (g *GitHub) GetLanguages(ctx context.Context, projectId int64) (map[string]float64, error) {
+	return g.getLanguages(ctx, projectId)
+}
+
+func (g *GitHub) ListContributors(ctx context.Context, projectId int64) ([]*gitlabTypes.Contributor, error) {
+	repoPath, err := g.repoPath(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	githubContributors, err := listAll[*githubContributor](ctx, g.restProvider, fmt.Sprintf("repos/%s/contributors", repoPath))
+	if err != nil {
+		return nil, err
+	}
+
+	contributors := []*gitlabTypes.Contributor{}
+	for _, contributor := range githubContributors {
+		contributors = append(contributors, &gitlabTypes.Contributor{
+			Name:       contributor.Login,
+			CommitsNum: contributor.Contributions,
+		})
+	}
+
+	return contributors, nil
+}
+
+func (g *GitHub) Compare(ctx context.Context, projectId int64, from, to string) (*gitlabTypes.CompareResult, error) {
+	return g.compare(ctx, projectId, from, to)
+}
+
+func (g *GitHub) EnsureHook(ctx context.Context, projectId int64, hook *Hook) error {
+	return g.ensureHook(ctx, projectId, hook, &restHook{Name: "web"})
+}
+
+func (g *GitHub) Stats() gitlab.Stats {
+	return g.client.Stats()
+}
+
+func (g *GitHub) ResolveRef(ctx context.Context, projectId int64, ref string) (string, error) {
+	return g.resolveRef(ctx, projectId, ref)
+}
+
+func (g *GitHub) ResetRefs() {
+	g.client.ResetRefs()
+	g.resetRefs()
+}
+
+func (g *GitHub) FileURL(projectURL, ref, filePath string) string {
+	return fmt.Sprintf("%s/blob/%s/%s", projectURL, ref, filePath)
+}
+
+func (g *GitHub) ArchivesByPath() bool {
+	return false
+}
@@ -0,0 +1,171 @@
+package scm
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"vislab/config"
+	"vislab/libs/ptr"
+	"vislab/sources/gitlab"
+	gitlabTypes "vislab/sources/gitlab/types"
+)
+
+type GitLab struct {
+	client      *gitlab.Client
+	useArchived bool
+}
+
+func NewGitLab(conf *config.GitLabClientConfig) (*GitLab, error) {
+	client, err := gitlab.NewClient(conf.Token, conf.BaseURL, gitlab.GetOptions(conf)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewGitLabFromClient(client, conf.UseArchived), nil
+}
+
+func NewGitLabFromClient(client *gitlab.Client, useArchived bool) *GitLab {
+	return &GitLab{
+		client:      client,
+		useArchived: useArchived,
+	}
+}
+
+func (g *GitLab) Kind() string {
+	return KindGitLab
+}
+
+func (g *GitLab) ListGroups(ctx context.Context) ([]*gitlabTypes.Group, error) {
+	groups, _, err := g.client.Groups.ListAll(ctx, &gitlabTypes.ListGroupsOptions{})
+	return groups, err
+}
+
+func (g *GitLab) ListProjects(ctx context.Context, group *gitlabTypes.Group, subgroups bool) ([]*gitlabTypes.Project, error) {
+	// shared projects belong to other groups, they are collected there
+	options := &gitlabTypes.ListProjectsOptions{
+		WithShared: ptr.Ptr(false),
+	}
+	if subgroups {
+		options.IncludeSubGroups = ptr.Ptr(true)
+	}
+	if !g.useArchived {
+		options.Archived = ptr.Ptr(false)
+	}
+
+	projects, _, err := g.client.Groups.ListAllProjects(ctx, group.ID, options)
+	return projects, err
+}
+
+func (g *GitLab) GetProject(ctx context.Context, projectId int64) (*gitlabTypes.Project, error) {
+	project, _, err := g.client.Projects.Get(ctx, projectId)
+	return project, err
+}
+
+func (g *GitLab) GetProjectByPath(ctx context.Context, path string) (*gitlabTypes.Project, error) {
+	project, _, err := g.client.Projects.GetByNameWithGroup(ctx, path)
+	return project, err
+}
+
+func (g *GitLab) GetFile(ctx context.Context, projectId int64, ref, filePath string) ([]byte, error) {
+	file64, _, err := g.client.Files.Get(ctx, filePath, projectId, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return base64.StdEncoding.DecodeString(file64.Content)
+}
+
+func (g *GitLab) ListDir(ctx context.Context, projectId int64, ref, dir string) ([]string, error) {
+	files, _, err := g.client.Files.ListDir(ctx, dir, projectId, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []string{}
+	for _, file := range files {
+		if file.Type != "blob" {
+			continue
+		}
+
+		paths = append(paths, file.Path)
+	}
+
+	return paths, nil
+}
+
+func (g *GitLab) Archive(ctx context.Context, projectId int64, ref, dir string) ([]byte, error) {
+	data, _, err := g.client.Files.Archive(ctx, projectId, ref, dir)
+	return data, err
+}
+
+func (g *GitLab) GetLatestTag(ctx context.Context, projectId int64) (*gitlabTypes.Tag, error) {
+	tag, _, err := g.client.Tags.GetLatest(ctx, projectId)
+	return tag, err
+}
+
+func (g *GitLab) GetLanguages(ctx context.Context, projectId int64) (map[string]float64, error) {
+	languages, _, err := g.client.Projects.GetLanguages(ctx, projectId)
+	return languages, err
+}
+
+func (g *GitLab) ListContributors(ctx context.Context, projectId int64) ([]*gitlabTypes.Contributor, error) {
+	options := &gitlabTypes.ListContributorsOptions{
+		OrderBy: ptr.Ptr("commits"),
+		Sort:    ptr.Ptr("desc"),
+	}
+
+	contributors, _, err := g.client.Contributors.ListAll(ctx, options, projectId)
+	return contributors, err
+}
+
+func (g *GitLab) Compare(ctx context.Context, projectId int64, from, to string) (*gitlabTypes.CompareResult, error) {
+	result, _, err := g.client.Tags.Compare(ctx, from, to, projectId)
+	return result, err
+}
+
+func (g *GitLab) EnsureHook(ctx context.Context, projectId int64, hook *Hook) error {
+	hooks, _, err := g.client.Hooks.List(ctx, projectId)
+	if err != nil {
+		return err
+	}
+
+	if slices.ContainsFunc(hooks, func(h *gitlabTypes.Hook) bool {
+		return h.URL == hook.URL
+	}) {
+		return nil
+	}
+
+	options := &gitlabTypes.AddHookOptions{
+		URL:                   ptr.Ptr(hook.URL),
+		PushEvents:            ptr.Ptr(true),
+		TagPushEvents:         ptr.Ptr(true),
+		EnableSSLVerification: ptr.Ptr(true),
+	}
+	if hook.Secret != "" {
+		options.Token = ptr.Ptr(hook.Secret)
+	}
+
+	_, _, err = g.client.Hooks.Add(ctx, projectId, options)
+	return err
+}
+
+func (g *GitLab) Stats() gitlab.Stats {
+	return g.client.Stats()
+}
+
+func (g *GitLab) ResolveRef(ctx context.Context, projectId int64, ref string) (string, error) {
+	return g.client.ResolveRef(ctx, projectId, ref)
+}
+
+func (g *GitLab) ResetRefs() {
+	g.client.ResetRefs()
+}
+
+func (g *GitLab) FileURL(projectURL, ref, filePath string) string {
+	return fmt.Sprintf("%s/-/blob/%s/%s", projectURL, ref, filePath)
+}
+
+func (g *GitLab) ArchivesByPath() bool {
+	return true
+}
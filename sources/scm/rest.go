@@ -0,0 +1,488 @@
+package scm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"vislab/libs/ptr"
+	"vislab/sources/gitlab"
+	gitlabTypes "vislab/sources/gitlab/types"
+)
+
+var shaPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// restProvider holds what github and gitea have in common, both address
+// repositories by owner/name and are paginated by the Link header. Orgs have
+// no subgroups there.
+type restProvider struct {
+	client *gitlab.Client
+	// gitea pages with limit instead of per_page and pages trees as well
+	limitPaging bool
+	useArchived bool
+
+	mu    sync.Mutex
+	paths map[int64]string
+	refs  map[string]string
+}
+
+type (
+	pageOptions struct {
+		Page      int64  `url:"page,omitempty"`
+		PerPage   int64  `url:"per_page,omitempty"`
+		Limit     int64  `url:"limit,omitempty"`
+		Ref       string `url:"ref,omitempty"`
+		Recursive string `url:"recursive,omitempty"`
+		SHA       string `url:"sha,omitempty"`
+	}
+
+	restOwner struct {
+		ID       int64  `json:"id"`
+		Login    string `json:"login"`
+		Username string `json:"username"`
+		Name     string `json:"name"`
+		Type     string `json:"type"`
+	}
+
+	restRepo struct {
+		ID            int64      `json:"id"`
+		Name          string     `json:"name"`
+		FullName      string     `json:"full_name"`
+		Description   string     `json:"description"`
+		DefaultBranch string     `json:"default_branch"`
+		HTMLURL       string     `json:"html_url"`
+		CreatedAt     *time.Time `json:"created_at"`
+		UpdatedAt     *time.Time `json:"updated_at"`
+		PushedAt      *time.Time `json:"pushed_at"`
+		Archived      bool       `json:"archived"`
+		Topics        []string   `json:"topics"`
+		Empty         *bool      `json:"empty"`
+		Owner         *restOwner `json:"owner"`
+	}
+
+	restOrg struct {
+		ID          int64  `json:"id"`
+		Login       string `json:"login"`
+		Username    string `json:"username"`
+		Name        string `json:"name"`
+		FullName    string `json:"full_name"`
+		Description string `json:"description"`
+	}
+
+	restTree struct {
+		Tree []struct {
+			Path string `json:"path"`
+			Type string `json:"type"`
+		} `json:"tree"`
+		Truncated bool `json:"truncated"`
+	}
+
+	restCompare struct {
+		Commits []struct {
+			SHA string `json:"sha"`
+		} `json:"commits"`
+		Files []struct {
+			Filename         string `json:"filename"`
+			PreviousFilename string `json:"previous_filename"`
+			Status           string `json:"status"`
+			Patch            string `json:"patch"`
+		} `json:"files"`
+	}
+
+	restHook struct {
+		ID     int64             `json:"id,omitempty"`
+		Type   string            `json:"type,omitempty"`
+		Name   string            `json:"name,omitempty"`
+		Active bool              `json:"active"`
+		Events []string          `json:"events,omitempty"`
+		Config map[string]string `json:"config"`
+	}
+)
+
+func newRestProvider(client *gitlab.Client, limitPaging, useArchived bool) *restProvider {
+	return &restProvider{
+		client:      client,
+		limitPaging: limitPaging,
+		useArchived: useArchived,
+		paths:       map[int64]string{},
+		refs:        map[string]string{},
+	}
+}
+
+func (p *restProvider) get(ctx context.Context, path string, opt any, v any) (*gitlab.Response, error) {
+	req, err := p.client.NewRequest(ctx, http.MethodGet, path, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.client.Do(req, v)
+}
+
+func (p *restProvider) pageOptions(page int64) *pageOptions {
+	options := &pageOptions{Page: page}
+	if p.limitPaging {
+		options.Limit = 50
+	} else {
+		options.PerPage = 100
+	}
+
+	return options
+}
+
+func listAll[T any](ctx context.Context, p *restProvider, path string) ([]T, error) {
+	all := []T{}
+
+	for page := int64(1); ; {
+		var items []T
+		resp, err := p.get(ctx, path, p.pageOptions(page), &items)
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, items...)
+
+		if resp.NextPage == 0 {
+			return all, nil
+		}
+		page = int64(resp.NextPage)
+	}
+}
+
+func (p *restProvider) listGroups(ctx context.Context) ([]*gitlabTypes.Group, error) {
+	orgs, err := listAll[*restOrg](ctx, p, "user/orgs")
+	if err != nil {
+		return nil, err
+	}
+
+	groups := []*gitlabTypes.Group{}
+	for _, org := range orgs {
+		path := firstNonEmpty(org.Login, org.Username, org.Name)
+
+		groups = append(groups, &gitlabTypes.Group{
+			ID:          org.ID,
+			Name:        path,
+			Path:        path,
+			FullName:    firstNonEmpty(org.FullName, path),
+			FullPath:    path,
+			Description: org.Description,
+		})
+	}
+
+	return groups, nil
+}
+
+func (p *restProvider) listProjects(ctx context.Context, group *gitlabTypes.Group) ([]*gitlabTypes.Project, error) {
+	repos, err := listAll[*restRepo](ctx, p, fmt.Sprintf("orgs/%s/repos", url.PathEscape(group.Path)))
+	if err != nil {
+		return nil, err
+	}
+
+	projects := []*gitlabTypes.Project{}
+	for _, repo := range repos {
+		if repo.Archived && !p.useArchived {
+			continue
+		}
+
+		projects = append(projects, p.project(repo))
+	}
+
+	return projects, nil
+}
+
+func (p *restProvider) getProject(ctx context.Context, projectId int64) (*gitlabTypes.Project, error) {
+	repo := &restRepo{}
+	if _, err := p.get(ctx, fmt.Sprintf("repositories/%d", projectId), nil, repo); err != nil {
+		return nil, err
+	}
+
+	return p.project(repo), nil
+}
+
+func (p *restProvider) getProjectByPath(ctx context.Context, path string) (*gitlabTypes.Project, error) {
+	repo := &restRepo{}
+	if _, err := p.get(ctx, "repos/"+path, nil, repo); err != nil {
+		return nil, err
+	}
+
+	return p.project(repo), nil
+}
+
+// repoPath returns owner/name of the repository, the apis address
+// repositories by it.
+func (p *restProvider) repoPath(ctx context.Context, projectId int64) (string, error) {
+	p.mu.Lock()
+	path, ok := p.paths[projectId]
+	p.mu.Unlock()
+	if ok {
+		return path, nil
+	}
+
+	project, err := p.getProject(ctx, projectId)
+	if err != nil {
+		return "", fmt.Errorf("failed to get repository: %w", err)
+	}
+
+	return *project.PathWithGroup, nil
+}
+
+// resolveRef returns the sha of the newest commit of the ref, refs are
+// resolved once until resetRefs.
+func (p *restProvider) resolveRef(ctx context.Context, projectId int64, ref string) (string, error) {
+	if shaPattern.MatchString(ref) {
+		return ref, nil
+	}
+
+	key := fmt.Sprintf("%d:%s", projectId, ref)
+
+	p.mu.Lock()
+	sha, ok := p.refs[key]
+	p.mu.Unlock()
+	if ok {
+		return sha, nil
+	}
+
+	repoPath, err := p.repoPath(ctx, projectId)
+	if err != nil {
+		return "", err
+	}
+
+	options := &pageOptions{SHA: ref}
+	if p.limitPaging {
+		options.Limit = 1
+	} else {
+		options.PerPage = 1
+	}
+
+	commits := []*struct {
+		SHA string `json:"sha"`
+	}{}
+	if _, err := p.get(ctx, fmt.Sprintf("repos/%s/commits", repoPath), options, &commits); err != nil {
+		return "", err
+	}
+
+	if len(commits) == 0 {
+		return "", fmt.Errorf("ref %s not found", ref)
+	}
+
+	p.mu.Lock()
+	p.refs[key] = commits[0].SHA
+	p.mu.Unlock()
+
+	return commits[0].SHA, nil
+}
+
+func (p *restProvider) resetRefs() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.refs = map[string]string{}
+}
+
+func (p *restProvider) getRaw(ctx context.Context, path string, opt any, accept string) ([]byte, error) {
+	req, err := p.client.NewRequest(ctx, http.MethodGet, path, opt)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	var data []byte
+	if _, err := p.client.Do(req, &data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (p *restProvider) listDir(ctx context.Context, projectId int64, ref, dir string) ([]string, error) {
+	repoPath, err := p.repoPath(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	dir = strings.Trim(dir, "/")
+	paths := []string{}
+
+	// gitea marks all but the last page of the tree as truncated, github
+	// returns it at once
+	for page := int64(1); ; page++ {
+		options := p.pageOptions(page)
+		options.Recursive = "true"
+
+		tree := &restTree{}
+		if _, err := p.get(ctx, fmt.Sprintf("repos/%s/git/trees/%s", repoPath, url.PathEscape(ref)), options, tree); err != nil {
+			return nil, err
+		}
+
+		for _, entry := range tree.Tree {
+			if entry.Type != "blob" {
+				continue
+			}
+			if dir == "" || strings.HasPrefix(entry.Path, dir+"/") {
+				paths = append(paths, entry.Path)
+			}
+		}
+
+		if !p.limitPaging && tree.Truncated {
+			return nil, fmt.Errorf("tree of %s at %s is truncated, %d files listed", repoPath, ref, len(paths))
+		}
+
+		if !p.limitPaging || !tree.Truncated || len(tree.Tree) == 0 {
+			break
+		}
+	}
+
+	return paths, nil
+}
+
+func (p *restProvider) getLanguages(ctx context.Context, projectId int64) (map[string]float64, error) {
+	repoPath, err := p.repoPath(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	bytesByLanguage := map[string]int64{}
+	if _, err := p.get(ctx, fmt.Sprintf("repos/%s/languages", repoPath), nil, &bytesByLanguage); err != nil {
+		return nil, err
+	}
+
+	total := int64(0)
+	for _, size := range bytesByLanguage {
+		total += size
+	}
+
+	// gitlab reports percentages, the others bytes
+	languages := map[string]float64{}
+	for language, size := range bytesByLanguage {
+		if total > 0 {
+			languages[language] = float64(size) * 100 / float64(total)
+		}
+	}
+
+	return languages, nil
+}
+
+func (p *restProvider) compare(ctx context.Context, projectId int64, from, to string) (*gitlabTypes.CompareResult, error) {
+	repoPath, err := p.repoPath(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	compare := &restCompare{}
+	if _, err := p.get(ctx, fmt.Sprintf("repos/%s/compare/%s...%s", repoPath, url.PathEscape(from), url.PathEscape(to)), nil, compare); err != nil {
+		return nil, err
+	}
+
+	result := &gitlabTypes.CompareResult{
+		CompareSameRef: ptr.Ptr(from == to),
+	}
+
+	for _, commit := range compare.Commits {
+		result.Commits = append(result.Commits, &gitlabTypes.Commit{ID: commit.SHA})
+	}
+
+	for _, file := range compare.Files {
+		oldPath := firstNonEmpty(file.PreviousFilename, file.Filename)
+
+		result.Diffs = append(result.Diffs, &gitlabTypes.Diff{
+			OldPath:     ptr.Ptr(oldPath),
+			NewPath:     ptr.Ptr(file.Filename),
+			NewFile:     ptr.Ptr(file.Status == "added"),
+			RenamedFile: ptr.Ptr(file.Status == "renamed"),
+			DeletedFile: ptr.Ptr(file.Status == "removed" || file.Status == "deleted"),
+			Diff:        ptr.Ptr(file.Patch),
+		})
+	}
+
+	return result, nil
+}
+
+func (p *restProvider) ensureHook(ctx context.Context, projectId int64, hook *Hook, newHook *restHook) error {
+	repoPath, err := p.repoPath(ctx, projectId)
+	if err != nil {
+		return err
+	}
+
+	hooks, err := listAll[*restHook](ctx, p, fmt.Sprintf("repos/%s/hooks", repoPath))
+	if err != nil {
+		return err
+	}
+
+	if slices.ContainsFunc(hooks, func(h *restHook) bool {
+		return h.Config["url"] == hook.URL
+	}) {
+		return nil
+	}
+
+	newHook.Active = true
+	newHook.Events = []string{"push", "create"}
+	newHook.Config = map[string]string{
+		"url":          hook.URL,
+		"content_type": "json",
+	}
+	if hook.Secret != "" {
+		newHook.Config["secret"] = hook.Secret
+	}
+
+	req, err := p.client.NewRequest(ctx, http.MethodPost, fmt.Sprintf("repos/%s/hooks", repoPath), newHook)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.client.Do(req, &restHook{})
+	return err
+}
+
+func (p *restProvider) project(repo *restRepo) *gitlabTypes.Project {
+	project := &gitlabTypes.Project{
+		ID:             ptr.Ptr(repo.ID),
+		Name:           ptr.Ptr(repo.Name),
+		Description:    ptr.Ptr(repo.Description),
+		DefaultBranch:  ptr.Ptr(repo.DefaultBranch),
+		WebURL:         ptr.Ptr(repo.HTMLURL),
+		PathWithGroup:  ptr.Ptr(repo.FullName),
+		CreatedAt:      repo.CreatedAt,
+		UpdatedAt:      repo.UpdatedAt,
+		LastActivityAt: repo.PushedAt,
+		Archived:       ptr.Ptr(repo.Archived),
+		Topics:         repo.Topics,
+		EmptyRepo:      repo.Empty,
+	}
+
+	if project.LastActivityAt == nil {
+		project.LastActivityAt = repo.UpdatedAt
+	}
+
+	if repo.Owner != nil {
+		login := firstNonEmpty(repo.Owner.Login, repo.Owner.Username)
+
+		project.Group = &gitlabTypes.Namespace{
+			ID:   ptr.Ptr(repo.Owner.ID),
+			Name: ptr.Ptr(login),
+			Path: ptr.Ptr(login),
+			Kind: ptr.Ptr(strings.ToLower(repo.Owner.Type)),
+		}
+	}
+
+	p.mu.Lock()
+	p.paths[repo.ID] = repo.FullName
+	p.mu.Unlock()
+
+	return project
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
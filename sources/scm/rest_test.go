@@ -0,0 +1,207 @@
+package scm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"vislab/config"
+	gitlabTypes "vislab/sources/gitlab/types"
+)
+
+func newTestGitHub(t *testing.T, handler http.HandlerFunc) *GitHub {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	github, err := NewGitHub(&config.GitLabClientConfig{BaseURL: server.URL, Token: "token"})
+	if err != nil {
+		t.Fatalf("failed to create github provider: %v", err)
+	}
+
+	return github
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, v any) {
+	t.Helper()
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		t.Errorf("failed to write response: %v", err)
+	}
+}
+
+func TestGetProjectMapsRepository(t *testing.T) {
+	github := newTestGitHub(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/acme/billing" {
+			http.NotFound(w, r)
+			return
+		}
+
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("Authorization = %q, want %q", got, "Bearer token")
+		}
+
+		w.Write([]byte(`{
+			"id": 42,
+			"name": "billing",
+			"full_name": "acme/billing",
+			"description": "billing service",
+			"default_branch": "main",
+			"html_url": "https://github.com/acme/billing",
+			"updated_at": "2024-05-01T10:00:00Z",
+			"archived": true,
+			"topics": ["payments"],
+			"owner": {"id": 7, "login": "acme", "type": "Organization"}
+		}`))
+	})
+
+	project, err := github.GetProjectByPath(context.Background(), "acme/billing")
+	if err != nil {
+		t.Fatalf("GetProjectByPath() error = %v", err)
+	}
+
+	if *project.ID != 42 || *project.Name != "billing" || *project.PathWithGroup != "acme/billing" {
+		t.Errorf("project = %d %s %s, want 42 billing acme/billing", *project.ID, *project.Name, *project.PathWithGroup)
+	}
+	if *project.DefaultBranch != "main" || *project.WebURL != "https://github.com/acme/billing" || !*project.Archived {
+		t.Errorf("project = %s %s %t, want main https://github.com/acme/billing true", *project.DefaultBranch, *project.WebURL, *project.Archived)
+	}
+	if project.LastActivityAt == nil || !project.LastActivityAt.Equal(*project.UpdatedAt) {
+		t.Errorf("LastActivityAt = %v, want the update time without a push time", project.LastActivityAt)
+	}
+	if len(project.Topics) != 1 || project.Topics[0] != "payments" {
+		t.Errorf("Topics = %v, want [payments]", project.Topics)
+	}
+	if project.Group == nil || *project.Group.Path != "acme" || *project.Group.Kind != "organization" {
+		t.Errorf("Group = %+v, want the acme organization", project.Group)
+	}
+
+	if path, err := github.repoPath(context.Background(), 42); err != nil || path != "acme/billing" {
+		t.Errorf("repoPath() = %q, %v, want the mapped path without a request", path, err)
+	}
+}
+
+func TestListProjectsFollowsLinkHeader(t *testing.T) {
+	requests := 0
+
+	github := newTestGitHub(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		page := r.URL.Query().Get("page")
+		if page == "1" {
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s/orgs/acme/repos?page=2>; rel="next", <http://%s/orgs/acme/repos?page=2>; rel="last"`, r.Host, r.Host))
+			writeJSON(t, w, []map[string]any{{"id": 1, "full_name": "acme/a"}, {"id": 2, "full_name": "acme/old", "archived": true}})
+			return
+		}
+
+		writeJSON(t, w, []map[string]any{{"id": 3, "full_name": "acme/b"}})
+	})
+
+	projects, err := github.ListProjects(context.Background(), &gitlabTypes.Group{Path: "acme"}, false)
+	if err != nil {
+		t.Fatalf("ListProjects() error = %v", err)
+	}
+
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2", requests)
+	}
+
+	paths := []string{}
+	for _, project := range projects {
+		paths = append(paths, *project.PathWithGroup)
+	}
+	if fmt.Sprint(paths) != "[acme/a acme/b]" {
+		t.Errorf("projects = %v, want [acme/a acme/b] without the archived one", paths)
+	}
+}
+
+func TestGetLanguagesConvertsBytesToPercent(t *testing.T) {
+	github := newTestGitHub(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repositories/1":
+			writeJSON(t, w, map[string]any{"id": 1, "full_name": "acme/a"})
+		case "/repos/acme/a/languages":
+			writeJSON(t, w, map[string]int64{"Go": 300, "Shell": 100})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	languages, err := github.GetLanguages(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetLanguages() error = %v", err)
+	}
+
+	want := map[string]float64{"Go": 75, "Shell": 25}
+	if len(languages) != len(want) {
+		t.Fatalf("languages = %v, want %v", languages, want)
+	}
+	for language, percent := range want {
+		if math.Abs(languages[language]-percent) > 1e-9 {
+			t.Errorf("languages[%s] = %v, want %v", language, languages[language], percent)
+		}
+	}
+}
+
+func TestCompareMapsFileStatus(t *testing.T) {
+	github := newTestGitHub(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repositories/1":
+			writeJSON(t, w, map[string]any{"id": 1, "full_name": "acme/a"})
+		case "/repos/acme/a/compare/v1...v2":
+			writeJSON(t, w, map[string]any{
+				"commits": []map[string]string{{"sha": "abc"}},
+				"files": []map[string]string{
+					{"filename": "new.go", "status": "added"},
+					{"filename": "moved.go", "previous_filename": "old.go", "status": "renamed"},
+					{"filename": "gone.go", "status": "removed"},
+					{"filename": "main.go", "status": "modified", "patch": "@@ -1 +1 @@"},
+				},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	result, err := github.Compare(context.Background(), 1, "v1", "v2")
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+
+	if len(result.Commits) != 1 || result.Commits[0].ID != "abc" {
+		t.Errorf("commits = %v, want [abc]", result.Commits)
+	}
+	if *result.CompareSameRef {
+		t.Errorf("CompareSameRef = true, want false")
+	}
+
+	tests := []struct {
+		oldPath, newPath        string
+		added, renamed, removed bool
+	}{
+		{"new.go", "new.go", true, false, false},
+		{"old.go", "moved.go", false, true, false},
+		{"gone.go", "gone.go", false, false, true},
+		{"main.go", "main.go", false, false, false},
+	}
+
+	if len(result.Diffs) != len(tests) {
+		t.Fatalf("diffs = %d, want %d", len(result.Diffs), len(tests))
+	}
+
+	for i, tt := range tests {
+		diff := result.Diffs[i]
+
+		if *diff.OldPath != tt.oldPath || *diff.NewPath != tt.newPath {
+			t.Errorf("diff %d paths = %s -> %s, want %s -> %s", i, *diff.OldPath, *diff.NewPath, tt.oldPath, tt.newPath)
+		}
+		if *diff.NewFile != tt.added || *diff.RenamedFile != tt.renamed || *diff.DeletedFile != tt.removed {
+			t.Errorf("diff %d flags = %t %t %t, want %t %t %t", i, *diff.NewFile, *diff.RenamedFile, *diff.DeletedFile, tt.added, tt.renamed, tt.removed)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package scm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"vislab/config"
+	"vislab/sources/gitlab"
+	gitlabTypes "vislab/sources/gitlab/types"
+)
+
+const (
+	KindGitLab = "gitlab"
+	KindGitHub = "github"
+	KindGitea  = "gitea"
+)
+
+var ErrUnsupported = errors.New("not supported by the scm")
+
+type (
+	// Provider is the source code hosting the collector reads projects from.
+	// Results use the gitlab types, other hostings are mapped onto them.
+	Provider interface {
+		Kind() string
+		ListGroups(ctx context.Context) ([]*gitlabTypes.Group, error)
+		// ListProjects returns the projects of the group, with the projects of
+		// its subgroups when asked and the hosting has subgroups. Archived
+		// projects are left out unless the client config uses them.
+		ListProjects(ctx context.Context, group *gitlabTypes.Group, subgroups bool) ([]*gitlabTypes.Project, error)
+		GetProject(ctx context.Context, projectId int64) (*gitlabTypes.Project, error)
+		GetProjectByPath(ctx context.Context, path string) (*gitlabTypes.Project, error)
+		GetFile(ctx context.Context, projectId int64, ref, filePath string) ([]byte, error)
+		// ListDir returns the paths of all files under dir, recursively.
+		ListDir(ctx context.Context, projectId int64, ref, dir string) ([]string, error)
+		// Archive returns the tar.gz of the repository at the ref. Providers
+		// which can not limit it to dir return the whole repository.
+		Archive(ctx context.Context, projectId int64, ref, dir string) ([]byte, error)
+		// ArchivesByPath tells whether Archive limits the archive to dir.
+		ArchivesByPath() bool
+		// FileURL is the web page of the file at the ref in the project with
+		// the given web url.
+		FileURL(projectURL, ref, filePath string) string
+		GetLatestTag(ctx context.Context, projectId int64) (*gitlabTypes.Tag, error)
+		GetLanguages(ctx context.Context, projectId int64) (map[string]float64, error)
+		ListContributors(ctx context.Context, projectId int64) ([]*gitlabTypes.Contributor, error)
+		Compare(ctx context.Context, projectId int64, from, to string) (*gitlabTypes.CompareResult, error)
+		// EnsureHook adds the push and tag webhook unless one with the url exists.
+		EnsureHook(ctx context.Context, projectId int64, hook *Hook) error
+		Stats() gitlab.Stats
+		// ResolveRef returns the commit sha the branch or tag points to.
+		ResolveRef(ctx context.Context, projectId int64, ref string) (string, error)
+		// ResetRefs makes refs resolved before be resolved again, it is called
+		// at the start of every collection.
+		ResetRefs()
+	}
+
+	Hook struct {
+		URL    string
+		Secret string
+	}
+)
+
+func New(kind string, conf *config.GitLabClientConfig) (Provider, error) {
+	if conf == nil {
+		return nil, fmt.Errorf("no scm client config specified")
+	}
+
+	switch kind {
+	case "", KindGitLab:
+		return NewGitLab(conf)
+	case KindGitHub:
+		return NewGitHub(conf)
+	case KindGitea:
+		return NewGitea(conf)
+	}
+
+	return nil, fmt.Errorf("unknown scm provider: %s", kind)
+}
@@ -29,24 +29,24 @@ func getSetKafkaFunc(pathParts []string) (func(string, *types.All) error, error)
 		}, nil
 	case "host":
 		return func(s string, all *types.All) error {
-			checkKafka(all)
+			checkKafkaBrokers(all)
 
 			host := ptr.Ptr(s)
 
-			if all.Kafka.LastInstance.Host == nil {
-				all.Kafka.LastInstance.Host = host
+			if all.Kafka.LastInstance.LastBroker.Host == nil {
+				all.Kafka.LastInstance.LastBroker.Host = host
 				return nil
 			}
 
-			kafka := &types.Kafka{Host: host}
-			all.Kafka.Instances = append(all.Kafka.Instances, kafka)
-			all.Kafka.LastInstance = kafka
+			// a repeated host is the next cluster, several brokers of one
+			// cluster are listed with brokers
+			newKafkaInstance(all, &types.KafkaBroker{Host: host})
 
 			return nil
 		}, nil
 	case "port":
 		return func(s string, all *types.All) error {
-			checkKafka(all)
+			checkKafkaBrokers(all)
 
 			intVal, err := strconv.Atoi(s)
 			if err != nil {
@@ -55,14 +55,47 @@ func getSetKafkaFunc(pathParts []string) (func(string, *types.All) error, error)
 
 			port := ptr.Ptr(int64(intVal))
 
-			if all.Kafka.LastInstance.Port == nil {
-				all.Kafka.LastInstance.Port = port
+			if all.Kafka.LastInstance.LastBroker.Port == nil {
+				all.Kafka.LastInstance.LastBroker.Port = port
 				return nil
 			}
 
-			kafka := &types.Kafka{Port: port}
-			all.Kafka.Instances = append(all.Kafka.Instances, kafka)
-			all.Kafka.LastInstance = kafka
+			newKafkaInstance(all, &types.KafkaBroker{Port: port})
+
+			return nil
+		}, nil
+	case "brokers":
+		return func(s string, all *types.All) error {
+			checkKafka(all)
+
+			for _, address := range strings.Split(s, ",") {
+				address = strings.TrimSpace(address)
+				if address == "" {
+					continue
+				}
+
+				broker := &types.KafkaBroker{}
+
+				host, portStr, ok := strings.Cut(address, ":")
+				broker.Host = ptr.Ptr(host)
+
+				if ok {
+					intVal, err := strconv.Atoi(portStr)
+					if err != nil {
+						return err
+					}
+
+					broker.Port = ptr.Ptr(int64(intVal))
+				}
+
+				if all.Kafka.LastInstance.LastBroker != nil && all.Kafka.LastInstance.LastBroker.Host == nil && all.Kafka.LastInstance.LastBroker.Port == nil {
+					*all.Kafka.LastInstance.LastBroker = *broker
+					continue
+				}
+
+				all.Kafka.LastInstance.Brokers = append(all.Kafka.LastInstance.Brokers, broker)
+				all.Kafka.LastInstance.LastBroker = broker
+			}
 
 			return nil
 		}, nil
@@ -155,6 +188,25 @@ func checkKafka(all *types.All) {
 	}
 }
 
+func newKafkaInstance(all *types.All, broker *types.KafkaBroker) {
+	kafka := &types.Kafka{
+		Brokers:    []*types.KafkaBroker{broker},
+		LastBroker: broker,
+	}
+	all.Kafka.Instances = append(all.Kafka.Instances, kafka)
+	all.Kafka.LastInstance = kafka
+}
+
+func checkKafkaBrokers(all *types.All) {
+	checkKafka(all)
+
+	if all.Kafka.LastInstance.Brokers == nil {
+		broker := &types.KafkaBroker{}
+		all.Kafka.LastInstance.LastBroker = broker
+		all.Kafka.LastInstance.Brokers = []*types.KafkaBroker{broker}
+	}
+}
+
 func checkKafkaQueues(all *types.All) {
 	checkKafka(all)
 
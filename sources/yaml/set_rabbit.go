@@ -87,6 +87,57 @@ func getSetRabbitFunc(pathParts []string) (func(string, *types.All) error, error
 				all.RabbitMQ.LastInstance.Queues = append(all.RabbitMQ.LastInstance.Queues, queue)
 				all.RabbitMQ.LastInstance.LastQueue = queue
 
+				return nil
+			}, nil
+		case "type":
+			return func(s string, all *types.All) error {
+				checkRabbitQueues(all)
+
+				ty := ptr.Ptr(s)
+
+				if all.RabbitMQ.LastInstance.LastQueue.QueueType == nil {
+					all.RabbitMQ.LastInstance.LastQueue.QueueType = ty
+					return nil
+				}
+
+				queue := &types.RabbitQueue{QueueType: ty}
+				all.RabbitMQ.LastInstance.Queues = append(all.RabbitMQ.LastInstance.Queues, queue)
+				all.RabbitMQ.LastInstance.LastQueue = queue
+
+				return nil
+			}, nil
+		case "exchange":
+			return func(s string, all *types.All) error {
+				checkRabbitQueues(all)
+
+				exchange := ptr.Ptr(s)
+
+				if all.RabbitMQ.LastInstance.LastQueue.Exchange == nil {
+					all.RabbitMQ.LastInstance.LastQueue.Exchange = exchange
+					return nil
+				}
+
+				queue := &types.RabbitQueue{Exchange: exchange}
+				all.RabbitMQ.LastInstance.Queues = append(all.RabbitMQ.LastInstance.Queues, queue)
+				all.RabbitMQ.LastInstance.LastQueue = queue
+
+				return nil
+			}, nil
+		case "routing_key":
+			return func(s string, all *types.All) error {
+				checkRabbitQueues(all)
+
+				routingKey := ptr.Ptr(s)
+
+				if all.RabbitMQ.LastInstance.LastQueue.RoutingKey == nil {
+					all.RabbitMQ.LastInstance.LastQueue.RoutingKey = routingKey
+					return nil
+				}
+
+				queue := &types.RabbitQueue{RoutingKey: routingKey}
+				all.RabbitMQ.LastInstance.Queues = append(all.RabbitMQ.LastInstance.Queues, queue)
+				all.RabbitMQ.LastInstance.LastQueue = queue
+
 				return nil
 			}, nil
 		}
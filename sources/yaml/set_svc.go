@@ -81,6 +81,23 @@ func getSetSvcFunc(pathParts []string) (func(string, *types.All) error, error) {
 			all.Service.Instances = append(all.Service.Instances, service)
 			all.Service.LastInstance = service
 
+			return nil
+		}, nil
+	case "origin":
+		return func(s string, all *types.All) error {
+			checkSvc(all)
+
+			origin := ptr.Ptr(s)
+
+			if all.Service.LastInstance.Origin == nil {
+				all.Service.LastInstance.Origin = origin
+				return nil
+			}
+
+			service := &types.Service{Origin: origin}
+			all.Service.Instances = append(all.Service.Instances, service)
+			all.Service.LastInstance = service
+
 			return nil
 		}, nil
 	case "port":
@@ -6,11 +6,16 @@ type Kafkas struct {
 }
 
 type Kafka struct {
-	Name      *string       `yaml:"name"`
-	Host      *string       `yaml:"host"`
-	Port      *int64        `yaml:"port"`
-	Queues    []*KafkaQueue `yaml:"queues"`
-	LastQueue *KafkaQueue   `yaml:"-"`
+	Name       *string        `yaml:"name"`
+	Brokers    []*KafkaBroker `yaml:"brokers"`
+	LastBroker *KafkaBroker   `yaml:"-"`
+	Queues     []*KafkaQueue  `yaml:"queues"`
+	LastQueue  *KafkaQueue    `yaml:"-"`
+}
+
+type KafkaBroker struct {
+	Host *string `yaml:"host"`
+	Port *int64  `yaml:"port"`
 }
 
 type KafkaQueue struct {
@@ -14,5 +14,8 @@ type RabbitMQ struct {
 }
 
 type RabbitQueue struct {
-	Name *string `yaml:"name"`
+	Name       *string `yaml:"name"`
+	QueueType  *string `yaml:"queue_type"`
+	Exchange   *string `yaml:"exchange"`
+	RoutingKey *string `yaml:"routing_key"`
 }
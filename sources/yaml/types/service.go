@@ -9,6 +9,7 @@ type Service struct {
 	Name      *string `yaml:"name"`
 	FullName  *string `yaml:"full_name"`
 	ProjectID *int64  `yaml:"gitlab_id"`
+	Origin    *string `yaml:"origin"`
 	Tag       *string `yaml:"tag"`
 	Ports     []*Port `yaml:"ports"`
 	LastPort  *Port   `yaml:"-"`
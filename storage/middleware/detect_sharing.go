@@ -0,0 +1,153 @@
+package storefuncs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"vislab/storage"
+	storeTypes "vislab/storage/neo4j/types"
+)
+
+type sharedUsers struct {
+	resource *storeTypes.SharedResource
+	// owners and users map service uids to their origin/name labels, services
+	// with the same name from different origins are kept apart
+	owners map[string]string
+	users  map[string]string
+}
+
+// DetectSharing marks postgres tables, schemes and databases with the services
+// that own them through migrations and the other services that use them. The
+// resources used by more than one service are returned.
+func DetectSharing(ctx context.Context, storage storage.Storage) ([]*storeTypes.SharedResource, error) {
+	usages, err := storage.Sharing().GetTableUsages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get table usages: %w", err)
+	}
+
+	resources := collectSharedResources(usages)
+
+	slog.Info("resetting shared flags")
+	if err := storage.Sharing().ResetAll(ctx); err != nil {
+		return nil, fmt.Errorf("failed to reset shared flags: %w", err)
+	}
+
+	shared := []*storeTypes.SharedResource{}
+
+	for _, resource := range resources {
+		if err := storage.Sharing().Set(ctx, resource); err != nil {
+			slog.Error("failed to set shared flag", "class", resource.Node.Class, "id", resource.Node.ID, "error", err)
+			continue
+		}
+
+		if resource.Shared() {
+			shared = append(shared, resource)
+		}
+	}
+
+	return shared, nil
+}
+
+func collectSharedResources(usages []*storeTypes.TableUsage) []*storeTypes.SharedResource {
+	resources := map[string]*sharedUsers{}
+	keys := []string{}
+
+	add := func(node storeTypes.ConnNode, resource *storeTypes.SharedResource, serviceUID, service string, owner bool) {
+		users, ok := resources[node.ID]
+		if !ok {
+			resource.Node = node
+			users = &sharedUsers{
+				resource: resource,
+				owners:   map[string]string{},
+				users:    map[string]string{},
+			}
+			resources[node.ID] = users
+			keys = append(keys, node.ID)
+		}
+
+		if owner {
+			users.owners[serviceUID] = service
+			return
+		}
+		users.users[serviceUID] = service
+	}
+
+	for _, usage := range usages {
+		if usage.ServiceUID == nil || usage.TableUID == nil || usage.SchemeUID == nil || usage.DatabaseUID == nil {
+			continue
+		}
+
+		serviceUID := *usage.ServiceUID
+		service := serviceLabel(usage)
+
+		owner := usage.ConnType == storeTypes.ConnMigrates
+
+		// dummy tables stand for a service that uses the whole scheme
+		if usage.ConnType != storeTypes.ConnDummy {
+			add(storeTypes.ConnNode{Class: storeTypes.PostgresTableClass, ID: *usage.TableUID}, &storeTypes.SharedResource{
+				Host:     usage.Host,
+				Database: usage.Database,
+				Scheme:   usage.Scheme,
+				Table:    usage.Table,
+			}, serviceUID, service, owner)
+		}
+
+		add(storeTypes.ConnNode{Class: storeTypes.PostgresSchemeClass, ID: *usage.SchemeUID}, &storeTypes.SharedResource{
+			Host:     usage.Host,
+			Database: usage.Database,
+			Scheme:   usage.Scheme,
+		}, serviceUID, service, owner)
+
+		add(storeTypes.ConnNode{Class: storeTypes.PostgresDBClass, ID: *usage.DatabaseUID}, &storeTypes.SharedResource{
+			Host:     usage.Host,
+			Database: usage.Database,
+		}, serviceUID, service, owner)
+	}
+
+	sharedResources := make([]*storeTypes.SharedResource, 0, len(keys))
+
+	for _, key := range keys {
+		users := resources[key]
+
+		for uid := range users.owners {
+			delete(users.users, uid)
+		}
+
+		users.resource.Owners = sortedValues(users.owners)
+		users.resource.Users = sortedValues(users.users)
+
+		sharedResources = append(sharedResources, users.resource)
+	}
+
+	slices.SortFunc(sharedResources, func(a, b *storeTypes.SharedResource) int {
+		return strings.Compare(a.Path(), b.Path())
+	})
+
+	return sharedResources
+}
+
+// serviceLabel names the service as origin/name, falling back to its uid when
+// the name is not stored.
+func serviceLabel(usage *storeTypes.TableUsage) string {
+	if usage.ServiceName == nil {
+		return *usage.ServiceUID
+	}
+
+	if usage.ServiceOrigin == nil || *usage.ServiceOrigin == "" {
+		return *usage.ServiceName
+	}
+
+	return *usage.ServiceOrigin + "/" + *usage.ServiceName
+}
+
+func sortedValues(set map[string]string) []string {
+	values := make([]string, 0, len(set))
+	for _, value := range set {
+		values = append(values, value)
+	}
+
+	slices.Sort(values)
+	return values
+}
@@ -0,0 +1,187 @@
+package storefuncs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"vislab/libs/ptr"
+	"vislab/storage"
+	storeTypes "vislab/storage/neo4j/types"
+)
+
+const (
+	kafkaBroker  = "kafka"
+	rabbitBroker = "rabbitmq"
+)
+
+// LinkServices recomputes PUBLISHES_TO edges between services that produce to and
+// consume from the same kafka topic or rabbitmq exchange/routing key.
+func LinkServices(ctx context.Context, storage storage.Storage) error {
+	slog.Info("deleting previous service flows")
+	if err := storage.Flow().DeleteAll(ctx); err != nil {
+		return fmt.Errorf("failed to delete service flows: %w", err)
+	}
+
+	kafkaEndpoints, err := storage.Flow().GetKafkaEndpoints(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get kafka endpoints: %w", err)
+	}
+
+	for _, flow := range matchKafkaFlows(kafkaEndpoints) {
+		slog.Info("creating svc-svc flow", "from_id", *flow.FromUID, "to_id", *flow.ToUID, "broker", *flow.Broker, "topic", *flow.Topic)
+		if err := storage.Flow().Create(ctx, flow); err != nil {
+			slog.Error("failed to create kafka flow", "from_id", *flow.FromUID, "to_id", *flow.ToUID, "topic", *flow.Topic, "error", err)
+			continue
+		}
+	}
+
+	rabbitEndpoints, err := storage.Flow().GetRabbitEndpoints(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get rabbitmq endpoints: %w", err)
+	}
+
+	for _, flow := range matchRabbitFlows(rabbitEndpoints) {
+		slog.Info("creating svc-svc flow", "from_id", *flow.FromUID, "to_id", *flow.ToUID, "broker", *flow.Broker, "topic", *flow.Topic)
+		if err := storage.Flow().Create(ctx, flow); err != nil {
+			slog.Error("failed to create rabbitmq flow", "from_id", *flow.FromUID, "to_id", *flow.ToUID, "topic", *flow.Topic, "error", err)
+			continue
+		}
+	}
+
+	return nil
+}
+
+func matchKafkaFlows(endpoints []*storeTypes.FlowEndpoint) []*storeTypes.Flow {
+	producers, consumers := splitEndpoints(endpoints)
+	flows := []*storeTypes.Flow{}
+
+	for _, producer := range producers {
+		for _, consumer := range consumers {
+			if *producer.ServiceUID == *consumer.ServiceUID || *producer.BrokerUID != *consumer.BrokerUID {
+				continue
+			}
+			if producer.Topic == nil || consumer.Topic == nil || *producer.Topic != *consumer.Topic {
+				continue
+			}
+
+			flows = appendFlow(flows, &storeTypes.Flow{
+				FromUID: producer.ServiceUID,
+				ToUID:   consumer.ServiceUID,
+				Broker:  ptr.Ptr(kafkaBroker),
+				Topic:   producer.Topic,
+			})
+		}
+	}
+
+	return flows
+}
+
+func matchRabbitFlows(endpoints []*storeTypes.FlowEndpoint) []*storeTypes.Flow {
+	producers, consumers := splitEndpoints(endpoints)
+	flows := []*storeTypes.Flow{}
+
+	for _, producer := range producers {
+		routingKey := rabbitKey(producer)
+		if routingKey == "" {
+			continue
+		}
+
+		for _, consumer := range consumers {
+			if *producer.ServiceUID == *consumer.ServiceUID || *producer.BrokerUID != *consumer.BrokerUID {
+				continue
+			}
+			if rabbitExchange(producer) != rabbitExchange(consumer) {
+				continue
+			}
+			if !matchRoutingKey(rabbitKey(consumer), routingKey) {
+				continue
+			}
+
+			flows = appendFlow(flows, &storeTypes.Flow{
+				FromUID:  producer.ServiceUID,
+				ToUID:    consumer.ServiceUID,
+				Broker:   ptr.Ptr(rabbitBroker),
+				Topic:    ptr.Ptr(routingKey),
+				Exchange: producer.Exchange,
+			})
+		}
+	}
+
+	return flows
+}
+
+func splitEndpoints(endpoints []*storeTypes.FlowEndpoint) ([]*storeTypes.FlowEndpoint, []*storeTypes.FlowEndpoint) {
+	producers := []*storeTypes.FlowEndpoint{}
+	consumers := []*storeTypes.FlowEndpoint{}
+
+	for _, endpoint := range endpoints {
+		switch endpoint.ConnType {
+		case storeTypes.ConnSendsTo:
+			producers = append(producers, endpoint)
+		case storeTypes.ConnReceivesFrom:
+			consumers = append(consumers, endpoint)
+		}
+	}
+
+	return producers, consumers
+}
+
+func appendFlow(flows []*storeTypes.Flow, flow *storeTypes.Flow) []*storeTypes.Flow {
+	for _, existingFlow := range flows {
+		if *existingFlow.FromUID == *flow.FromUID && *existingFlow.ToUID == *flow.ToUID &&
+			*existingFlow.Broker == *flow.Broker && *existingFlow.Topic == *flow.Topic {
+			return flows
+		}
+	}
+
+	return append(flows, flow)
+}
+
+func rabbitExchange(endpoint *storeTypes.FlowEndpoint) string {
+	if endpoint.Exchange == nil {
+		return ""
+	}
+
+	return *endpoint.Exchange
+}
+
+// rabbitKey falls back to the queue name, as the default exchange routes by queue name.
+func rabbitKey(endpoint *storeTypes.FlowEndpoint) string {
+	if endpoint.RoutingKey != nil {
+		return *endpoint.RoutingKey
+	}
+	if endpoint.Queue != nil {
+		return *endpoint.Queue
+	}
+
+	return ""
+}
+
+func matchRoutingKey(binding, routingKey string) bool {
+	if binding == "" {
+		return false
+	}
+
+	return matchRoutingWords(strings.Split(binding, "."), strings.Split(routingKey, "."))
+}
+
+func matchRoutingWords(binding, words []string) bool {
+	if len(binding) == 0 {
+		return len(words) == 0
+	}
+
+	switch binding[0] {
+	case "#":
+		for i := 0; i <= len(words); i++ {
+			if matchRoutingWords(binding[1:], words[i:]) {
+				return true
+			}
+		}
+		return false
+	case "*":
+		return len(words) > 0 && matchRoutingWords(binding[1:], words[1:])
+	default:
+		return len(words) > 0 && binding[0] == words[0] && matchRoutingWords(binding[1:], words[1:])
+	}
+}
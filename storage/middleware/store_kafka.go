@@ -4,18 +4,22 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
 	"vislab/libs/check"
 	"vislab/libs/ptr"
+	"vislab/resolver"
 	"vislab/storage"
 	storeTypes "vislab/storage/neo4j/types"
 	"vislab/types"
 )
 
-func storeKafka(ctx context.Context, kafka *types.Kafka, serviceNode *storeTypes.ConnNode, storage storage.Storage) error {
-	slog.Info("processing kafka", "kafka", kafka.Host)
+func storeKafka(ctx context.Context, kafka *types.Kafka, serviceNode *storeTypes.ConnNode, storage storage.Storage, hosts *resolver.HostRegistry) error {
+	brokers := kafkaBrokers(kafka, hosts)
 
-	kafkaNode, err := storeKafkaNode(ctx, kafka, storage)
+	slog.Info("processing kafka", "kafka", kafka.Name, "brokers", brokers)
+
+	kafkaNode, err := storeKafkaNode(ctx, kafka, storage, hosts)
 	if err != nil {
 		return err
 	}
@@ -26,7 +30,7 @@ func storeKafka(ctx context.Context, kafka *types.Kafka, serviceNode *storeTypes
 	}
 
 	if len(kafka.Queues) == 0 {
-		slog.Info("creating dummy kafka queue", "kafka", kafka.Host)
+		slog.Info("creating dummy kafka queue", "kafka", kafka.Name, "brokers", brokers)
 		if err := storeDummyKafkaQueue(ctx, kafkaNode, storage, serviceNode, existingQueues); err != nil {
 			return err
 		}
@@ -37,7 +41,7 @@ func storeKafka(ctx context.Context, kafka *types.Kafka, serviceNode *storeTypes
 	for _, queue := range kafka.Queues {
 		queueNode, err := storeKafkaQueue(ctx, queue, kafkaNode, existingQueues, storage)
 		if err != nil {
-			slog.Error("failed to create kafka queue", "queue", queue.Name, "kafka", kafka.Host, "error", err)
+			slog.Error("failed to create kafka queue", "queue", queue.Name, "kafka", kafka.Name, "brokers", brokers, "error", err)
 			continue
 		}
 
@@ -60,45 +64,128 @@ func storeKafka(ctx context.Context, kafka *types.Kafka, serviceNode *storeTypes
 	return nil
 }
 
-func storeKafkaNode(ctx context.Context, kafka *types.Kafka, storage storage.Storage) (*storeTypes.ConnNode, error) {
+func storeKafkaNode(ctx context.Context, kafka *types.Kafka, storage storage.Storage, hosts *resolver.HostRegistry) (*storeTypes.ConnNode, error) {
 	storeKafka := &storeTypes.Kafka{
-		Host: kafka.Host,
-		Name: kafka.Name,
-		Port: kafka.Port,
+		Name:    kafka.Name,
+		Brokers: kafkaBrokers(kafka, hosts),
+	}
+
+	if storeKafka.Name == nil && len(storeKafka.Brokers) == 0 {
+		return nil, fmt.Errorf("kafka cannot be stored, neither name nor brokers specified")
 	}
 
 	kafkaNode := &storeTypes.ConnNode{
 		Class: storeTypes.KafkaClass,
 	}
 
-	slog.Info("updating kafka", "kafka", kafka.Host)
-	dbKafka, err := storage.Kafka().Update(ctx, storeKafka)
-	if err == nil {
-		kafkaNode.ID = *dbKafka.UID
-		return kafkaNode, nil
+	slog.Info("searching kafka cluster", "kafka", kafka.Name, "brokers", storeKafka.Brokers)
+	dbKafkas, err := storage.Kafka().Find(ctx, storeKafka.Name, storeKafka.Brokers)
+	if err != nil {
+		return nil, err
 	}
-	slog.Error("error updating kafka", "kafka", kafka.Host, "error", err)
 
-	if strings.Contains(err.Error(), "nothing to update") {
-		dbKafka, err := storage.Kafka().Get(ctx, *storeKafka.Host)
+	if len(dbKafkas) == 0 {
+		slog.Info("creating kafka", "kafka", kafka.Name, "brokers", storeKafka.Brokers)
+		id, err := storage.Kafka().Create(ctx, storeKafka)
 		if err != nil {
 			return nil, err
 		}
 
-		kafkaNode.ID = *dbKafka.UID
+		kafkaNode.ID = id
 		return kafkaNode, nil
 	}
 
-	slog.Info("creating kafka", "kafka", kafka.Host)
-	id, err := storage.Kafka().Create(ctx, storeKafka)
-	if err != nil {
+	dbKafka := dbKafkas[0]
+	brokers := slices.Clone(dbKafka.Brokers)
+
+	for _, otherKafka := range dbKafkas[1:] {
+		slog.Info("merging kafka clusters", "into", dbKafka.UID, "from", otherKafka.UID)
+		if err := storage.Kafka().Merge(ctx, *dbKafka.UID, *otherKafka.UID); err != nil {
+			return nil, fmt.Errorf("failed to merge kafka clusters: %w", err)
+		}
+
+		brokers = append(brokers, otherKafka.Brokers...)
+	}
+
+	if len(dbKafkas) > 1 {
+		if err := mergeKafkaQueues(ctx, *dbKafka.UID, storage); err != nil {
+			return nil, err
+		}
+	}
+
+	brokers = append(brokers, storeKafka.Brokers...)
+	slices.Sort(brokers)
+
+	storeKafka.UID = dbKafka.UID
+	storeKafka.Brokers = slices.Compact(brokers)
+	// the first name a cluster got is kept, services name it differently
+	if dbKafka.Name != nil {
+		storeKafka.Name = dbKafka.Name
+	}
+
+	kafkaNode.ID = *dbKafka.UID
+
+	if dbKafka.Equal(storeKafka) {
+		return kafkaNode, nil
+	}
+
+	slog.Info("updating kafka", "kafka", storeKafka.Name, "brokers", storeKafka.Brokers)
+	if _, err := storage.Kafka().Update(ctx, storeKafka); err != nil {
 		return nil, err
 	}
 
-	kafkaNode.ID = id
 	return kafkaNode, nil
 }
 
+func mergeKafkaQueues(ctx context.Context, kafkaUid string, storage storage.Storage) error {
+	queues, err := storage.Kafka().GetQueues(ctx, kafkaUid)
+	if err != nil {
+		return err
+	}
+
+	queuesByName := map[string]*storeTypes.KafkaQueue{}
+
+	for _, queue := range queues {
+		if queue.Name == nil {
+			continue
+		}
+
+		existingQueue, ok := queuesByName[*queue.Name]
+		if !ok {
+			queuesByName[*queue.Name] = queue
+			continue
+		}
+
+		slog.Info("merging kafka queues", "queue", *queue.Name, "into", existingQueue.UID, "from", queue.UID)
+		if err := storage.Kafka().MergeQueue(ctx, *existingQueue.UID, *queue.UID); err != nil {
+			return fmt.Errorf("failed to merge kafka queues: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func kafkaBrokers(kafka *types.Kafka, hosts *resolver.HostRegistry) []string {
+	brokers := []string{}
+
+	for _, broker := range kafka.Brokers {
+		if broker.Host == nil {
+			continue
+		}
+
+		address := strings.ToLower(hosts.Resolve(*broker.Host))
+		if broker.Port != nil {
+			address = fmt.Sprintf("%s:%d", address, *broker.Port)
+		}
+
+		brokers = append(brokers, address)
+	}
+
+	slices.Sort(brokers)
+
+	return slices.Compact(brokers)
+}
+
 func storeKafkaQueue(ctx context.Context, queue *types.KafkaQueue, kafkaNode *storeTypes.ConnNode, existingQueues []*storeTypes.KafkaQueue, storage storage.Storage) (*storeTypes.ConnNode, error) {
 	storeQueue := &storeTypes.KafkaQueue{
 		Name:      queue.Name,
@@ -0,0 +1,342 @@
+package storefuncs
+
+import (
+	"context"
+	"log/slog"
+	"vislab/libs/check"
+	"vislab/storage"
+	storeTypes "vislab/storage/neo4j/types"
+	"vislab/types"
+)
+
+func storeKubernetes(ctx context.Context, resInfo *types.All, serviceNode *storeTypes.ConnNode, storage storage.Storage) error {
+	slog.Info("getting deployments", "service", resInfo.Service.Name)
+	existingDeployments, err := storage.Kubernetes().GetDeployments(ctx, serviceNode.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, deployment := range resInfo.Deployments {
+		if _, err := storeKubernetesDeployment(ctx, deployment, serviceNode, existingDeployments, storage); err != nil {
+			slog.Error("failed to store deployment", "error", err)
+		}
+	}
+
+	slog.Info("getting cron jobs", "service", resInfo.Service.Name)
+	existingCronJobs, err := storage.Kubernetes().GetCronJobs(ctx, serviceNode.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, cronJob := range resInfo.CronJobs {
+		if _, err := storeKubernetesCronJob(ctx, cronJob, serviceNode, existingCronJobs, storage); err != nil {
+			slog.Error("failed to store cron job", "error", err)
+		}
+	}
+
+	slog.Info("getting kubernetes jobs", "service", resInfo.Service.Name)
+	existingJobs, err := storage.Kubernetes().GetJobs(ctx, serviceNode.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range resInfo.KubernetesJobs {
+		if _, err := storeKubernetesJob(ctx, job, serviceNode, existingJobs, storage); err != nil {
+			slog.Error("failed to store kubernetes job", "error", err)
+		}
+	}
+
+	slog.Info("getting kubernetes services", "service", resInfo.Service.Name)
+	existingServices, err := storage.Kubernetes().GetServices(ctx, serviceNode.ID)
+	if err != nil {
+		return err
+	}
+
+	serviceNodes := map[string]*storeTypes.ConnNode{}
+
+	for _, service := range resInfo.KubernetesServices {
+		k8sServiceNode, err := storeKubernetesService(ctx, service, serviceNode, existingServices, storage)
+		if err != nil {
+			slog.Error("failed to store kubernetes service", "error", err)
+			continue
+		}
+
+		if service.Name != nil {
+			serviceNodes[*service.Name] = k8sServiceNode
+		}
+	}
+
+	slog.Info("getting ingresses", "service", resInfo.Service.Name)
+	existingIngresses, err := storage.Kubernetes().GetIngresses(ctx, serviceNode.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, ingress := range resInfo.Ingresses {
+		ingressNode, err := storeKubernetesIngress(ctx, ingress, serviceNode, existingIngresses, storage)
+		if err != nil {
+			slog.Error("failed to store ingress", "error", err)
+			continue
+		}
+
+		for _, backend := range ingress.Backends {
+			k8sServiceNode, ok := serviceNodes[backend]
+			if !ok {
+				slog.Warn("ingress backend service not found", "ingress", ingress.Name, "backend", backend)
+				continue
+			}
+
+			slog.Info("creating ingress-k8sService connection", "from_id", ingressNode.ID, "to_id", k8sServiceNode.ID, "type", storeTypes.ConnRoutesTo)
+			if err := storage.Connection().Create(ctx, ingressNode, k8sServiceNode, storeTypes.ConnRoutesTo); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func storeKubernetesDeployment(ctx context.Context, deployment *types.Deployment, serviceNode *storeTypes.ConnNode, existingDeployments []*storeTypes.Deployment, storage storage.Storage) (*storeTypes.ConnNode, error) {
+	storeDeployment := &storeTypes.Deployment{
+		Name:      deployment.Name,
+		Link:      deployment.Link,
+		Namespace: deployment.Namespace,
+		Replicas:  deployment.Replicas,
+		Images:    deployment.Images,
+		Ports:     deployment.Ports,
+	}
+
+	deploymentNode := &storeTypes.ConnNode{
+		Class: storeTypes.DeploymentClass,
+	}
+
+	for _, existingDeployment := range existingDeployments {
+		if check.ComparePointers(existingDeployment.Name, deployment.Name) && check.ComparePointers(existingDeployment.Namespace, deployment.Namespace) {
+			if existingDeployment.Equal(storeDeployment) {
+				deploymentNode.ID = *existingDeployment.UID
+				return deploymentNode, nil
+			}
+
+			storeDeployment.UID = existingDeployment.UID
+
+			slog.Info("updating deployment", "deployment", deployment.Name)
+			dbDeployment, err := storage.Kubernetes().UpdateDeployment(ctx, storeDeployment)
+			if err != nil {
+				return nil, err
+			}
+
+			deploymentNode.ID = *dbDeployment.UID
+			return deploymentNode, nil
+		}
+	}
+
+	slog.Info("creating deployment", "deployment", deployment.Name)
+	id, err := storage.Kubernetes().CreateDeployment(ctx, storeDeployment)
+	if err != nil {
+		return nil, err
+	}
+
+	deploymentNode.ID = id
+
+	slog.Info("creating deployment-svc connection", "from_id", deploymentNode.ID, "to_id", serviceNode.ID, "type", storeTypes.ConnIN)
+	if err := storage.Connection().Create(ctx, deploymentNode, serviceNode, storeTypes.ConnIN); err != nil {
+		return nil, err
+	}
+
+	return deploymentNode, nil
+}
+
+func storeKubernetesCronJob(ctx context.Context, cronJob *types.CronJob, serviceNode *storeTypes.ConnNode, existingCronJobs []*storeTypes.CronJob, storage storage.Storage) (*storeTypes.ConnNode, error) {
+	storeCronJob := &storeTypes.CronJob{
+		Name:      cronJob.Name,
+		Link:      cronJob.Link,
+		Namespace: cronJob.Namespace,
+		Schedule:  cronJob.Schedule,
+		Images:    cronJob.Images,
+	}
+
+	cronJobNode := &storeTypes.ConnNode{
+		Class: storeTypes.CronJobClass,
+	}
+
+	for _, existingCronJob := range existingCronJobs {
+		if check.ComparePointers(existingCronJob.Name, cronJob.Name) && check.ComparePointers(existingCronJob.Namespace, cronJob.Namespace) {
+			if existingCronJob.Equal(storeCronJob) {
+				cronJobNode.ID = *existingCronJob.UID
+				return cronJobNode, nil
+			}
+
+			storeCronJob.UID = existingCronJob.UID
+
+			slog.Info("updating cron job", "cron_job", cronJob.Name)
+			dbCronJob, err := storage.Kubernetes().UpdateCronJob(ctx, storeCronJob)
+			if err != nil {
+				return nil, err
+			}
+
+			cronJobNode.ID = *dbCronJob.UID
+			return cronJobNode, nil
+		}
+	}
+
+	slog.Info("creating cron job", "cron_job", cronJob.Name)
+	id, err := storage.Kubernetes().CreateCronJob(ctx, storeCronJob)
+	if err != nil {
+		return nil, err
+	}
+
+	cronJobNode.ID = id
+
+	slog.Info("creating cronjob-svc connection", "from_id", cronJobNode.ID, "to_id", serviceNode.ID, "type", storeTypes.ConnIN)
+	if err := storage.Connection().Create(ctx, cronJobNode, serviceNode, storeTypes.ConnIN); err != nil {
+		return nil, err
+	}
+
+	return cronJobNode, nil
+}
+
+func storeKubernetesJob(ctx context.Context, job *types.KubernetesJob, serviceNode *storeTypes.ConnNode, existingJobs []*storeTypes.KubernetesJob, storage storage.Storage) (*storeTypes.ConnNode, error) {
+	storeJob := &storeTypes.KubernetesJob{
+		Name:      job.Name,
+		Link:      job.Link,
+		Namespace: job.Namespace,
+		Images:    job.Images,
+	}
+
+	jobNode := &storeTypes.ConnNode{
+		Class: storeTypes.KubernetesJobClass,
+	}
+
+	for _, existingJob := range existingJobs {
+		if check.ComparePointers(existingJob.Name, job.Name) && check.ComparePointers(existingJob.Namespace, job.Namespace) {
+			if existingJob.Equal(storeJob) {
+				jobNode.ID = *existingJob.UID
+				return jobNode, nil
+			}
+
+			storeJob.UID = existingJob.UID
+
+			slog.Info("updating kubernetes job", "kubernetes_job", job.Name)
+			dbJob, err := storage.Kubernetes().UpdateJob(ctx, storeJob)
+			if err != nil {
+				return nil, err
+			}
+
+			jobNode.ID = *dbJob.UID
+			return jobNode, nil
+		}
+	}
+
+	slog.Info("creating kubernetes job", "kubernetes_job", job.Name)
+	id, err := storage.Kubernetes().CreateJob(ctx, storeJob)
+	if err != nil {
+		return nil, err
+	}
+
+	jobNode.ID = id
+
+	slog.Info("creating job-svc connection", "from_id", jobNode.ID, "to_id", serviceNode.ID, "type", storeTypes.ConnIN)
+	if err := storage.Connection().Create(ctx, jobNode, serviceNode, storeTypes.ConnIN); err != nil {
+		return nil, err
+	}
+
+	return jobNode, nil
+}
+
+func storeKubernetesService(ctx context.Context, k8sService *types.KubernetesService, serviceNode *storeTypes.ConnNode, existingServices []*storeTypes.KubernetesService, storage storage.Storage) (*storeTypes.ConnNode, error) {
+	storeK8sService := &storeTypes.KubernetesService{
+		Name:      k8sService.Name,
+		Link:      k8sService.Link,
+		Namespace: k8sService.Namespace,
+		Type:      k8sService.Type,
+		Ports:     k8sService.Ports,
+	}
+
+	k8sServiceNode := &storeTypes.ConnNode{
+		Class: storeTypes.KubernetesServiceClass,
+	}
+
+	for _, existingK8sService := range existingServices {
+		if check.ComparePointers(existingK8sService.Name, k8sService.Name) && check.ComparePointers(existingK8sService.Namespace, k8sService.Namespace) {
+			if existingK8sService.Equal(storeK8sService) {
+				k8sServiceNode.ID = *existingK8sService.UID
+				return k8sServiceNode, nil
+			}
+
+			storeK8sService.UID = existingK8sService.UID
+
+			slog.Info("updating kubernetes service", "kubernetes_service", k8sService.Name)
+			dbK8sService, err := storage.Kubernetes().UpdateService(ctx, storeK8sService)
+			if err != nil {
+				return nil, err
+			}
+
+			k8sServiceNode.ID = *dbK8sService.UID
+			return k8sServiceNode, nil
+		}
+	}
+
+	slog.Info("creating kubernetes service", "kubernetes_service", k8sService.Name)
+	id, err := storage.Kubernetes().CreateService(ctx, storeK8sService)
+	if err != nil {
+		return nil, err
+	}
+
+	k8sServiceNode.ID = id
+
+	slog.Info("creating k8sService-svc connection", "from_id", k8sServiceNode.ID, "to_id", serviceNode.ID, "type", storeTypes.ConnIN)
+	if err := storage.Connection().Create(ctx, k8sServiceNode, serviceNode, storeTypes.ConnIN); err != nil {
+		return nil, err
+	}
+
+	return k8sServiceNode, nil
+}
+
+func storeKubernetesIngress(ctx context.Context, ingress *types.Ingress, serviceNode *storeTypes.ConnNode, existingIngresses []*storeTypes.Ingress, storage storage.Storage) (*storeTypes.ConnNode, error) {
+	storeIngress := &storeTypes.Ingress{
+		Name:      ingress.Name,
+		Link:      ingress.Link,
+		Namespace: ingress.Namespace,
+		Hosts:     ingress.Hosts,
+		Backends:  ingress.Backends,
+	}
+
+	ingressNode := &storeTypes.ConnNode{
+		Class: storeTypes.IngressClass,
+	}
+
+	for _, existingIngress := range existingIngresses {
+		if check.ComparePointers(existingIngress.Name, ingress.Name) && check.ComparePointers(existingIngress.Namespace, ingress.Namespace) {
+			if existingIngress.Equal(storeIngress) {
+				ingressNode.ID = *existingIngress.UID
+				return ingressNode, nil
+			}
+
+			storeIngress.UID = existingIngress.UID
+
+			slog.Info("updating ingress", "ingress", ingress.Name)
+			dbIngress, err := storage.Kubernetes().UpdateIngress(ctx, storeIngress)
+			if err != nil {
+				return nil, err
+			}
+
+			ingressNode.ID = *dbIngress.UID
+			return ingressNode, nil
+		}
+	}
+
+	slog.Info("creating ingress", "ingress", ingress.Name)
+	id, err := storage.Kubernetes().CreateIngress(ctx, storeIngress)
+	if err != nil {
+		return nil, err
+	}
+
+	ingressNode.ID = id
+
+	slog.Info("creating ingress-svc connection", "from_id", ingressNode.ID, "to_id", serviceNode.ID, "type", storeTypes.ConnIN)
+	if err := storage.Connection().Create(ctx, ingressNode, serviceNode, storeTypes.ConnIN); err != nil {
+		return nil, err
+	}
+
+	return ingressNode, nil
+}
@@ -0,0 +1,214 @@
+package storefuncs
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"vislab/libs/check"
+	"vislab/storage"
+	storeTypes "vislab/storage/neo4j/types"
+	"vislab/types"
+)
+
+func storePipeline(ctx context.Context, pipeline *types.Pipeline, serviceNode *storeTypes.ConnNode, storage storage.Storage) error {
+	slog.Info("getting pipelines", "service_id", serviceNode.ID)
+	existingPipelines, err := storage.Pipeline().Get(ctx, serviceNode.ID)
+	if err != nil {
+		return err
+	}
+
+	pipelineNode, err := storePipelineNode(ctx, pipeline, serviceNode, existingPipelines, storage)
+	if err != nil {
+		return err
+	}
+
+	templateNodes := map[string]*storeTypes.ConnNode{}
+
+	for _, template := range pipeline.Templates {
+		templateNode, err := storePipelineTemplate(ctx, template, storage)
+		if err != nil {
+			slog.Error("failed to store pipeline template", "error", err)
+			continue
+		}
+
+		templateNodes[*template.Name] = templateNode
+
+		slog.Info("creating pipeline-template connection", "from_id", pipelineNode.ID, "to_id", templateNode.ID, "type", storeTypes.ConnIncludes)
+		if err := storage.Connection().Create(ctx, pipelineNode, templateNode, storeTypes.ConnIncludes); err != nil {
+			return err
+		}
+	}
+
+	slog.Info("getting pipeline jobs", "pipeline", pipeline.Name)
+	existingJobs, err := storage.Pipeline().GetJobs(ctx, pipelineNode.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range pipeline.Jobs {
+		jobNode, err := storePipelineJob(ctx, job, pipelineNode, existingJobs, storage)
+		if err != nil {
+			slog.Error("failed to store pipeline job", "error", err)
+			continue
+		}
+
+		if job.Template == nil {
+			continue
+		}
+
+		templateNode, ok := templateNodes[*job.Template]
+		if !ok {
+			continue
+		}
+
+		slog.Info("creating job-template connection", "from_id", jobNode.ID, "to_id", templateNode.ID, "type", storeTypes.ConnExtends)
+		if err := storage.Connection().Create(ctx, jobNode, templateNode, storeTypes.ConnExtends); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func storePipelineNode(ctx context.Context, pipeline *types.Pipeline, serviceNode *storeTypes.ConnNode, existingPipelines []*storeTypes.Pipeline, storage storage.Storage) (*storeTypes.ConnNode, error) {
+	storePipeline := &storeTypes.Pipeline{
+		Name:   pipeline.Name,
+		Link:   pipeline.Link,
+		Stages: pipeline.Stages,
+	}
+
+	pipelineNode := &storeTypes.ConnNode{
+		Class: storeTypes.PipelineClass,
+	}
+
+	for _, existingPipeline := range existingPipelines {
+		if check.ComparePointers(existingPipeline.Name, pipeline.Name) {
+			if existingPipeline.Equal(storePipeline) {
+				pipelineNode.ID = *existingPipeline.UID
+				return pipelineNode, nil
+			}
+
+			storePipeline.UID = existingPipeline.UID
+
+			slog.Info("updating pipeline", "pipeline", pipeline.Name)
+			dbPipeline, err := storage.Pipeline().Update(ctx, storePipeline)
+			if err != nil {
+				return nil, err
+			}
+
+			pipelineNode.ID = *dbPipeline.UID
+			return pipelineNode, nil
+		}
+	}
+
+	slog.Info("creating pipeline", "pipeline", pipeline.Name)
+	id, err := storage.Pipeline().Create(ctx, storePipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	pipelineNode.ID = id
+
+	slog.Info("creating pipeline-svc connection", "from_id", pipelineNode.ID, "to_id", serviceNode.ID, "type", storeTypes.ConnIN)
+	if err := storage.Connection().Create(ctx, pipelineNode, serviceNode, storeTypes.ConnIN); err != nil {
+		return nil, err
+	}
+
+	return pipelineNode, nil
+}
+
+func storePipelineJob(ctx context.Context, job *types.PipelineJob, pipelineNode *storeTypes.ConnNode, existingJobs []*storeTypes.PipelineJob, storage storage.Storage) (*storeTypes.ConnNode, error) {
+	storeJob := &storeTypes.PipelineJob{
+		Name:           job.Name,
+		Link:           job.Link,
+		Stage:          job.Stage,
+		Image:          job.Image,
+		Environment:    job.Environment,
+		Deploys:        job.Deploys,
+		Migrates:       job.Migrates,
+		PublishesImage: job.PublishesImage,
+	}
+
+	jobNode := &storeTypes.ConnNode{
+		Class: storeTypes.PipelineJobClass,
+	}
+
+	for _, existingJob := range existingJobs {
+		if check.ComparePointers(existingJob.Name, job.Name) {
+			if existingJob.Equal(storeJob) {
+				jobNode.ID = *existingJob.UID
+				return jobNode, nil
+			}
+
+			storeJob.UID = existingJob.UID
+
+			slog.Info("updating pipeline job", "job", job.Name)
+			dbJob, err := storage.Pipeline().UpdateJob(ctx, storeJob)
+			if err != nil {
+				return nil, err
+			}
+
+			jobNode.ID = *dbJob.UID
+			return jobNode, nil
+		}
+	}
+
+	slog.Info("creating pipeline job", "job", job.Name)
+	id, err := storage.Pipeline().CreateJob(ctx, storeJob)
+	if err != nil {
+		return nil, err
+	}
+
+	jobNode.ID = id
+
+	slog.Info("creating job-pipeline connection", "from_id", jobNode.ID, "to_id", pipelineNode.ID, "type", storeTypes.ConnIN)
+	if err := storage.Connection().Create(ctx, jobNode, pipelineNode, storeTypes.ConnIN); err != nil {
+		return nil, err
+	}
+
+	return jobNode, nil
+}
+
+// storePipelineTemplate keeps one node per template, projects including the
+// same template share it.
+func storePipelineTemplate(ctx context.Context, template *types.PipelineTemplate, storage storage.Storage) (*storeTypes.ConnNode, error) {
+	storeTemplate := &storeTypes.PipelineTemplate{
+		Name: template.Name,
+		Link: template.Link,
+	}
+
+	templateNode := &storeTypes.ConnNode{
+		Class: storeTypes.PipelineTemplateClass,
+	}
+
+	dbTemplate, err := storage.Pipeline().GetTemplate(ctx, *template.Name)
+	if err == nil {
+		if dbTemplate.Equal(storeTemplate) || template.Link == nil {
+			templateNode.ID = *dbTemplate.UID
+			return templateNode, nil
+		}
+
+		storeTemplate.UID = dbTemplate.UID
+
+		slog.Info("updating pipeline template", "template", template.Name)
+		dbTemplate, err := storage.Pipeline().UpdateTemplate(ctx, storeTemplate)
+		if err != nil {
+			return nil, err
+		}
+
+		templateNode.ID = *dbTemplate.UID
+		return templateNode, nil
+	}
+	if !strings.Contains(err.Error(), "not found") {
+		return nil, err
+	}
+
+	slog.Info("creating pipeline template", "template", template.Name)
+	id, err := storage.Pipeline().CreateTemplate(ctx, storeTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	templateNode.ID = id
+	return templateNode, nil
+}
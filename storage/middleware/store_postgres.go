@@ -7,17 +7,23 @@ import (
 	"strings"
 	"vislab/libs/check"
 	"vislab/libs/ptr"
+	"vislab/resolver"
 	"vislab/storage"
 	storeTypes "vislab/storage/neo4j/types"
 	"vislab/types"
 )
 
-func storePostgres(ctx context.Context, postgres *types.Postgresql, serviceNode *storeTypes.ConnNode, storage storage.Storage) error {
-	postgresNode, err := storePostgresNode(ctx, postgres, storage)
+func storePostgres(ctx context.Context, postgres *types.Postgresql, serviceNode *storeTypes.ConnNode, storage storage.Storage, hosts *resolver.HostRegistry, report *StoreReport) error {
+	postgresNode, err := storePostgresNode(ctx, postgres, storage, hosts)
 	if err != nil {
 		return err
 	}
 
+	roles, err := storePostgresRoles(ctx, postgres, postgresNode, serviceNode, storage)
+	if err != nil {
+		slog.Error("failed to store postgres roles", "postgres", postgres.Host, "error", err)
+	}
+
 	slog.Info("getting postgres databases", "postgres", postgres.Host)
 	existingDatabases, err := storage.Postgres().GetDBs(ctx, postgresNode.ID)
 	if err != nil {
@@ -37,6 +43,11 @@ func storePostgres(ctx context.Context, postgres *types.Postgresql, serviceNode
 		databaseNode, err := storePostgresDB(ctx, database, postgresNode, existingDatabases, storage)
 		if err != nil {
 			slog.Error("failed to store postgres database", "error", err)
+			continue
+		}
+
+		if err := storePostgresExtensions(ctx, database, databaseNode, storage); err != nil {
+			slog.Error("failed to store postgres extensions", "database", database.Name, "error", err)
 		}
 
 		slog.Info("getting postgres schemes", "database", database.Name, "postgres", postgres.Host)
@@ -45,7 +56,10 @@ func storePostgres(ctx context.Context, postgres *types.Postgresql, serviceNode
 			return err
 		}
 
-		if len(database.Schemes) == 0 {
+		schemeNodes := map[string]*storeTypes.ConnNode{}
+		tableNodes := map[string]*storeTypes.ConnNode{}
+
+		if len(database.Schemes) == 0 && len(database.Usages) == 0 {
 			slog.Info("creating dummy postgres scheme", "database", database.Name, "postgres", postgres.Host)
 			if err := storePublicScheme(ctx, databaseNode, storage, serviceNode, existingSchemes); err != nil {
 				return err
@@ -61,13 +75,20 @@ func storePostgres(ctx context.Context, postgres *types.Postgresql, serviceNode
 				continue
 			}
 
+			schemeNodes[*scheme.Name] = schemeNode
+
+			schemeObjects, err := storePostgresSchemeObjects(ctx, scheme, schemeNode, storage)
+			if err != nil {
+				slog.Error("failed to store postgres scheme objects", "scheme", scheme.Name, "error", err)
+			}
+
 			slog.Info("getting postgres tables", "scheme", scheme.Name, "database", database.Name, "postgres", postgres.Host)
 			existingTables, err := storage.Postgres().GetTables(ctx, schemeNode.ID)
 			if err != nil {
 				return err
 			}
 
-			if len(scheme.Tables) == 0 {
+			if len(scheme.Tables) == 0 && len(scheme.Views) == 0 {
 				slog.Info("creating dummy postgres table", "scheme", scheme.Name, "database", database.Name, "postgres", postgres.Host)
 				if err := storeDummyPostgresTable(ctx, schemeNode, storage, serviceNode, existingTables); err != nil {
 					return err
@@ -83,21 +104,51 @@ func storePostgres(ctx context.Context, postgres *types.Postgresql, serviceNode
 					continue
 				}
 
+				tableNodes[postgresTableKey(scheme.Name, table.Name)] = tableNode
+
+				if err := storePostgresTableObjects(ctx, table, tableNode, schemeObjects, storage); err != nil {
+					slog.Error("failed to store postgres table objects", "table", table.Name, "error", err)
+				}
+
+				if err := storePostgresTableAccess(ctx, table, tableNode, roles, storage); err != nil {
+					slog.Error("failed to store postgres table access", "table", table.Name, "error", err)
+				}
+
 				slog.Info("creating svc-table connection", "from_id", serviceNode.ID, "to_id", tableNode.ID, "type", storeTypes.ConnUses)
 
 				if err := storage.Connection().Create(ctx, serviceNode, tableNode, storeTypes.ConnUses); err != nil {
 					return err
 				}
+
+				if table.Migrated != nil && *table.Migrated {
+					slog.Info("creating svc-table connection", "from_id", serviceNode.ID, "to_id", tableNode.ID, "type", storeTypes.ConnMigrates)
+
+					if err := storage.Connection().Create(ctx, serviceNode, tableNode, storeTypes.ConnMigrates); err != nil {
+						return err
+					}
+				}
 			}
 		}
+
+		if err := storePostgresReferences(ctx, database, databaseNode, tableNodes, storage); err != nil {
+			slog.Error("failed to store postgres references", "database", database.Name, "error", err)
+		}
+
+		if err := storePostgresViews(ctx, database, databaseNode, schemeNodes, tableNodes, serviceNode, storage); err != nil {
+			slog.Error("failed to store postgres views", "database", database.Name, "error", err)
+		}
+
+		if err := storePostgresTableUsages(ctx, database, databaseNode, tableNodes, serviceNode, storage, report); err != nil {
+			slog.Error("failed to store postgres table usages", "database", database.Name, "error", err)
+		}
 	}
 
 	return nil
 }
 
-func storePostgresNode(ctx context.Context, postgres *types.Postgresql, storage storage.Storage) (*storeTypes.ConnNode, error) {
+func storePostgresNode(ctx context.Context, postgres *types.Postgresql, storage storage.Storage, hosts *resolver.HostRegistry) (*storeTypes.ConnNode, error) {
 	storePostgresql := &storeTypes.Postgresql{
-		Host: postgres.Host,
+		Host: resolveHost(postgres.Host, hosts),
 		Port: postgres.Port,
 		User: postgres.User,
 	}
@@ -226,7 +277,9 @@ func storePostgresScheme(ctx context.Context, scheme *types.PostgresqlScheme, db
 
 func storePostgresTable(ctx context.Context, table *types.PostgresqlTable, schemeNode *storeTypes.ConnNode, existingTables []*storeTypes.PostgresqlTable, storage storage.Storage) (*storeTypes.ConnNode, error) {
 	storeTable := &storeTypes.PostgresqlTable{
-		Name: table.Name,
+		Name:  table.Name,
+		Type:  table.Type,
+		Owner: table.Owner,
 	}
 
 	tableNode := &storeTypes.ConnNode{
@@ -313,8 +366,8 @@ func storeDummyPostgresTable(ctx context.Context, schemeNode *storeTypes.ConnNod
 		return err
 	}
 
-	slog.Info("creating svc-table connection", "from_id", serviceNode.ID, "to_id", tableNode.ID, "type", "dummy")
-	if err := storage.Connection().Create(ctx, serviceNode, tableNode, "dummy"); err != nil {
+	slog.Info("creating svc-table connection", "from_id", serviceNode.ID, "to_id", tableNode.ID, "type", storeTypes.ConnDummy)
+	if err := storage.Connection().Create(ctx, serviceNode, tableNode, storeTypes.ConnDummy); err != nil {
 		return err
 	}
 
@@ -0,0 +1,99 @@
+package storefuncs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"vislab/libs/check"
+	"vislab/storage"
+	storeTypes "vislab/storage/neo4j/types"
+	"vislab/types"
+)
+
+func storePostgresReferences(ctx context.Context, database *types.PostgresqlDB, databaseNode *storeTypes.ConnNode, tableNodes map[string]*storeTypes.ConnNode, storage storage.Storage) error {
+	for _, scheme := range database.Schemes {
+		for _, table := range scheme.Tables {
+			if len(table.ForeignKeys) == 0 {
+				continue
+			}
+
+			fromNode, ok := tableNodes[postgresTableKey(scheme.Name, table.Name)]
+			if !ok {
+				continue
+			}
+
+			for _, fk := range table.ForeignKeys {
+				toNode, err := existingTableNode(ctx, fk.RefScheme, fk.RefTable, databaseNode, tableNodes, storage)
+				if err != nil {
+					slog.Error("failed to get referenced postgres table", "table", table.Name, "ref_table", fk.RefTable, "error", err)
+					continue
+				}
+				if toNode == nil {
+					slog.Warn("referenced postgres table not found", "table", table.Name, "ref_scheme", fk.RefScheme, "ref_table", fk.RefTable)
+					continue
+				}
+
+				reference := &storeTypes.PostgresqlReference{
+					Name:       fk.Name,
+					Columns:    nonNilStrings(fk.Columns),
+					RefColumns: nonNilStrings(fk.RefColumns),
+				}
+
+				slog.Info("creating table-table connection", "from_id", fromNode.ID, "to_id", toNode.ID, "type", storeTypes.ConnReferences)
+				if err := storage.Postgres().CreateReference(ctx, fromNode.ID, toNode.ID, reference); err != nil {
+					return fmt.Errorf("failed to create table-table connection: %w", err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// existingTableNode returns the table migrated in this run or stored by
+// another service, nil without an error when the table is not known. Tables
+// are never created from a reference, a typo would leave a ghost table.
+func existingTableNode(ctx context.Context, scheme, table *string, databaseNode *storeTypes.ConnNode, tableNodes map[string]*storeTypes.ConnNode, storage storage.Storage) (*storeTypes.ConnNode, error) {
+	key := postgresTableKey(scheme, table)
+	if tableNode, ok := tableNodes[key]; ok {
+		return tableNode, nil
+	}
+
+	existingSchemes, err := storage.Postgres().GetSchemes(ctx, databaseNode.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, existingScheme := range existingSchemes {
+		if !check.ComparePointers(scheme, existingScheme.Name) {
+			continue
+		}
+
+		existingTables, err := storage.Postgres().GetTables(ctx, *existingScheme.UID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, existingTable := range existingTables {
+			if check.ComparePointers(table, existingTable.Name) {
+				tableNode := &storeTypes.ConnNode{
+					ID:    *existingTable.UID,
+					Class: storeTypes.PostgresTableClass,
+				}
+
+				tableNodes[key] = tableNode
+				return tableNode, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func postgresTableKey(scheme, table *string) string {
+	if scheme == nil || table == nil {
+		return ""
+	}
+
+	return *scheme + "." + *table
+}
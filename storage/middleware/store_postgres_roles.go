@@ -0,0 +1,193 @@
+package storefuncs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"vislab/libs/check"
+	"vislab/libs/ptr"
+	"vislab/storage"
+	storeTypes "vislab/storage/neo4j/types"
+	"vislab/types"
+)
+
+var (
+	readPrivileges  = []string{"SELECT"}
+	writePrivileges = []string{"INSERT", "UPDATE", "DELETE", "TRUNCATE"}
+)
+
+type postgresRoles struct {
+	postgresNode *storeTypes.ConnNode
+	nodes        map[string]*storeTypes.ConnNode
+}
+
+func storePostgresRoles(ctx context.Context, postgres *types.Postgresql, postgresNode, serviceNode *storeTypes.ConnNode, storage storage.Storage) (*postgresRoles, error) {
+	roles := &postgresRoles{
+		postgresNode: postgresNode,
+		nodes:        map[string]*storeTypes.ConnNode{},
+	}
+
+	if len(postgres.Roles) > 0 {
+		slog.Info("getting postgres roles", "postgres", postgres.Host)
+		existingRoles, err := storage.Postgres().GetRoles(ctx, postgresNode.ID)
+		if err != nil {
+			return roles, err
+		}
+
+		for _, role := range postgres.Roles {
+			roleNode, err := storePostgresRole(ctx, role, postgresNode, existingRoles, storage)
+			if err != nil {
+				slog.Error("failed to store postgres role", "role", role.Name, "error", err)
+				continue
+			}
+
+			roles.nodes[*role.Name] = roleNode
+		}
+	}
+
+	if postgres.User == nil {
+		return roles, nil
+	}
+
+	userNode, err := postgresRoleNode(ctx, *postgres.User, roles, storage)
+	if err != nil {
+		return roles, err
+	}
+
+	slog.Info("creating svc-role connection", "from_id", serviceNode.ID, "to_id", userNode.ID, "type", storeTypes.ConnConnectsAs)
+	if err := storage.Connection().Create(ctx, serviceNode, userNode, storeTypes.ConnConnectsAs); err != nil {
+		return roles, fmt.Errorf("failed to create svc-role connection: %w", err)
+	}
+
+	return roles, nil
+}
+
+func storePostgresTableAccess(ctx context.Context, table *types.PostgresqlTable, tableNode *storeTypes.ConnNode, roles *postgresRoles, storage storage.Storage) error {
+	if table.Owner != nil {
+		ownerNode, err := postgresRoleNode(ctx, *table.Owner, roles, storage)
+		if err != nil {
+			return err
+		}
+
+		slog.Info("creating role-table connection", "from_id", ownerNode.ID, "to_id", tableNode.ID, "type", storeTypes.ConnOwns)
+		if err := storage.Connection().Create(ctx, ownerNode, tableNode, storeTypes.ConnOwns); err != nil {
+			return fmt.Errorf("failed to create role-table connection: %w", err)
+		}
+	}
+
+	for _, grant := range table.Grants {
+		if grant.Role == nil {
+			continue
+		}
+
+		roleNode, err := postgresRoleNode(ctx, *grant.Role, roles, storage)
+		if err != nil {
+			return err
+		}
+
+		for _, connType := range grantConnTypes(grant.Privileges) {
+			slog.Info("creating role-table connection", "from_id", roleNode.ID, "to_id", tableNode.ID, "type", connType)
+			if err := storage.Connection().Create(ctx, roleNode, tableNode, connType); err != nil {
+				return fmt.Errorf("failed to create role-table connection: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func grantConnTypes(privileges []string) []storeTypes.ConnType {
+	connTypes := []storeTypes.ConnType{}
+
+	all := slices.Contains(privileges, "ALL")
+
+	if all || slices.ContainsFunc(privileges, func(p string) bool { return slices.Contains(readPrivileges, p) }) {
+		connTypes = append(connTypes, storeTypes.ConnCanRead)
+	}
+	if all || slices.ContainsFunc(privileges, func(p string) bool { return slices.Contains(writePrivileges, p) }) {
+		connTypes = append(connTypes, storeTypes.ConnCanWrite)
+	}
+
+	return connTypes
+}
+
+// postgresRoleNode returns the node of a role, roles that are not created by
+// migrations (e.g. the service user) are created on demand.
+func postgresRoleNode(ctx context.Context, name string, roles *postgresRoles, storage storage.Storage) (*storeTypes.ConnNode, error) {
+	if roleNode, ok := roles.nodes[name]; ok {
+		return roleNode, nil
+	}
+
+	existingRoles, err := storage.Postgres().GetRoles(ctx, roles.postgresNode.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, existingRole := range existingRoles {
+		if check.ComparePointers(existingRole.Name, &name) {
+			roleNode := &storeTypes.ConnNode{
+				Class: storeTypes.PostgresRoleClass,
+				ID:    *existingRole.UID,
+			}
+
+			roles.nodes[name] = roleNode
+			return roleNode, nil
+		}
+	}
+
+	roleNode, err := storePostgresRole(ctx, &types.PostgresqlRole{Name: ptr.Ptr(name)}, roles.postgresNode, existingRoles, storage)
+	if err != nil {
+		return nil, err
+	}
+
+	roles.nodes[name] = roleNode
+	return roleNode, nil
+}
+
+func storePostgresRole(ctx context.Context, role *types.PostgresqlRole, postgresNode *storeTypes.ConnNode, existingRoles []*storeTypes.PostgresqlRole, storage storage.Storage) (*storeTypes.ConnNode, error) {
+	storeRole := &storeTypes.PostgresqlRole{
+		Name:     role.Name,
+		Login:    role.Login,
+		MemberOf: nonNilStrings(role.MemberOf),
+	}
+
+	roleNode := &storeTypes.ConnNode{
+		Class: storeTypes.PostgresRoleClass,
+	}
+
+	for _, existingRole := range existingRoles {
+		if check.ComparePointers(role.Name, existingRole.Name) {
+			if existingRole.Equal(storeRole) {
+				roleNode.ID = *existingRole.UID
+				return roleNode, nil
+			}
+
+			storeRole.UID = existingRole.UID
+
+			slog.Info("updating postgres role", "role", role.Name)
+			dbRole, err := storage.Postgres().UpdateRole(ctx, storeRole)
+			if err != nil {
+				return nil, err
+			}
+
+			roleNode.ID = *dbRole.UID
+			return roleNode, nil
+		}
+	}
+
+	slog.Info("creating postgres role", "role", role.Name)
+	id, err := storage.Postgres().CreateRole(ctx, storeRole)
+	if err != nil {
+		return nil, err
+	}
+
+	roleNode.ID = id
+
+	slog.Info("creating role-postgres connection", "from_id", roleNode.ID, "to_id", postgresNode.ID, "type", storeTypes.ConnIN)
+	if err := storage.Connection().Create(ctx, roleNode, postgresNode, storeTypes.ConnIN); err != nil {
+		return nil, fmt.Errorf("failed to create role-postgres connection: %w", err)
+	}
+
+	return roleNode, nil
+}
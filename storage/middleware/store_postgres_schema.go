@@ -0,0 +1,489 @@
+package storefuncs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"vislab/libs/check"
+	"vislab/storage"
+	storeTypes "vislab/storage/neo4j/types"
+	"vislab/types"
+)
+
+func storePostgresExtensions(ctx context.Context, database *types.PostgresqlDB, dbNode *storeTypes.ConnNode, storage storage.Storage) error {
+	if len(database.Extensions) == 0 {
+		return nil
+	}
+
+	slog.Info("getting postgres extensions", "database", database.Name)
+	existingExtensions, err := storage.Postgres().GetExtensions(ctx, dbNode.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, extension := range database.Extensions {
+		if _, err := storePostgresExtension(ctx, extension, dbNode, existingExtensions, storage); err != nil {
+			slog.Error("failed to store postgres extension", "extension", extension.Name, "error", err)
+		}
+	}
+
+	return nil
+}
+
+type postgresSchemeObjects struct {
+	functions map[string]*storeTypes.ConnNode
+	enums     map[string]*storeTypes.ConnNode
+}
+
+func storePostgresSchemeObjects(ctx context.Context, scheme *types.PostgresqlScheme, schemeNode *storeTypes.ConnNode, storage storage.Storage) (*postgresSchemeObjects, error) {
+	objects := &postgresSchemeObjects{
+		functions: map[string]*storeTypes.ConnNode{},
+		enums:     map[string]*storeTypes.ConnNode{},
+	}
+
+	if len(scheme.Functions) > 0 {
+		slog.Info("getting postgres functions", "scheme", scheme.Name)
+		existingFunctions, err := storage.Postgres().GetFunctions(ctx, schemeNode.ID)
+		if err != nil {
+			return objects, err
+		}
+
+		for _, function := range scheme.Functions {
+			functionNode, err := storePostgresFunction(ctx, function, schemeNode, existingFunctions, storage)
+			if err != nil {
+				slog.Error("failed to store postgres function", "function", function.Name, "error", err)
+				continue
+			}
+
+			if _, ok := objects.functions[*function.Name]; !ok {
+				objects.functions[*function.Name] = functionNode
+			}
+		}
+	}
+
+	if len(scheme.Enums) > 0 {
+		slog.Info("getting postgres enums", "scheme", scheme.Name)
+		existingEnums, err := storage.Postgres().GetEnums(ctx, schemeNode.ID)
+		if err != nil {
+			return objects, err
+		}
+
+		for _, enum := range scheme.Enums {
+			enumNode, err := storePostgresEnum(ctx, enum, schemeNode, existingEnums, storage)
+			if err != nil {
+				slog.Error("failed to store postgres enum", "enum", enum.Name, "error", err)
+				continue
+			}
+
+			objects.enums[*enum.Name] = enumNode
+		}
+	}
+
+	if len(scheme.Sequences) > 0 {
+		slog.Info("getting postgres sequences", "scheme", scheme.Name)
+		existingSequences, err := storage.Postgres().GetSequences(ctx, schemeNode.ID)
+		if err != nil {
+			return objects, err
+		}
+
+		for _, sequence := range scheme.Sequences {
+			if _, err := storePostgresSequence(ctx, sequence, schemeNode, existingSequences, storage); err != nil {
+				slog.Error("failed to store postgres sequence", "sequence", sequence.Name, "error", err)
+			}
+		}
+	}
+
+	return objects, nil
+}
+
+func storePostgresTableObjects(ctx context.Context, table *types.PostgresqlTable, tableNode *storeTypes.ConnNode, schemeObjects *postgresSchemeObjects, storage storage.Storage) error {
+	if len(table.Columns) > 0 {
+		slog.Info("getting postgres columns", "table", table.Name)
+		existingColumns, err := storage.Postgres().GetColumns(ctx, tableNode.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, column := range table.Columns {
+			columnNode, err := storePostgresColumn(ctx, column, tableNode, existingColumns, storage)
+			if err != nil {
+				slog.Error("failed to store postgres column", "column", column.Name, "error", err)
+				continue
+			}
+
+			if column.Type == nil || schemeObjects == nil {
+				continue
+			}
+
+			if enumNode, ok := schemeObjects.enums[*column.Type]; ok {
+				slog.Info("creating column-enum connection", "from_id", columnNode.ID, "to_id", enumNode.ID, "type", storeTypes.ConnOfType)
+				if err := storage.Connection().Create(ctx, columnNode, enumNode, storeTypes.ConnOfType); err != nil {
+					return fmt.Errorf("failed to create column-enum connection: %w", err)
+				}
+			}
+		}
+	}
+
+	if len(table.Indexes) > 0 {
+		slog.Info("getting postgres indexes", "table", table.Name)
+		existingIndexes, err := storage.Postgres().GetIndexes(ctx, tableNode.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, index := range table.Indexes {
+			if _, err := storePostgresIndex(ctx, index, tableNode, existingIndexes, storage); err != nil {
+				slog.Error("failed to store postgres index", "index", index.Name, "error", err)
+			}
+		}
+	}
+
+	if len(table.Triggers) > 0 {
+		slog.Info("getting postgres triggers", "table", table.Name)
+		existingTriggers, err := storage.Postgres().GetTriggers(ctx, tableNode.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, trigger := range table.Triggers {
+			triggerNode, err := storePostgresTrigger(ctx, trigger, tableNode, existingTriggers, storage)
+			if err != nil {
+				slog.Error("failed to store postgres trigger", "trigger", trigger.Name, "error", err)
+				continue
+			}
+
+			if trigger.Function == nil || schemeObjects == nil {
+				continue
+			}
+
+			if functionNode, ok := schemeObjects.functions[*trigger.Function]; ok {
+				slog.Info("creating trigger-function connection", "from_id", triggerNode.ID, "to_id", functionNode.ID, "type", storeTypes.ConnExecutes)
+				if err := storage.Connection().Create(ctx, triggerNode, functionNode, storeTypes.ConnExecutes); err != nil {
+					return fmt.Errorf("failed to create trigger-function connection: %w", err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func storePostgresColumn(ctx context.Context, column *types.PostgresqlColumn, tableNode *storeTypes.ConnNode, existingColumns []*storeTypes.PostgresqlColumn, storage storage.Storage) (*storeTypes.ConnNode, error) {
+	storeColumn := &storeTypes.PostgresqlColumn{
+		Name:        column.Name,
+		Type:        column.Type,
+		Constraints: nonNilStrings(column.Constraints),
+	}
+
+	columnNode := &storeTypes.ConnNode{
+		Class: storeTypes.PostgresColumnClass,
+	}
+
+	for _, existingColumn := range existingColumns {
+		if check.ComparePointers(column.Name, existingColumn.Name) {
+			if existingColumn.Equal(storeColumn) {
+				columnNode.ID = *existingColumn.UID
+				return columnNode, nil
+			}
+
+			storeColumn.UID = existingColumn.UID
+
+			slog.Info("updating postgres column", "column", column.Name)
+			dbColumn, err := storage.Postgres().UpdateColumn(ctx, storeColumn)
+			if err != nil {
+				return nil, err
+			}
+
+			columnNode.ID = *dbColumn.UID
+			return columnNode, nil
+		}
+	}
+
+	slog.Info("creating postgres column", "column", column.Name)
+	id, err := storage.Postgres().CreateColumn(ctx, storeColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	columnNode.ID = id
+
+	slog.Info("creating column-table connection", "from_id", columnNode.ID, "to_id", tableNode.ID, "type", storeTypes.ConnIN)
+	if err := storage.Connection().Create(ctx, columnNode, tableNode, storeTypes.ConnIN); err != nil {
+		return nil, fmt.Errorf("failed to create column-table connection: %w", err)
+	}
+
+	return columnNode, nil
+}
+
+func storePostgresIndex(ctx context.Context, index *types.PostgresqlIndex, tableNode *storeTypes.ConnNode, existingIndexes []*storeTypes.PostgresqlIndex, storage storage.Storage) (*storeTypes.ConnNode, error) {
+	storeIndex := &storeTypes.PostgresqlIndex{
+		Name:    index.Name,
+		Columns: nonNilStrings(index.Columns),
+		Unique:  index.Unique,
+	}
+
+	indexNode := &storeTypes.ConnNode{
+		Class: storeTypes.PostgresIndexClass,
+	}
+
+	for _, existingIndex := range existingIndexes {
+		if check.ComparePointers(index.Name, existingIndex.Name) {
+			if existingIndex.Equal(storeIndex) {
+				indexNode.ID = *existingIndex.UID
+				return indexNode, nil
+			}
+
+			storeIndex.UID = existingIndex.UID
+
+			slog.Info("updating postgres index", "index", index.Name)
+			dbIndex, err := storage.Postgres().UpdateIndex(ctx, storeIndex)
+			if err != nil {
+				return nil, err
+			}
+
+			indexNode.ID = *dbIndex.UID
+			return indexNode, nil
+		}
+	}
+
+	slog.Info("creating postgres index", "index", index.Name)
+	id, err := storage.Postgres().CreateIndex(ctx, storeIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	indexNode.ID = id
+
+	slog.Info("creating index-table connection", "from_id", indexNode.ID, "to_id", tableNode.ID, "type", storeTypes.ConnIN)
+	if err := storage.Connection().Create(ctx, indexNode, tableNode, storeTypes.ConnIN); err != nil {
+		return nil, fmt.Errorf("failed to create index-table connection: %w", err)
+	}
+
+	return indexNode, nil
+}
+
+func storePostgresTrigger(ctx context.Context, trigger *types.PostgresqlTrigger, tableNode *storeTypes.ConnNode, existingTriggers []*storeTypes.PostgresqlTrigger, storage storage.Storage) (*storeTypes.ConnNode, error) {
+	storeTrigger := &storeTypes.PostgresqlTrigger{
+		Name:     trigger.Name,
+		Function: trigger.Function,
+	}
+
+	triggerNode := &storeTypes.ConnNode{
+		Class: storeTypes.PostgresTriggerClass,
+	}
+
+	for _, existingTrigger := range existingTriggers {
+		if check.ComparePointers(trigger.Name, existingTrigger.Name) {
+			if existingTrigger.Equal(storeTrigger) {
+				triggerNode.ID = *existingTrigger.UID
+				return triggerNode, nil
+			}
+
+			storeTrigger.UID = existingTrigger.UID
+
+			slog.Info("updating postgres trigger", "trigger", trigger.Name)
+			dbTrigger, err := storage.Postgres().UpdateTrigger(ctx, storeTrigger)
+			if err != nil {
+				return nil, err
+			}
+
+			triggerNode.ID = *dbTrigger.UID
+			return triggerNode, nil
+		}
+	}
+
+	slog.Info("creating postgres trigger", "trigger", trigger.Name)
+	id, err := storage.Postgres().CreateTrigger(ctx, storeTrigger)
+	if err != nil {
+		return nil, err
+	}
+
+	triggerNode.ID = id
+
+	slog.Info("creating trigger-table connection", "from_id", triggerNode.ID, "to_id", tableNode.ID, "type", storeTypes.ConnIN)
+	if err := storage.Connection().Create(ctx, triggerNode, tableNode, storeTypes.ConnIN); err != nil {
+		return nil, fmt.Errorf("failed to create trigger-table connection: %w", err)
+	}
+
+	return triggerNode, nil
+}
+
+func storePostgresFunction(ctx context.Context, function *types.PostgresqlFunction, schemeNode *storeTypes.ConnNode, existingFunctions []*storeTypes.PostgresqlFunction, storage storage.Storage) (*storeTypes.ConnNode, error) {
+	storeFunction := &storeTypes.PostgresqlFunction{
+		Name: function.Name,
+		Args: nonNilStrings(function.Args),
+	}
+
+	functionNode := &storeTypes.ConnNode{
+		Class: storeTypes.PostgresFunctionClass,
+	}
+
+	// functions are overloaded by arguments, so name and args identify one
+	for _, existingFunction := range existingFunctions {
+		if existingFunction.Equal(storeFunction) {
+			functionNode.ID = *existingFunction.UID
+			return functionNode, nil
+		}
+	}
+
+	slog.Info("creating postgres function", "function", function.Name)
+	id, err := storage.Postgres().CreateFunction(ctx, storeFunction)
+	if err != nil {
+		return nil, err
+	}
+
+	functionNode.ID = id
+
+	slog.Info("creating function-scheme connection", "from_id", functionNode.ID, "to_id", schemeNode.ID, "type", storeTypes.ConnIN)
+	if err := storage.Connection().Create(ctx, functionNode, schemeNode, storeTypes.ConnIN); err != nil {
+		return nil, fmt.Errorf("failed to create function-scheme connection: %w", err)
+	}
+
+	return functionNode, nil
+}
+
+func storePostgresEnum(ctx context.Context, enum *types.PostgresqlEnum, schemeNode *storeTypes.ConnNode, existingEnums []*storeTypes.PostgresqlEnum, storage storage.Storage) (*storeTypes.ConnNode, error) {
+	storeEnum := &storeTypes.PostgresqlEnum{
+		Name:   enum.Name,
+		Values: nonNilStrings(enum.Values),
+	}
+
+	enumNode := &storeTypes.ConnNode{
+		Class: storeTypes.PostgresEnumClass,
+	}
+
+	for _, existingEnum := range existingEnums {
+		if check.ComparePointers(enum.Name, existingEnum.Name) {
+			if existingEnum.Equal(storeEnum) {
+				enumNode.ID = *existingEnum.UID
+				return enumNode, nil
+			}
+
+			storeEnum.UID = existingEnum.UID
+
+			slog.Info("updating postgres enum", "enum", enum.Name)
+			dbEnum, err := storage.Postgres().UpdateEnum(ctx, storeEnum)
+			if err != nil {
+				return nil, err
+			}
+
+			enumNode.ID = *dbEnum.UID
+			return enumNode, nil
+		}
+	}
+
+	slog.Info("creating postgres enum", "enum", enum.Name)
+	id, err := storage.Postgres().CreateEnum(ctx, storeEnum)
+	if err != nil {
+		return nil, err
+	}
+
+	enumNode.ID = id
+
+	slog.Info("creating enum-scheme connection", "from_id", enumNode.ID, "to_id", schemeNode.ID, "type", storeTypes.ConnIN)
+	if err := storage.Connection().Create(ctx, enumNode, schemeNode, storeTypes.ConnIN); err != nil {
+		return nil, fmt.Errorf("failed to create enum-scheme connection: %w", err)
+	}
+
+	return enumNode, nil
+}
+
+func storePostgresSequence(ctx context.Context, sequence *types.PostgresqlSequence, schemeNode *storeTypes.ConnNode, existingSequences []*storeTypes.PostgresqlSequence, storage storage.Storage) (*storeTypes.ConnNode, error) {
+	storeSequence := &storeTypes.PostgresqlSequence{
+		Name: sequence.Name,
+	}
+
+	sequenceNode := &storeTypes.ConnNode{
+		Class: storeTypes.PostgresSequenceClass,
+	}
+
+	for _, existingSequence := range existingSequences {
+		if check.ComparePointers(sequence.Name, existingSequence.Name) {
+			if existingSequence.Equal(storeSequence) {
+				sequenceNode.ID = *existingSequence.UID
+				return sequenceNode, nil
+			}
+
+			storeSequence.UID = existingSequence.UID
+
+			slog.Info("updating postgres sequence", "sequence", sequence.Name)
+			dbSequence, err := storage.Postgres().UpdateSequence(ctx, storeSequence)
+			if err != nil {
+				return nil, err
+			}
+
+			sequenceNode.ID = *dbSequence.UID
+			return sequenceNode, nil
+		}
+	}
+
+	slog.Info("creating postgres sequence", "sequence", sequence.Name)
+	id, err := storage.Postgres().CreateSequence(ctx, storeSequence)
+	if err != nil {
+		return nil, err
+	}
+
+	sequenceNode.ID = id
+
+	slog.Info("creating sequence-scheme connection", "from_id", sequenceNode.ID, "to_id", schemeNode.ID, "type", storeTypes.ConnIN)
+	if err := storage.Connection().Create(ctx, sequenceNode, schemeNode, storeTypes.ConnIN); err != nil {
+		return nil, fmt.Errorf("failed to create sequence-scheme connection: %w", err)
+	}
+
+	return sequenceNode, nil
+}
+
+func storePostgresExtension(ctx context.Context, extension *types.PostgresqlExtension, dbNode *storeTypes.ConnNode, existingExtensions []*storeTypes.PostgresqlExtension, storage storage.Storage) (*storeTypes.ConnNode, error) {
+	storeExtension := &storeTypes.PostgresqlExtension{
+		Name:   extension.Name,
+		Scheme: extension.Scheme,
+	}
+
+	extensionNode := &storeTypes.ConnNode{
+		Class: storeTypes.PostgresExtensionClass,
+	}
+
+	for _, existingExtension := range existingExtensions {
+		if check.ComparePointers(extension.Name, existingExtension.Name) {
+			if existingExtension.Equal(storeExtension) {
+				extensionNode.ID = *existingExtension.UID
+				return extensionNode, nil
+			}
+
+			storeExtension.UID = existingExtension.UID
+
+			slog.Info("updating postgres extension", "extension", extension.Name)
+			dbExtension, err := storage.Postgres().UpdateExtension(ctx, storeExtension)
+			if err != nil {
+				return nil, err
+			}
+
+			extensionNode.ID = *dbExtension.UID
+			return extensionNode, nil
+		}
+	}
+
+	slog.Info("creating postgres extension", "extension", extension.Name)
+	id, err := storage.Postgres().CreateExtension(ctx, storeExtension)
+	if err != nil {
+		return nil, err
+	}
+
+	extensionNode.ID = id
+
+	slog.Info("creating extension-db connection", "from_id", extensionNode.ID, "to_id", dbNode.ID, "type", storeTypes.ConnIN)
+	if err := storage.Connection().Create(ctx, extensionNode, dbNode, storeTypes.ConnIN); err != nil {
+		return nil, fmt.Errorf("failed to create extension-db connection: %w", err)
+	}
+
+	return extensionNode, nil
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+
+	return slices.Clone(values)
+}
@@ -0,0 +1,49 @@
+package storefuncs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"vislab/storage"
+	storeTypes "vislab/storage/neo4j/types"
+	"vislab/types"
+)
+
+// storePostgresTableUsages links the service to the tables its code reads and
+// writes. Only tables migrated by this or an already stored service are
+// linked, unknown ones are reported instead of being created.
+func storePostgresTableUsages(ctx context.Context, database *types.PostgresqlDB, databaseNode *storeTypes.ConnNode, tableNodes map[string]*storeTypes.ConnNode, serviceNode *storeTypes.ConnNode, storage storage.Storage, report *StoreReport) error {
+	for _, usage := range database.Usages {
+		if usage.To == nil || usage.To.Name == nil {
+			continue
+		}
+
+		tableNode, err := existingTableNode(ctx, usage.Scheme, usage.To.Name, databaseNode, tableNodes, storage)
+		if err != nil {
+			slog.Error("failed to get used table", "scheme", usage.Scheme, "table", usage.To.Name, "error", err)
+			continue
+		}
+
+		if tableNode == nil {
+			slog.Warn("used table not found", "scheme", usage.Scheme, "table", usage.To.Name)
+			report.UnknownTables = append(report.UnknownTables, postgresTableKey(usage.Scheme, usage.To.Name))
+			continue
+		}
+
+		if usage.Read != nil && *usage.Read {
+			slog.Info("creating svc-table connection", "from_id", serviceNode.ID, "to_id", tableNode.ID, "type", storeTypes.ConnReads)
+			if err := storage.Connection().Create(ctx, serviceNode, tableNode, storeTypes.ConnReads); err != nil {
+				return fmt.Errorf("failed to create svc-table connection: %w", err)
+			}
+		}
+
+		if usage.Write != nil && *usage.Write {
+			slog.Info("creating svc-table connection", "from_id", serviceNode.ID, "to_id", tableNode.ID, "type", storeTypes.ConnWrites)
+			if err := storage.Connection().Create(ctx, serviceNode, tableNode, storeTypes.ConnWrites); err != nil {
+				return fmt.Errorf("failed to create svc-table connection: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package storefuncs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"vislab/libs/check"
+	"vislab/storage"
+	storeTypes "vislab/storage/neo4j/types"
+	"vislab/types"
+)
+
+func storePostgresViews(ctx context.Context, database *types.PostgresqlDB, databaseNode *storeTypes.ConnNode, schemeNodes, tableNodes map[string]*storeTypes.ConnNode, serviceNode *storeTypes.ConnNode, storage storage.Storage) error {
+	viewNodes := map[string]*storeTypes.ConnNode{}
+
+	for _, scheme := range database.Schemes {
+		if len(scheme.Views) == 0 {
+			continue
+		}
+
+		schemeNode, ok := schemeNodes[*scheme.Name]
+		if !ok {
+			continue
+		}
+
+		slog.Info("getting postgres views", "scheme", scheme.Name, "database", database.Name)
+		existingViews, err := storage.Postgres().GetViews(ctx, schemeNode.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, view := range scheme.Views {
+			viewNode, err := storePostgresView(ctx, view, schemeNode, existingViews, storage)
+			if err != nil {
+				slog.Error("failed to store postgres view", "view", view.Name, "error", err)
+				continue
+			}
+
+			viewNodes[postgresTableKey(scheme.Name, view.Name)] = viewNode
+
+			slog.Info("creating svc-view connection", "from_id", serviceNode.ID, "to_id", viewNode.ID, "type", storeTypes.ConnUses)
+			if err := storage.Connection().Create(ctx, serviceNode, viewNode, storeTypes.ConnUses); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, scheme := range database.Schemes {
+		for _, view := range scheme.Views {
+			viewNode, ok := viewNodes[postgresTableKey(scheme.Name, view.Name)]
+			if !ok {
+				continue
+			}
+
+			for _, relation := range view.Reads {
+				readNode, ok := viewNodes[postgresTableKey(relation.Scheme, relation.Name)]
+				if !ok {
+					var err error
+
+					readNode, err = existingTableNode(ctx, relation.Scheme, relation.Name, databaseNode, tableNodes, storage)
+					if err != nil {
+						slog.Error("failed to get postgres table read by view", "view", view.Name, "table", relation.Name, "error", err)
+						continue
+					}
+					if readNode == nil {
+						slog.Warn("postgres table read by view not found", "view", view.Name, "scheme", relation.Scheme, "table", relation.Name)
+						continue
+					}
+				}
+
+				slog.Info("creating view-table connection", "from_id", viewNode.ID, "to_id", readNode.ID, "type", storeTypes.ConnReads)
+				if err := storage.Connection().Create(ctx, viewNode, readNode, storeTypes.ConnReads); err != nil {
+					return fmt.Errorf("failed to create view-table connection: %w", err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func storePostgresView(ctx context.Context, view *types.PostgresqlView, schemeNode *storeTypes.ConnNode, existingViews []*storeTypes.PostgresqlView, storage storage.Storage) (*storeTypes.ConnNode, error) {
+	storeView := &storeTypes.PostgresqlView{
+		Name:         view.Name,
+		Materialized: view.Materialized,
+	}
+
+	viewNode := &storeTypes.ConnNode{
+		Class: storeTypes.PostgresViewClass,
+	}
+
+	for _, existingView := range existingViews {
+		if check.ComparePointers(view.Name, existingView.Name) {
+			if existingView.Equal(storeView) {
+				viewNode.ID = *existingView.UID
+				return viewNode, nil
+			}
+
+			storeView.UID = existingView.UID
+
+			slog.Info("updating postgres view", "view", view.Name)
+			dbView, err := storage.Postgres().UpdateView(ctx, storeView)
+			if err != nil {
+				return nil, err
+			}
+
+			viewNode.ID = *dbView.UID
+			return viewNode, nil
+		}
+	}
+
+	slog.Info("creating postgres view", "view", view.Name)
+	id, err := storage.Postgres().CreateView(ctx, storeView)
+	if err != nil {
+		return nil, err
+	}
+
+	viewNode.ID = id
+
+	slog.Info("creating view-scheme connection", "from_id", viewNode.ID, "to_id", schemeNode.ID, "type", storeTypes.ConnIN)
+	if err := storage.Connection().Create(ctx, viewNode, schemeNode, storeTypes.ConnIN); err != nil {
+		return nil, fmt.Errorf("failed to create view-scheme connection: %w", err)
+	}
+
+	return viewNode, nil
+}
@@ -2,17 +2,19 @@ package storefuncs
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"strings"
 	"vislab/libs/check"
 	"vislab/libs/ptr"
+	"vislab/resolver"
 	"vislab/storage"
 	storeTypes "vislab/storage/neo4j/types"
 	"vislab/types"
 )
 
-func storeRabbitMQ(ctx context.Context, rabbitMQ *types.RabbitMQ, serviceNode *storeTypes.ConnNode, storage storage.Storage) error {
-	rabbitMQNode, err := storeRabbitMQNode(ctx, rabbitMQ, serviceNode, storage)
+func storeRabbitMQ(ctx context.Context, rabbitMQ *types.RabbitMQ, serviceNode *storeTypes.ConnNode, storage storage.Storage, hosts *resolver.HostRegistry) error {
+	rabbitMQNode, err := storeRabbitMQNode(ctx, rabbitMQ, serviceNode, storage, hosts)
 	if err != nil {
 		return err
 	}
@@ -39,8 +41,20 @@ func storeRabbitMQ(ctx context.Context, rabbitMQ *types.RabbitMQ, serviceNode *s
 			continue
 		}
 
-		slog.Info("creating svc-queue connection", "from_id", serviceNode.ID, "to_id", queueNode.ID, "type", storeTypes.ConnUses)
-		if err := storage.Connection().Create(ctx, serviceNode, queueNode, storeTypes.ConnUses); err != nil {
+		connType := storeTypes.ConnUses
+		if queue.QueueType != nil {
+			switch *queue.QueueType {
+			case "consumer":
+				connType = storeTypes.ConnReceivesFrom
+			case "producer":
+				connType = storeTypes.ConnSendsTo
+			default:
+				return fmt.Errorf("unknown queue type: %s", *queue.QueueType)
+			}
+		}
+
+		slog.Info("creating svc-queue connection", "from_id", serviceNode.ID, "to_id", queueNode.ID, "type", connType)
+		if err := storage.Connection().Create(ctx, serviceNode, queueNode, connType); err != nil {
 			return err
 		}
 	}
@@ -48,9 +62,9 @@ func storeRabbitMQ(ctx context.Context, rabbitMQ *types.RabbitMQ, serviceNode *s
 	return nil
 }
 
-func storeRabbitMQNode(ctx context.Context, rabbitMQ *types.RabbitMQ, serviceNode *storeTypes.ConnNode, storage storage.Storage) (*storeTypes.ConnNode, error) {
+func storeRabbitMQNode(ctx context.Context, rabbitMQ *types.RabbitMQ, serviceNode *storeTypes.ConnNode, storage storage.Storage, hosts *resolver.HostRegistry) (*storeTypes.ConnNode, error) {
 	storeRabbitMQ := &storeTypes.RabbitMQ{
-		Host: rabbitMQ.Host,
+		Host: resolveHost(rabbitMQ.Host, hosts),
 		Port: rabbitMQ.Port,
 		User: rabbitMQ.User,
 	}
@@ -89,7 +103,10 @@ func storeRabbitMQNode(ctx context.Context, rabbitMQ *types.RabbitMQ, serviceNod
 
 func storeRabbitMQQueue(ctx context.Context, queue *types.RabbitQueue, rabbitMQNode *storeTypes.ConnNode, existingQueues []*storeTypes.RabbitQueue, storage storage.Storage) (*storeTypes.ConnNode, error) {
 	storeQueue := &storeTypes.RabbitQueue{
-		Name: queue.Name,
+		Name:       queue.Name,
+		QueueType:  queue.QueueType,
+		Exchange:   queue.Exchange,
+		RoutingKey: queue.RoutingKey,
 	}
 
 	queueNode := &storeTypes.ConnNode{
@@ -97,7 +114,7 @@ func storeRabbitMQQueue(ctx context.Context, queue *types.RabbitQueue, rabbitMQN
 	}
 
 	for _, existingQueue := range existingQueues {
-		if check.ComparePointers(existingQueue.Name, storeQueue.Name) {
+		if sameRabbitQueue(existingQueue, storeQueue) {
 			if existingQueue.Equal(storeQueue) {
 				queueNode.ID = *existingQueue.UID
 				return queueNode, nil
@@ -149,3 +166,11 @@ func storeDummyRabbitMQQueue(ctx context.Context, rabbitMQNode *storeTypes.ConnN
 
 	return nil
 }
+
+// sameRabbitQueue keys queues by name, exchange and routing key, services
+// binding the same queue name to different exchanges get their own nodes.
+func sameRabbitQueue(a, b *storeTypes.RabbitQueue) bool {
+	return check.ComparePointers(a.Name, b.Name) &&
+		check.ComparePointers(a.Exchange, b.Exchange) &&
+		check.ComparePointers(a.RoutingKey, b.RoutingKey)
+}
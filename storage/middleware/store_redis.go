@@ -6,13 +6,14 @@ import (
 	"strings"
 	"vislab/libs/check"
 	"vislab/libs/ptr"
+	"vislab/resolver"
 	"vislab/storage"
 	storeTypes "vislab/storage/neo4j/types"
 	"vislab/types"
 )
 
-func storeRedis(ctx context.Context, redis *types.Redis, serviceNode *storeTypes.ConnNode, storage storage.Storage) error {
-	redisNode, err := storeRedisNode(ctx, redis, serviceNode, storage)
+func storeRedis(ctx context.Context, redis *types.Redis, serviceNode *storeTypes.ConnNode, storage storage.Storage, hosts *resolver.HostRegistry) error {
+	redisNode, err := storeRedisNode(ctx, redis, serviceNode, storage, hosts)
 	if err != nil {
 		return err
 	}
@@ -70,9 +71,9 @@ func storeRedis(ctx context.Context, redis *types.Redis, serviceNode *storeTypes
 	return nil
 }
 
-func storeRedisNode(ctx context.Context, redis *types.Redis, serviceNode *storeTypes.ConnNode, storage storage.Storage) (*storeTypes.ConnNode, error) {
+func storeRedisNode(ctx context.Context, redis *types.Redis, serviceNode *storeTypes.ConnNode, storage storage.Storage, hosts *resolver.HostRegistry) (*storeTypes.ConnNode, error) {
 	storeRedis := &storeTypes.Redis{
-		Host:   redis.Host,
+		Host:   resolveHost(redis.Host, hosts),
 		Port:   redis.Port,
 		Master: redis.Master,
 	}
@@ -3,21 +3,31 @@ package storefuncs
 import (
 	"context"
 	"log/slog"
+	"vislab/libs/ptr"
+	"vislab/resolver"
 	"vislab/storage"
 	"vislab/types"
 )
 
-func StoreResources(ctx context.Context, resInfo *types.All, storage storage.Storage) error {
+// StoreReport lists what was found while storing a service but could not be
+// linked to anything stored.
+type StoreReport struct {
+	UnknownTables []string
+}
+
+func StoreResources(ctx context.Context, resInfo *types.All, storage storage.Storage, hosts *resolver.HostRegistry) (*StoreReport, error) {
+	report := &StoreReport{}
+
 	serviceNode, err := storeService(ctx, resInfo.Service, storage)
 	if err != nil {
-		return err
+		return report, err
 	}
 
 	if len(resInfo.Kafkas) == 0 {
 		slog.Debug("no kafkas found in resource yaml")
 	} else {
 		for _, kafka := range resInfo.Kafkas {
-			if err := storeKafka(ctx, kafka, serviceNode, storage); err != nil {
+			if err := storeKafka(ctx, kafka, serviceNode, storage, hosts); err != nil {
 				slog.Error("failed to store kafkas", "err", err)
 				continue
 			}
@@ -28,7 +38,7 @@ func StoreResources(ctx context.Context, resInfo *types.All, storage storage.Sto
 		slog.Debug("no rabbitmq found in resource yaml")
 	} else {
 		for _, rabbitmq := range resInfo.RabbitMQs {
-			if err := storeRabbitMQ(ctx, rabbitmq, serviceNode, storage); err != nil {
+			if err := storeRabbitMQ(ctx, rabbitmq, serviceNode, storage, hosts); err != nil {
 				slog.Error("failed to store rabbitmq", "err", err)
 				continue
 			}
@@ -39,7 +49,7 @@ func StoreResources(ctx context.Context, resInfo *types.All, storage storage.Sto
 		slog.Debug("no postgresql found in resource yaml")
 	} else {
 		for _, postgresql := range resInfo.Postgresqls {
-			if err := storePostgres(ctx, postgresql, serviceNode, storage); err != nil {
+			if err := storePostgres(ctx, postgresql, serviceNode, storage, hosts, report); err != nil {
 				slog.Error("failed to store postgresql", "err", err)
 				continue
 			}
@@ -50,13 +60,38 @@ func StoreResources(ctx context.Context, resInfo *types.All, storage storage.Sto
 		slog.Debug("no redis found in resource yaml")
 	} else {
 		for _, redis := range resInfo.Redises {
-			if err := storeRedis(ctx, redis, serviceNode, storage); err != nil {
+			if err := storeRedis(ctx, redis, serviceNode, storage, hosts); err != nil {
 				slog.Error("failed to store redis", "err", err)
 				continue
 			}
 		}
 	}
 
+	if len(resInfo.Deployments) == 0 && len(resInfo.CronJobs) == 0 && len(resInfo.KubernetesJobs) == 0 &&
+		len(resInfo.KubernetesServices) == 0 && len(resInfo.Ingresses) == 0 {
+		slog.Debug("no kubernetes objects found in manifests")
+	} else {
+		if err := storeKubernetes(ctx, resInfo, serviceNode, storage); err != nil {
+			slog.Error("failed to store kubernetes objects", "err", err)
+		}
+	}
+
+	if resInfo.Pipeline == nil {
+		slog.Debug("no pipeline found in repository")
+	} else {
+		if err := storePipeline(ctx, resInfo.Pipeline, serviceNode, storage); err != nil {
+			slog.Error("failed to store pipeline", "err", err)
+		}
+	}
+
+	if len(resInfo.Teams) == 0 {
+		slog.Debug("no owners found in repository")
+	} else {
+		if err := storeTeams(ctx, resInfo.Teams, serviceNode, storage); err != nil {
+			slog.Error("failed to store teams", "err", err)
+		}
+	}
+
 	if len(resInfo.OtherServices) == 0 {
 		slog.Debug("no other services found in resource yaml")
 	} else {
@@ -68,5 +103,13 @@ func StoreResources(ctx context.Context, resInfo *types.All, storage storage.Sto
 		}
 	}
 
-	return nil
+	return report, nil
+}
+
+func resolveHost(host *string, hosts *resolver.HostRegistry) *string {
+	if host == nil {
+		return nil
+	}
+
+	return ptr.Ptr(hosts.Resolve(*host))
 }
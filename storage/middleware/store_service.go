@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"strings"
 	"vislab/libs/check"
+	"vislab/libs/ptr"
 	"vislab/storage"
 	storeTypes "vislab/storage/neo4j/types"
 	"vislab/types"
@@ -35,12 +36,15 @@ func storeServiceNode(ctx context.Context, service *types.Service, storage stora
 	storeService := &storeTypes.Service{
 		Name:        service.Name,
 		FullName:    service.FullName,
+		Origin:      service.Origin,
 		Link:        service.Link,
 		MainBranch:  service.MainBranch,
 		LatestTag:   service.LatestTag,
 		Language:    service.Language,
+		Languages:   service.Languages,
 		Description: service.Description,
 		Status:      service.Status,
+		External:    service.External,
 	}
 
 	serviceNode := &storeTypes.ConnNode{
@@ -56,7 +60,7 @@ func storeServiceNode(ctx context.Context, service *types.Service, storage stora
 	slog.Warn("error updating service", "service", *service.Name, "error", err)
 
 	if strings.Contains(err.Error(), "nothing to update") {
-		dbService, err := storage.Service().Get(ctx, *storeService.Name)
+		dbService, err := storage.Service().Get(ctx, *storeService.Name, ptr.Value(storeService.Origin))
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,154 @@
+package storefuncs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"vislab/libs/check"
+	"vislab/libs/ptr"
+	"vislab/storage"
+	storeTypes "vislab/storage/neo4j/types"
+	"vislab/types"
+)
+
+// storeTeams links the owning teams to the service, teams and devs are shared
+// between services. Teams which no longer own the service are unlinked.
+func storeTeams(ctx context.Context, teams []*types.Team, serviceNode *storeTypes.ConnNode, storage storage.Storage) error {
+	slog.Info("getting service owners", "service_id", serviceNode.ID)
+	existingOwners, err := storage.Team().GetByService(ctx, serviceNode.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, team := range teams {
+		teamNode, err := storeTeam(ctx, team, storage)
+		if err != nil {
+			slog.Error("failed to store team", "error", err)
+			continue
+		}
+
+		slog.Info("creating team-svc connection", "from_id", teamNode.ID, "to_id", serviceNode.ID, "type", storeTypes.ConnOwns)
+		if err := storage.Connection().Create(ctx, teamNode, serviceNode, storeTypes.ConnOwns); err != nil {
+			return err
+		}
+
+		for _, dev := range team.Devs {
+			devNode, err := storeDev(ctx, dev, storage)
+			if err != nil {
+				slog.Error("failed to store dev", "error", err)
+				continue
+			}
+
+			slog.Info("creating dev-team connection", "from_id", devNode.ID, "to_id", teamNode.ID, "type", storeTypes.ConnIN)
+			if err := storage.Connection().Create(ctx, devNode, teamNode, storeTypes.ConnIN); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, existingOwner := range existingOwners {
+		if slices.ContainsFunc(teams, func(team *types.Team) bool {
+			return check.ComparePointers(team.Name, existingOwner.Name)
+		}) {
+			continue
+		}
+
+		teamNode := &storeTypes.ConnNode{
+			Class: storeTypes.TeamClass,
+			ID:    *existingOwner.UID,
+		}
+
+		slog.Info("deleting team-svc connection", "from_id", teamNode.ID, "to_id", serviceNode.ID, "type", storeTypes.ConnOwns)
+		if err := storage.Connection().Delete(ctx, teamNode, serviceNode, storeTypes.ConnOwns); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func storeTeam(ctx context.Context, team *types.Team, storage storage.Storage) (*storeTypes.ConnNode, error) {
+	teamNode := &storeTypes.ConnNode{
+		Class: storeTypes.TeamClass,
+	}
+
+	dbTeam, err := storage.Team().Get(ctx, *team.Name)
+	if err == nil {
+		teamNode.ID = *dbTeam.UID
+		return teamNode, nil
+	}
+	if !strings.Contains(err.Error(), "not found") {
+		return nil, err
+	}
+
+	slog.Info("creating team", "team", team.Name)
+	id, err := storage.Team().Create(ctx, &storeTypes.Team{Name: team.Name})
+	if err != nil {
+		return nil, err
+	}
+
+	teamNode.ID = id
+	return teamNode, nil
+}
+
+func storeDev(ctx context.Context, dev *types.Dev, storage storage.Storage) (*storeTypes.ConnNode, error) {
+	storeDev := &storeTypes.Dev{
+		Key:      devKey(dev),
+		Name:     dev.Name,
+		Username: dev.Username,
+		Email:    dev.Email,
+		Link:     dev.Link,
+		Role:     dev.Role,
+	}
+
+	devNode := &storeTypes.ConnNode{
+		Class: storeTypes.DevClass,
+	}
+
+	if storeDev.Key == nil {
+		return nil, fmt.Errorf("dev without name, username and email")
+	}
+
+	dbDev, err := storage.Team().GetDev(ctx, *storeDev.Key)
+	if err == nil {
+		if dbDev.Equal(storeDev) {
+			devNode.ID = *dbDev.UID
+			return devNode, nil
+		}
+
+		storeDev.UID = dbDev.UID
+
+		slog.Info("updating dev", "dev", dev.Name)
+		dbDev, err := storage.Team().UpdateDev(ctx, storeDev)
+		if err != nil {
+			return nil, err
+		}
+
+		devNode.ID = *dbDev.UID
+		return devNode, nil
+	}
+	if !strings.Contains(err.Error(), "not found") {
+		return nil, err
+	}
+
+	slog.Info("creating dev", "dev", dev.Name)
+	id, err := storage.Team().CreateDev(ctx, storeDev)
+	if err != nil {
+		return nil, err
+	}
+
+	devNode.ID = id
+	return devNode, nil
+}
+
+func devKey(dev *types.Dev) *string {
+	for _, value := range []*string{dev.Username, dev.Email, dev.Name} {
+		if value != nil && *value != "" {
+			return ptr.Ptr(strings.ToLower(*value))
+		}
+	}
+
+	return nil
+}
@@ -51,7 +51,7 @@ func (n *neo4jConnRepo) Delete(ctx context.Context, fromID, toID *types.ConnNode
 	(n:%s)-[c:%s]-(m:%s)
 	WHERE elementId(n) = $fromID and elementId(m) = $toID
 	DELETE c
-	`, fromID.Class, toID.Class, connType.String())
+	`, fromID.Class, connType.String(), toID.Class)
 
 	args := map[string]any{
 		"fromID": fromID.ID,
@@ -0,0 +1,164 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+	"vislab/storage"
+	"vislab/storage/neo4j/types"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+type (
+	neo4jFlowRepo struct {
+		db neo4j.DriverWithContext
+	}
+)
+
+func (n *Neo4jStorage) Flow() storage.FlowRepository {
+	if n.flowRepo != nil {
+		return n.flowRepo
+	}
+
+	n.flowRepo = &neo4jFlowRepo{db: n.db}
+	return n.flowRepo
+}
+
+func (n *neo4jFlowRepo) GetKafkaEndpoints(ctx context.Context) ([]*types.FlowEndpoint, error) {
+	query := `MATCH
+	(s:Service)-[c:SENDS_TO|RECEIVES_FROM]-(kq:KafkaQueue)-[:IN]->(k:Kafka)
+	RETURN
+	elementId(s) AS serviceUid,
+	s.name AS serviceName,
+	elementId(k) AS brokerUid,
+	type(c) AS connType,
+	kq.name AS queue,
+	coalesce(kq.topic, kq.name) AS topic
+	`
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, nil, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseFlowEndpoints(res.Records)
+}
+
+func (n *neo4jFlowRepo) GetRabbitEndpoints(ctx context.Context) ([]*types.FlowEndpoint, error) {
+	query := `MATCH
+	(s:Service)-[c:SENDS_TO|RECEIVES_FROM]-(rq:RabbitQueue)-[:IN]->(r:RabbitMQ)
+	RETURN
+	elementId(s) AS serviceUid,
+	s.name AS serviceName,
+	elementId(r) AS brokerUid,
+	type(c) AS connType,
+	rq.name AS queue,
+	rq.exchange AS exchange,
+	rq.routingKey AS routingKey
+	`
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, nil, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseFlowEndpoints(res.Records)
+}
+
+func (n *neo4jFlowRepo) Create(ctx context.Context, flow *types.Flow) error {
+	if flow.FromUID == nil || flow.ToUID == nil {
+		return fmt.Errorf("flow cannot be created, from and to uids are required")
+	}
+	if flow.Broker == nil || flow.Topic == nil {
+		return fmt.Errorf("flow cannot be created, broker and topic fields are required")
+	}
+
+	query := `MATCH
+	(from:Service),
+	(to:Service)
+	WHERE elementId(from) = $fromUid AND elementId(to) = $toUid
+	MERGE
+	(from)-[p:PUBLISHES_TO {broker: $broker, topic: $topic}]->(to)
+	SET p.exchange = $exchange
+	`
+
+	args := map[string]any{
+		"fromUid":  flow.FromUID,
+		"toUid":    flow.ToUID,
+		"broker":   flow.Broker,
+		"topic":    flow.Topic,
+		"exchange": flow.Exchange,
+	}
+
+	_, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (n *neo4jFlowRepo) DeleteAll(ctx context.Context) error {
+	query := `MATCH
+	(:Service)-[p:PUBLISHES_TO]->(:Service)
+	DELETE p
+	`
+
+	_, err := neo4j.ExecuteQuery(ctx, n.db, query, nil, neo4j.EagerResultTransformer)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func parseFlowEndpoints(records []*neo4j.Record) ([]*types.FlowEndpoint, error) {
+	endpoints := []*types.FlowEndpoint{}
+
+	for _, record := range records {
+		endpoint := &types.FlowEndpoint{}
+
+		serviceUid, _, err := neo4j.GetRecordValue[string](record, "serviceUid")
+		if err != nil {
+			return nil, err
+		}
+		endpoint.ServiceUID = &serviceUid
+
+		brokerUid, _, err := neo4j.GetRecordValue[string](record, "brokerUid")
+		if err != nil {
+			return nil, err
+		}
+		endpoint.BrokerUID = &brokerUid
+
+		connType, _, err := neo4j.GetRecordValue[string](record, "connType")
+		if err != nil {
+			return nil, err
+		}
+		endpoint.ConnType = types.ConnType(connType)
+
+		if serviceNameAny, ok := record.Get("serviceName"); ok && serviceNameAny != nil {
+			serviceName := serviceNameAny.(string)
+			endpoint.ServiceName = &serviceName
+		}
+		if queueAny, ok := record.Get("queue"); ok && queueAny != nil {
+			queue := queueAny.(string)
+			endpoint.Queue = &queue
+		}
+		if topicAny, ok := record.Get("topic"); ok && topicAny != nil {
+			topic := topicAny.(string)
+			endpoint.Topic = &topic
+		}
+		if exchangeAny, ok := record.Get("exchange"); ok && exchangeAny != nil {
+			exchange := exchangeAny.(string)
+			endpoint.Exchange = &exchange
+		}
+		if routingKeyAny, ok := record.Get("routingKey"); ok && routingKeyAny != nil {
+			routingKey := routingKeyAny.(string)
+			endpoint.RoutingKey = &routingKey
+		}
+
+		endpoints = append(endpoints, endpoint)
+	}
+
+	return endpoints, nil
+}
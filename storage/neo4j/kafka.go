@@ -28,15 +28,15 @@ func (n *Neo4jStorage) Kafka() storage.KafkaRepository {
 func (n *neo4jKafkaRepo) Create(ctx context.Context, kafka *types.Kafka) (string, error) {
 	query := `CREATE
 	(k:Kafka {
-		host: $host,
-		port: $port
+		name: $name,
+		brokers: $brokers
 	})
 	RETURN k
 	`
 
 	args := map[string]any{
-		"host": kafka.Host,
-		"port": kafka.Port,
+		"name":    kafka.Name,
+		"brokers": kafka.Brokers,
 	}
 
 	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
@@ -129,15 +129,22 @@ func (n *neo4jKafkaRepo) DeleteQueue(ctx context.Context, uid string) error {
 	return nil
 }
 
-func (n *neo4jKafkaRepo) Get(ctx context.Context, host string) (*types.Kafka, error) {
+func (n *neo4jKafkaRepo) Find(ctx context.Context, name *string, brokers []string) ([]*types.Kafka, error) {
 	query := `MATCH
 	(k:Kafka)
-	WHERE k.host = $host
-	RETURN k
+	WHERE ($name IS NOT NULL AND k.name = $name)
+	OR any(broker IN coalesce(k.brokers, []) WHERE broker IN $brokers)
+	RETURN k, ($name IS NOT NULL AND k.name = $name) AS byName
+	ORDER BY byName DESC
 	`
 
+	if brokers == nil {
+		brokers = []string{}
+	}
+
 	args := map[string]any{
-		"host": host,
+		"name":    name,
+		"brokers": brokers,
 	}
 
 	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
@@ -145,33 +152,32 @@ func (n *neo4jKafkaRepo) Get(ctx context.Context, host string) (*types.Kafka, er
 		return nil, err
 	}
 
-	if len(res.Records) == 0 {
-		return nil, fmt.Errorf("kafka node not found")
-	}
+	kafkas := []*types.Kafka{}
 
-	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "k")
-	if err != nil {
-		return nil, err
-	}
+	for _, record := range res.Records {
+		itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](record, "k")
+		if err != nil {
+			return nil, err
+		}
 
-	kafka := &types.Kafka{
-		UID: &itemNode.ElementId,
-	}
+		kafka := &types.Kafka{
+			UID: &itemNode.ElementId,
+		}
 
-	if nameAny, ok := itemNode.Props["name"]; ok {
-		name := nameAny.(string)
-		kafka.Name = &name
-	}
-	if hostAny, ok := itemNode.Props["host"]; ok {
-		host := hostAny.(string)
-		kafka.Host = &host
-	}
-	if portAny, ok := itemNode.Props["port"]; ok {
-		port := portAny.(int64)
-		kafka.Port = &port
+		if nameAny, ok := itemNode.Props["name"]; ok {
+			name := nameAny.(string)
+			kafka.Name = &name
+		}
+		if brokersAny, ok := itemNode.Props["brokers"]; ok {
+			for _, brokerAny := range brokersAny.([]any) {
+				kafka.Brokers = append(kafka.Brokers, brokerAny.(string))
+			}
+		}
+
+		kafkas = append(kafkas, kafka)
 	}
 
-	return kafka, nil
+	return kafkas, nil
 }
 
 func (n *neo4jKafkaRepo) GetQueues(ctx context.Context, kafkaUid string) ([]*types.KafkaQueue, error) {
@@ -232,32 +238,32 @@ func (n *neo4jKafkaRepo) GetQueues(ctx context.Context, kafkaUid string) ([]*typ
 func (n *neo4jKafkaRepo) Update(ctx context.Context, kafka *types.Kafka) (*types.Kafka, error) {
 	query := `MATCH
 	(k:Kafka)
-	WHERE k.host = $host
+	WHERE elementId(k) = $uid
 	SET
 	`
 	params := []string{}
 
-	if kafka.Host == nil {
-		return nil, fmt.Errorf("kafka cannot be updated, host field is required")
+	if kafka.UID == nil {
+		return nil, fmt.Errorf("kafka cannot be updated, uid field is required")
 	}
 	if kafka.Name != nil {
 		params = append(params, "k.name = $name")
 	}
-	if kafka.Port != nil {
-		params = append(params, "k.port = $port")
+	if kafka.Brokers != nil {
+		params = append(params, "k.brokers = $brokers")
 	}
 
 	if len(params) == 0 {
-		return nil, fmt.Errorf("no fields to update")
+		return nil, fmt.Errorf("nothing to update")
 	}
 
 	query += strings.Join(params, ", ")
 	query += " RETURN k"
 
 	args := map[string]any{
-		"host": kafka.Host,
-		"name": kafka.Name,
-		"port": kafka.Port,
+		"uid":     kafka.UID,
+		"name":    kafka.Name,
+		"brokers": kafka.Brokers,
 	}
 
 	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
@@ -282,18 +288,78 @@ func (n *neo4jKafkaRepo) Update(ctx context.Context, kafka *types.Kafka) (*types
 		name := nameAny.(string)
 		newKafka.Name = &name
 	}
-	if hostAny, ok := itemNode.Props["host"]; ok {
-		host := hostAny.(string)
-		newKafka.Host = &host
-	}
-	if portAny, ok := itemNode.Props["port"]; ok {
-		port := portAny.(int64)
-		newKafka.Port = &port
+	if brokersAny, ok := itemNode.Props["brokers"]; ok {
+		for _, brokerAny := range brokersAny.([]any) {
+			newKafka.Brokers = append(newKafka.Brokers, brokerAny.(string))
+		}
 	}
 
 	return newKafka, nil
 }
 
+func (n *neo4jKafkaRepo) Merge(ctx context.Context, intoUid, fromUid string) error {
+	query := `MATCH
+	(into:Kafka),
+	(from:Kafka)
+	WHERE elementId(into) = $intoUid AND elementId(from) = $fromUid
+	SET
+	into.name = coalesce(into.name, from.name),
+	into.brokers = reduce(acc = coalesce(into.brokers, []), broker IN coalesce(from.brokers, []) |
+		CASE WHEN broker IN acc THEN acc ELSE acc + broker END)
+	WITH into, from
+	OPTIONAL MATCH
+	(kq:KafkaQueue)-[:IN]->(from)
+	WITH into, from, collect(kq) AS queues
+	FOREACH (kq IN queues | MERGE (kq)-[:IN]->(into))
+	DETACH DELETE from
+	`
+
+	args := map[string]any{
+		"intoUid": intoUid,
+		"fromUid": fromUid,
+	}
+
+	_, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (n *neo4jKafkaRepo) MergeQueue(ctx context.Context, intoUid, fromUid string) error {
+	query := `MATCH
+	(into:KafkaQueue),
+	(from:KafkaQueue)
+	WHERE elementId(into) = $intoUid AND elementId(from) = $fromUid
+	OPTIONAL MATCH
+	(producer:Service)-[:SENDS_TO]-(from)
+	WITH into, from, collect(producer) AS producers
+	OPTIONAL MATCH
+	(consumer:Service)-[:RECEIVES_FROM]-(from)
+	WITH into, from, producers, collect(consumer) AS consumers
+	OPTIONAL MATCH
+	(user:Service)-[:dummy]-(from)
+	WITH into, from, producers, consumers, collect(user) AS users
+	FOREACH (s IN producers | MERGE (s)-[:SENDS_TO]->(into))
+	FOREACH (s IN consumers | MERGE (s)-[:RECEIVES_FROM]->(into))
+	FOREACH (s IN users | MERGE (s)-[:dummy]->(into))
+	DETACH DELETE from
+	`
+
+	args := map[string]any{
+		"intoUid": intoUid,
+		"fromUid": fromUid,
+	}
+
+	_, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (n *neo4jKafkaRepo) UpdateQueue(ctx context.Context, queue *types.KafkaQueue) (*types.KafkaQueue, error) {
 	query := `MATCH
 	(kq:KafkaQueue)
@@ -0,0 +1,1079 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"vislab/storage"
+	"vislab/storage/neo4j/types"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+type (
+	neo4jKubernetesRepo struct {
+		db neo4j.DriverWithContext
+	}
+)
+
+func (n *Neo4jStorage) Kubernetes() storage.KubernetesRepository {
+	if n.kubernetesRepo != nil {
+		return n.kubernetesRepo
+	}
+
+	n.kubernetesRepo = &neo4jKubernetesRepo{db: n.db}
+	return n.kubernetesRepo
+}
+
+func (n *neo4jKubernetesRepo) CreateDeployment(ctx context.Context, deployment *types.Deployment) (string, error) {
+	query := `CREATE
+	(d:Deployment {
+		name: $name,
+		link: $link,
+		namespace: $namespace,
+		replicas: $replicas,
+		images: $images,
+		ports: $ports
+	})
+	RETURN d
+	`
+
+	args := map[string]any{
+		"name":      deployment.Name,
+		"link":      deployment.Link,
+		"namespace": deployment.Namespace,
+		"replicas":  deployment.Replicas,
+		"images":    deployment.Images,
+		"ports":     deployment.Ports,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return "", err
+	}
+
+	if len(res.Records) == 0 {
+		return "", fmt.Errorf("deployment node not created")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "d")
+	if err != nil {
+		return "", err
+	}
+
+	return itemNode.ElementId, nil
+}
+
+func (n *neo4jKubernetesRepo) GetDeployments(ctx context.Context, serviceUid string) ([]*types.Deployment, error) {
+	query := `MATCH
+	(d:Deployment)-[:IN]->(s:Service)
+	WHERE elementId(s) = $uid
+	RETURN d
+	`
+
+	args := map[string]any{
+		"uid": serviceUid,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	deployments := []*types.Deployment{}
+
+	if len(res.Records) == 0 {
+		return deployments, nil
+	}
+
+	for _, record := range res.Records {
+		itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](record, "d")
+		if err != nil {
+			return nil, err
+		}
+
+		deployment := &types.Deployment{
+			UID: &itemNode.ElementId,
+		}
+
+		if nameAny, ok := itemNode.Props["name"]; ok {
+			name := nameAny.(string)
+			deployment.Name = &name
+		}
+		if linkAny, ok := itemNode.Props["link"]; ok {
+			link := linkAny.(string)
+			deployment.Link = &link
+		}
+		if namespaceAny, ok := itemNode.Props["namespace"]; ok {
+			namespace := namespaceAny.(string)
+			deployment.Namespace = &namespace
+		}
+		if replicasAny, ok := itemNode.Props["replicas"]; ok {
+			replicas := replicasAny.(int64)
+			deployment.Replicas = &replicas
+		}
+		if imagesAny, ok := itemNode.Props["images"]; ok {
+			for _, valueAny := range imagesAny.([]any) {
+				deployment.Images = append(deployment.Images, valueAny.(string))
+			}
+		}
+		if portsAny, ok := itemNode.Props["ports"]; ok {
+			for _, valueAny := range portsAny.([]any) {
+				deployment.Ports = append(deployment.Ports, valueAny.(int64))
+			}
+		}
+
+		deployments = append(deployments, deployment)
+	}
+
+	return deployments, nil
+}
+
+func (n *neo4jKubernetesRepo) DeleteDeployment(ctx context.Context, uid string) error {
+	query := `MATCH
+	(d:Deployment)
+	WHERE elementId(d) = $uid
+	DETACH DELETE d
+	`
+
+	args := map[string]any{
+		"uid": uid,
+	}
+
+	_, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (n *neo4jKubernetesRepo) UpdateDeployment(ctx context.Context, deployment *types.Deployment) (*types.Deployment, error) {
+	query := `MATCH
+	(d:Deployment)
+	WHERE elementId(d) = $uid
+	SET
+	`
+
+	params := []string{}
+
+	if deployment.UID == nil {
+		return nil, fmt.Errorf("deployment cannot be updated, uid field is required")
+	}
+	if deployment.Name != nil {
+		params = append(params, "d.name = $name")
+	}
+	if deployment.Link != nil {
+		params = append(params, "d.link = $link")
+	}
+	if deployment.Namespace != nil {
+		params = append(params, "d.namespace = $namespace")
+	}
+	if deployment.Replicas != nil {
+		params = append(params, "d.replicas = $replicas")
+	}
+	if deployment.Images != nil {
+		params = append(params, "d.images = $images")
+	}
+	if deployment.Ports != nil {
+		params = append(params, "d.ports = $ports")
+	}
+
+	if len(params) == 0 {
+		return nil, fmt.Errorf("noting to update")
+	}
+
+	query += strings.Join(params, ", ")
+	query += " RETURN d"
+
+	args := map[string]any{
+		"uid":       deployment.UID,
+		"name":      deployment.Name,
+		"link":      deployment.Link,
+		"namespace": deployment.Namespace,
+		"replicas":  deployment.Replicas,
+		"images":    deployment.Images,
+		"ports":     deployment.Ports,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Records) == 0 {
+		return nil, fmt.Errorf("deployment node not found")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "d")
+	if err != nil {
+		return nil, err
+	}
+
+	newDeployment := &types.Deployment{
+		UID: &itemNode.ElementId,
+	}
+
+	if nameAny, ok := itemNode.Props["name"]; ok {
+		name := nameAny.(string)
+		newDeployment.Name = &name
+	}
+	if linkAny, ok := itemNode.Props["link"]; ok {
+		link := linkAny.(string)
+		newDeployment.Link = &link
+	}
+	if namespaceAny, ok := itemNode.Props["namespace"]; ok {
+		namespace := namespaceAny.(string)
+		newDeployment.Namespace = &namespace
+	}
+	if replicasAny, ok := itemNode.Props["replicas"]; ok {
+		replicas := replicasAny.(int64)
+		newDeployment.Replicas = &replicas
+	}
+	if imagesAny, ok := itemNode.Props["images"]; ok {
+		for _, valueAny := range imagesAny.([]any) {
+			newDeployment.Images = append(newDeployment.Images, valueAny.(string))
+		}
+	}
+	if portsAny, ok := itemNode.Props["ports"]; ok {
+		for _, valueAny := range portsAny.([]any) {
+			newDeployment.Ports = append(newDeployment.Ports, valueAny.(int64))
+		}
+	}
+
+	return newDeployment, nil
+}
+
+func (n *neo4jKubernetesRepo) CreateCronJob(ctx context.Context, cronJob *types.CronJob) (string, error) {
+	query := `CREATE
+	(cj:CronJob {
+		name: $name,
+		link: $link,
+		namespace: $namespace,
+		schedule: $schedule,
+		images: $images
+	})
+	RETURN cj
+	`
+
+	args := map[string]any{
+		"name":      cronJob.Name,
+		"link":      cronJob.Link,
+		"namespace": cronJob.Namespace,
+		"schedule":  cronJob.Schedule,
+		"images":    cronJob.Images,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return "", err
+	}
+
+	if len(res.Records) == 0 {
+		return "", fmt.Errorf("cron job node not created")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "cj")
+	if err != nil {
+		return "", err
+	}
+
+	return itemNode.ElementId, nil
+}
+
+func (n *neo4jKubernetesRepo) GetCronJobs(ctx context.Context, serviceUid string) ([]*types.CronJob, error) {
+	query := `MATCH
+	(cj:CronJob)-[:IN]->(s:Service)
+	WHERE elementId(s) = $uid
+	RETURN cj
+	`
+
+	args := map[string]any{
+		"uid": serviceUid,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	cronJobs := []*types.CronJob{}
+
+	if len(res.Records) == 0 {
+		return cronJobs, nil
+	}
+
+	for _, record := range res.Records {
+		itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](record, "cj")
+		if err != nil {
+			return nil, err
+		}
+
+		cronJob := &types.CronJob{
+			UID: &itemNode.ElementId,
+		}
+
+		if nameAny, ok := itemNode.Props["name"]; ok {
+			name := nameAny.(string)
+			cronJob.Name = &name
+		}
+		if linkAny, ok := itemNode.Props["link"]; ok {
+			link := linkAny.(string)
+			cronJob.Link = &link
+		}
+		if namespaceAny, ok := itemNode.Props["namespace"]; ok {
+			namespace := namespaceAny.(string)
+			cronJob.Namespace = &namespace
+		}
+		if scheduleAny, ok := itemNode.Props["schedule"]; ok {
+			schedule := scheduleAny.(string)
+			cronJob.Schedule = &schedule
+		}
+		if imagesAny, ok := itemNode.Props["images"]; ok {
+			for _, valueAny := range imagesAny.([]any) {
+				cronJob.Images = append(cronJob.Images, valueAny.(string))
+			}
+		}
+
+		cronJobs = append(cronJobs, cronJob)
+	}
+
+	return cronJobs, nil
+}
+
+func (n *neo4jKubernetesRepo) DeleteCronJob(ctx context.Context, uid string) error {
+	query := `MATCH
+	(cj:CronJob)
+	WHERE elementId(cj) = $uid
+	DETACH DELETE cj
+	`
+
+	args := map[string]any{
+		"uid": uid,
+	}
+
+	_, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (n *neo4jKubernetesRepo) UpdateCronJob(ctx context.Context, cronJob *types.CronJob) (*types.CronJob, error) {
+	query := `MATCH
+	(cj:CronJob)
+	WHERE elementId(cj) = $uid
+	SET
+	`
+
+	params := []string{}
+
+	if cronJob.UID == nil {
+		return nil, fmt.Errorf("cron job cannot be updated, uid field is required")
+	}
+	if cronJob.Name != nil {
+		params = append(params, "cj.name = $name")
+	}
+	if cronJob.Link != nil {
+		params = append(params, "cj.link = $link")
+	}
+	if cronJob.Namespace != nil {
+		params = append(params, "cj.namespace = $namespace")
+	}
+	if cronJob.Schedule != nil {
+		params = append(params, "cj.schedule = $schedule")
+	}
+	if cronJob.Images != nil {
+		params = append(params, "cj.images = $images")
+	}
+
+	if len(params) == 0 {
+		return nil, fmt.Errorf("noting to update")
+	}
+
+	query += strings.Join(params, ", ")
+	query += " RETURN cj"
+
+	args := map[string]any{
+		"uid":       cronJob.UID,
+		"name":      cronJob.Name,
+		"link":      cronJob.Link,
+		"namespace": cronJob.Namespace,
+		"schedule":  cronJob.Schedule,
+		"images":    cronJob.Images,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Records) == 0 {
+		return nil, fmt.Errorf("cron job node not found")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "cj")
+	if err != nil {
+		return nil, err
+	}
+
+	newCronJob := &types.CronJob{
+		UID: &itemNode.ElementId,
+	}
+
+	if nameAny, ok := itemNode.Props["name"]; ok {
+		name := nameAny.(string)
+		newCronJob.Name = &name
+	}
+	if linkAny, ok := itemNode.Props["link"]; ok {
+		link := linkAny.(string)
+		newCronJob.Link = &link
+	}
+	if namespaceAny, ok := itemNode.Props["namespace"]; ok {
+		namespace := namespaceAny.(string)
+		newCronJob.Namespace = &namespace
+	}
+	if scheduleAny, ok := itemNode.Props["schedule"]; ok {
+		schedule := scheduleAny.(string)
+		newCronJob.Schedule = &schedule
+	}
+	if imagesAny, ok := itemNode.Props["images"]; ok {
+		for _, valueAny := range imagesAny.([]any) {
+			newCronJob.Images = append(newCronJob.Images, valueAny.(string))
+		}
+	}
+
+	return newCronJob, nil
+}
+
+func (n *neo4jKubernetesRepo) CreateJob(ctx context.Context, job *types.KubernetesJob) (string, error) {
+	query := `CREATE
+	(kj:KubernetesJob {
+		name: $name,
+		link: $link,
+		namespace: $namespace,
+		images: $images
+	})
+	RETURN kj
+	`
+
+	args := map[string]any{
+		"name":      job.Name,
+		"link":      job.Link,
+		"namespace": job.Namespace,
+		"images":    job.Images,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return "", err
+	}
+
+	if len(res.Records) == 0 {
+		return "", fmt.Errorf("kubernetes job node not created")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "kj")
+	if err != nil {
+		return "", err
+	}
+
+	return itemNode.ElementId, nil
+}
+
+func (n *neo4jKubernetesRepo) GetJobs(ctx context.Context, serviceUid string) ([]*types.KubernetesJob, error) {
+	query := `MATCH
+	(kj:KubernetesJob)-[:IN]->(s:Service)
+	WHERE elementId(s) = $uid
+	RETURN kj
+	`
+
+	args := map[string]any{
+		"uid": serviceUid,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := []*types.KubernetesJob{}
+
+	if len(res.Records) == 0 {
+		return jobs, nil
+	}
+
+	for _, record := range res.Records {
+		itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](record, "kj")
+		if err != nil {
+			return nil, err
+		}
+
+		job := &types.KubernetesJob{
+			UID: &itemNode.ElementId,
+		}
+
+		if nameAny, ok := itemNode.Props["name"]; ok {
+			name := nameAny.(string)
+			job.Name = &name
+		}
+		if linkAny, ok := itemNode.Props["link"]; ok {
+			link := linkAny.(string)
+			job.Link = &link
+		}
+		if namespaceAny, ok := itemNode.Props["namespace"]; ok {
+			namespace := namespaceAny.(string)
+			job.Namespace = &namespace
+		}
+		if imagesAny, ok := itemNode.Props["images"]; ok {
+			for _, valueAny := range imagesAny.([]any) {
+				job.Images = append(job.Images, valueAny.(string))
+			}
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+func (n *neo4jKubernetesRepo) DeleteJob(ctx context.Context, uid string) error {
+	query := `MATCH
+	(kj:KubernetesJob)
+	WHERE elementId(kj) = $uid
+	DETACH DELETE kj
+	`
+
+	args := map[string]any{
+		"uid": uid,
+	}
+
+	_, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (n *neo4jKubernetesRepo) UpdateJob(ctx context.Context, job *types.KubernetesJob) (*types.KubernetesJob, error) {
+	query := `MATCH
+	(kj:KubernetesJob)
+	WHERE elementId(kj) = $uid
+	SET
+	`
+
+	params := []string{}
+
+	if job.UID == nil {
+		return nil, fmt.Errorf("kubernetes job cannot be updated, uid field is required")
+	}
+	if job.Name != nil {
+		params = append(params, "kj.name = $name")
+	}
+	if job.Link != nil {
+		params = append(params, "kj.link = $link")
+	}
+	if job.Namespace != nil {
+		params = append(params, "kj.namespace = $namespace")
+	}
+	if job.Images != nil {
+		params = append(params, "kj.images = $images")
+	}
+
+	if len(params) == 0 {
+		return nil, fmt.Errorf("noting to update")
+	}
+
+	query += strings.Join(params, ", ")
+	query += " RETURN kj"
+
+	args := map[string]any{
+		"uid":       job.UID,
+		"name":      job.Name,
+		"link":      job.Link,
+		"namespace": job.Namespace,
+		"images":    job.Images,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Records) == 0 {
+		return nil, fmt.Errorf("kubernetes job node not found")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "kj")
+	if err != nil {
+		return nil, err
+	}
+
+	newJob := &types.KubernetesJob{
+		UID: &itemNode.ElementId,
+	}
+
+	if nameAny, ok := itemNode.Props["name"]; ok {
+		name := nameAny.(string)
+		newJob.Name = &name
+	}
+	if linkAny, ok := itemNode.Props["link"]; ok {
+		link := linkAny.(string)
+		newJob.Link = &link
+	}
+	if namespaceAny, ok := itemNode.Props["namespace"]; ok {
+		namespace := namespaceAny.(string)
+		newJob.Namespace = &namespace
+	}
+	if imagesAny, ok := itemNode.Props["images"]; ok {
+		for _, valueAny := range imagesAny.([]any) {
+			newJob.Images = append(newJob.Images, valueAny.(string))
+		}
+	}
+
+	return newJob, nil
+}
+
+func (n *neo4jKubernetesRepo) CreateService(ctx context.Context, service *types.KubernetesService) (string, error) {
+	query := `CREATE
+	(ks:KubernetesService {
+		name: $name,
+		link: $link,
+		namespace: $namespace,
+		type: $type,
+		ports: $ports
+	})
+	RETURN ks
+	`
+
+	args := map[string]any{
+		"name":      service.Name,
+		"link":      service.Link,
+		"namespace": service.Namespace,
+		"type":      service.Type,
+		"ports":     service.Ports,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return "", err
+	}
+
+	if len(res.Records) == 0 {
+		return "", fmt.Errorf("kubernetes service node not created")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "ks")
+	if err != nil {
+		return "", err
+	}
+
+	return itemNode.ElementId, nil
+}
+
+func (n *neo4jKubernetesRepo) GetServices(ctx context.Context, serviceUid string) ([]*types.KubernetesService, error) {
+	query := `MATCH
+	(ks:KubernetesService)-[:IN]->(s:Service)
+	WHERE elementId(s) = $uid
+	RETURN ks
+	`
+
+	args := map[string]any{
+		"uid": serviceUid,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	services := []*types.KubernetesService{}
+
+	if len(res.Records) == 0 {
+		return services, nil
+	}
+
+	for _, record := range res.Records {
+		itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](record, "ks")
+		if err != nil {
+			return nil, err
+		}
+
+		service := &types.KubernetesService{
+			UID: &itemNode.ElementId,
+		}
+
+		if nameAny, ok := itemNode.Props["name"]; ok {
+			name := nameAny.(string)
+			service.Name = &name
+		}
+		if linkAny, ok := itemNode.Props["link"]; ok {
+			link := linkAny.(string)
+			service.Link = &link
+		}
+		if namespaceAny, ok := itemNode.Props["namespace"]; ok {
+			namespace := namespaceAny.(string)
+			service.Namespace = &namespace
+		}
+		if typeAny, ok := itemNode.Props["type"]; ok {
+			serviceType := typeAny.(string)
+			service.Type = &serviceType
+		}
+		if portsAny, ok := itemNode.Props["ports"]; ok {
+			for _, valueAny := range portsAny.([]any) {
+				service.Ports = append(service.Ports, valueAny.(int64))
+			}
+		}
+
+		services = append(services, service)
+	}
+
+	return services, nil
+}
+
+func (n *neo4jKubernetesRepo) DeleteService(ctx context.Context, uid string) error {
+	query := `MATCH
+	(ks:KubernetesService)
+	WHERE elementId(ks) = $uid
+	DETACH DELETE ks
+	`
+
+	args := map[string]any{
+		"uid": uid,
+	}
+
+	_, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (n *neo4jKubernetesRepo) UpdateService(ctx context.Context, service *types.KubernetesService) (*types.KubernetesService, error) {
+	query := `MATCH
+	(ks:KubernetesService)
+	WHERE elementId(ks) = $uid
+	SET
+	`
+
+	params := []string{}
+
+	if service.UID == nil {
+		return nil, fmt.Errorf("kubernetes service cannot be updated, uid field is required")
+	}
+	if service.Name != nil {
+		params = append(params, "ks.name = $name")
+	}
+	if service.Link != nil {
+		params = append(params, "ks.link = $link")
+	}
+	if service.Namespace != nil {
+		params = append(params, "ks.namespace = $namespace")
+	}
+	if service.Type != nil {
+		params = append(params, "ks.type = $type")
+	}
+	if service.Ports != nil {
+		params = append(params, "ks.ports = $ports")
+	}
+
+	if len(params) == 0 {
+		return nil, fmt.Errorf("noting to update")
+	}
+
+	query += strings.Join(params, ", ")
+	query += " RETURN ks"
+
+	args := map[string]any{
+		"uid":       service.UID,
+		"name":      service.Name,
+		"link":      service.Link,
+		"namespace": service.Namespace,
+		"type":      service.Type,
+		"ports":     service.Ports,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Records) == 0 {
+		return nil, fmt.Errorf("kubernetes service node not found")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "ks")
+	if err != nil {
+		return nil, err
+	}
+
+	newService := &types.KubernetesService{
+		UID: &itemNode.ElementId,
+	}
+
+	if nameAny, ok := itemNode.Props["name"]; ok {
+		name := nameAny.(string)
+		newService.Name = &name
+	}
+	if linkAny, ok := itemNode.Props["link"]; ok {
+		link := linkAny.(string)
+		newService.Link = &link
+	}
+	if namespaceAny, ok := itemNode.Props["namespace"]; ok {
+		namespace := namespaceAny.(string)
+		newService.Namespace = &namespace
+	}
+	if typeAny, ok := itemNode.Props["type"]; ok {
+		serviceType := typeAny.(string)
+		newService.Type = &serviceType
+	}
+	if portsAny, ok := itemNode.Props["ports"]; ok {
+		for _, valueAny := range portsAny.([]any) {
+			newService.Ports = append(newService.Ports, valueAny.(int64))
+		}
+	}
+
+	return newService, nil
+}
+
+func (n *neo4jKubernetesRepo) CreateIngress(ctx context.Context, ingress *types.Ingress) (string, error) {
+	query := `CREATE
+	(i:Ingress {
+		name: $name,
+		link: $link,
+		namespace: $namespace,
+		hosts: $hosts,
+		backends: $backends
+	})
+	RETURN i
+	`
+
+	args := map[string]any{
+		"name":      ingress.Name,
+		"link":      ingress.Link,
+		"namespace": ingress.Namespace,
+		"hosts":     ingress.Hosts,
+		"backends":  ingress.Backends,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return "", err
+	}
+
+	if len(res.Records) == 0 {
+		return "", fmt.Errorf("ingress node not created")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "i")
+	if err != nil {
+		return "", err
+	}
+
+	return itemNode.ElementId, nil
+}
+
+func (n *neo4jKubernetesRepo) GetIngresses(ctx context.Context, serviceUid string) ([]*types.Ingress, error) {
+	query := `MATCH
+	(i:Ingress)-[:IN]->(s:Service)
+	WHERE elementId(s) = $uid
+	RETURN i
+	`
+
+	args := map[string]any{
+		"uid": serviceUid,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	ingresss := []*types.Ingress{}
+
+	if len(res.Records) == 0 {
+		return ingresss, nil
+	}
+
+	for _, record := range res.Records {
+		itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](record, "i")
+		if err != nil {
+			return nil, err
+		}
+
+		ingress := &types.Ingress{
+			UID: &itemNode.ElementId,
+		}
+
+		if nameAny, ok := itemNode.Props["name"]; ok {
+			name := nameAny.(string)
+			ingress.Name = &name
+		}
+		if linkAny, ok := itemNode.Props["link"]; ok {
+			link := linkAny.(string)
+			ingress.Link = &link
+		}
+		if namespaceAny, ok := itemNode.Props["namespace"]; ok {
+			namespace := namespaceAny.(string)
+			ingress.Namespace = &namespace
+		}
+		if hostsAny, ok := itemNode.Props["hosts"]; ok {
+			for _, valueAny := range hostsAny.([]any) {
+				ingress.Hosts = append(ingress.Hosts, valueAny.(string))
+			}
+		}
+		if backendsAny, ok := itemNode.Props["backends"]; ok {
+			for _, valueAny := range backendsAny.([]any) {
+				ingress.Backends = append(ingress.Backends, valueAny.(string))
+			}
+		}
+
+		ingresss = append(ingresss, ingress)
+	}
+
+	return ingresss, nil
+}
+
+func (n *neo4jKubernetesRepo) DeleteIngress(ctx context.Context, uid string) error {
+	query := `MATCH
+	(i:Ingress)
+	WHERE elementId(i) = $uid
+	DETACH DELETE i
+	`
+
+	args := map[string]any{
+		"uid": uid,
+	}
+
+	_, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (n *neo4jKubernetesRepo) UpdateIngress(ctx context.Context, ingress *types.Ingress) (*types.Ingress, error) {
+	query := `MATCH
+	(i:Ingress)
+	WHERE elementId(i) = $uid
+	SET
+	`
+
+	params := []string{}
+
+	if ingress.UID == nil {
+		return nil, fmt.Errorf("ingress cannot be updated, uid field is required")
+	}
+	if ingress.Name != nil {
+		params = append(params, "i.name = $name")
+	}
+	if ingress.Link != nil {
+		params = append(params, "i.link = $link")
+	}
+	if ingress.Namespace != nil {
+		params = append(params, "i.namespace = $namespace")
+	}
+	if ingress.Hosts != nil {
+		params = append(params, "i.hosts = $hosts")
+	}
+	if ingress.Backends != nil {
+		params = append(params, "i.backends = $backends")
+	}
+
+	if len(params) == 0 {
+		return nil, fmt.Errorf("noting to update")
+	}
+
+	query += strings.Join(params, ", ")
+	query += " RETURN i"
+
+	args := map[string]any{
+		"uid":       ingress.UID,
+		"name":      ingress.Name,
+		"link":      ingress.Link,
+		"namespace": ingress.Namespace,
+		"hosts":     ingress.Hosts,
+		"backends":  ingress.Backends,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Records) == 0 {
+		return nil, fmt.Errorf("ingress node not found")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "i")
+	if err != nil {
+		return nil, err
+	}
+
+	newIngress := &types.Ingress{
+		UID: &itemNode.ElementId,
+	}
+
+	if nameAny, ok := itemNode.Props["name"]; ok {
+		name := nameAny.(string)
+		newIngress.Name = &name
+	}
+	if linkAny, ok := itemNode.Props["link"]; ok {
+		link := linkAny.(string)
+		newIngress.Link = &link
+	}
+	if namespaceAny, ok := itemNode.Props["namespace"]; ok {
+		namespace := namespaceAny.(string)
+		newIngress.Namespace = &namespace
+	}
+	if hostsAny, ok := itemNode.Props["hosts"]; ok {
+		for _, valueAny := range hostsAny.([]any) {
+			newIngress.Hosts = append(newIngress.Hosts, valueAny.(string))
+		}
+	}
+	if backendsAny, ok := itemNode.Props["backends"]; ok {
+		for _, valueAny := range backendsAny.([]any) {
+			newIngress.Backends = append(newIngress.Backends, valueAny.(string))
+		}
+	}
+
+	return newIngress, nil
+}
+
+func (n *neo4jKubernetesRepo) GetServiceByIngressHost(ctx context.Context, host string) (string, error) {
+	query := `MATCH
+	(i:Ingress)-[:IN]->(s:Service)
+	WHERE $host IN i.hosts
+	RETURN s
+	LIMIT 1
+	`
+
+	args := map[string]any{
+		"host": host,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return "", err
+	}
+
+	if len(res.Records) == 0 {
+		return "", fmt.Errorf("service not found for ingress host: %s", host)
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "s")
+	if err != nil {
+		return "", err
+	}
+
+	nameAny, ok := itemNode.Props["name"]
+	if !ok {
+		return "", fmt.Errorf("service without name for ingress host: %s", host)
+	}
+
+	return nameAny.(string), nil
+}
@@ -0,0 +1,597 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"vislab/storage"
+	"vislab/storage/neo4j/types"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+type (
+	neo4jPipelineRepo struct {
+		db neo4j.DriverWithContext
+	}
+)
+
+func (n *Neo4jStorage) Pipeline() storage.PipelineRepository {
+	if n.pipelineRepo != nil {
+		return n.pipelineRepo
+	}
+
+	n.pipelineRepo = &neo4jPipelineRepo{db: n.db}
+	return n.pipelineRepo
+}
+
+func (n *neo4jPipelineRepo) Create(ctx context.Context, pipeline *types.Pipeline) (string, error) {
+	query := `CREATE
+	(p:Pipeline {
+		name: $name,
+		link: $link,
+		stages: $stages
+	})
+	RETURN p
+	`
+
+	args := map[string]any{
+		"name":   pipeline.Name,
+		"link":   pipeline.Link,
+		"stages": pipeline.Stages,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return "", err
+	}
+
+	if len(res.Records) == 0 {
+		return "", fmt.Errorf("pipeline node not created")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "p")
+	if err != nil {
+		return "", err
+	}
+
+	return itemNode.ElementId, nil
+}
+
+func (n *neo4jPipelineRepo) Get(ctx context.Context, serviceUid string) ([]*types.Pipeline, error) {
+	query := `MATCH
+	(p:Pipeline)-[:IN]->(s:Service)
+	WHERE elementId(s) = $uid
+	RETURN p
+	`
+
+	args := map[string]any{
+		"uid": serviceUid,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	pipelines := []*types.Pipeline{}
+
+	if len(res.Records) == 0 {
+		return pipelines, nil
+	}
+
+	for _, record := range res.Records {
+		itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](record, "p")
+		if err != nil {
+			return nil, err
+		}
+
+		pipeline := &types.Pipeline{
+			UID: &itemNode.ElementId,
+		}
+
+		if nameAny, ok := itemNode.Props["name"]; ok {
+			name := nameAny.(string)
+			pipeline.Name = &name
+		}
+		if linkAny, ok := itemNode.Props["link"]; ok {
+			link := linkAny.(string)
+			pipeline.Link = &link
+		}
+		if stagesAny, ok := itemNode.Props["stages"]; ok {
+			for _, valueAny := range stagesAny.([]any) {
+				pipeline.Stages = append(pipeline.Stages, valueAny.(string))
+			}
+		}
+
+		pipelines = append(pipelines, pipeline)
+	}
+
+	return pipelines, nil
+}
+
+func (n *neo4jPipelineRepo) Delete(ctx context.Context, uid string) error {
+	query := `MATCH
+	(p:Pipeline)
+	WHERE elementId(p) = $uid
+	DETACH DELETE p
+	`
+
+	args := map[string]any{
+		"uid": uid,
+	}
+
+	_, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (n *neo4jPipelineRepo) Update(ctx context.Context, pipeline *types.Pipeline) (*types.Pipeline, error) {
+	query := `MATCH
+	(p:Pipeline)
+	WHERE elementId(p) = $uid
+	SET
+	`
+
+	params := []string{}
+
+	if pipeline.UID == nil {
+		return nil, fmt.Errorf("pipeline cannot be updated, uid field is required")
+	}
+	if pipeline.Name != nil {
+		params = append(params, "p.name = $name")
+	}
+	if pipeline.Link != nil {
+		params = append(params, "p.link = $link")
+	}
+	if pipeline.Stages != nil {
+		params = append(params, "p.stages = $stages")
+	}
+
+	if len(params) == 0 {
+		return nil, fmt.Errorf("noting to update")
+	}
+
+	query += strings.Join(params, ", ")
+	query += " RETURN p"
+
+	args := map[string]any{
+		"uid":    pipeline.UID,
+		"name":   pipeline.Name,
+		"link":   pipeline.Link,
+		"stages": pipeline.Stages,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Records) == 0 {
+		return nil, fmt.Errorf("pipeline node not found")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "p")
+	if err != nil {
+		return nil, err
+	}
+
+	new := &types.Pipeline{
+		UID: &itemNode.ElementId,
+	}
+
+	if nameAny, ok := itemNode.Props["name"]; ok {
+		name := nameAny.(string)
+		new.Name = &name
+	}
+	if linkAny, ok := itemNode.Props["link"]; ok {
+		link := linkAny.(string)
+		new.Link = &link
+	}
+	if stagesAny, ok := itemNode.Props["stages"]; ok {
+		for _, valueAny := range stagesAny.([]any) {
+			new.Stages = append(new.Stages, valueAny.(string))
+		}
+	}
+
+	return new, nil
+}
+
+func (n *neo4jPipelineRepo) CreateJob(ctx context.Context, pipelineJob *types.PipelineJob) (string, error) {
+	query := `CREATE
+	(pj:PipelineJob {
+		name: $name,
+		link: $link,
+		stage: $stage,
+		image: $image,
+		environment: $environment,
+		deploys: $deploys,
+		migrates: $migrates,
+		publishesImage: $publishesImage
+	})
+	RETURN pj
+	`
+
+	args := map[string]any{
+		"name":           pipelineJob.Name,
+		"link":           pipelineJob.Link,
+		"stage":          pipelineJob.Stage,
+		"image":          pipelineJob.Image,
+		"environment":    pipelineJob.Environment,
+		"deploys":        pipelineJob.Deploys,
+		"migrates":       pipelineJob.Migrates,
+		"publishesImage": pipelineJob.PublishesImage,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return "", err
+	}
+
+	if len(res.Records) == 0 {
+		return "", fmt.Errorf("pipeline job node not created")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "pj")
+	if err != nil {
+		return "", err
+	}
+
+	return itemNode.ElementId, nil
+}
+
+func (n *neo4jPipelineRepo) GetJobs(ctx context.Context, pipelineUid string) ([]*types.PipelineJob, error) {
+	query := `MATCH
+	(pj:PipelineJob)-[:IN]->(p:Pipeline)
+	WHERE elementId(p) = $uid
+	RETURN pj
+	`
+
+	args := map[string]any{
+		"uid": pipelineUid,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	pipelineJobs := []*types.PipelineJob{}
+
+	if len(res.Records) == 0 {
+		return pipelineJobs, nil
+	}
+
+	for _, record := range res.Records {
+		itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](record, "pj")
+		if err != nil {
+			return nil, err
+		}
+
+		pipelineJob := &types.PipelineJob{
+			UID: &itemNode.ElementId,
+		}
+
+		if nameAny, ok := itemNode.Props["name"]; ok {
+			name := nameAny.(string)
+			pipelineJob.Name = &name
+		}
+		if linkAny, ok := itemNode.Props["link"]; ok {
+			link := linkAny.(string)
+			pipelineJob.Link = &link
+		}
+		if stageAny, ok := itemNode.Props["stage"]; ok {
+			stage := stageAny.(string)
+			pipelineJob.Stage = &stage
+		}
+		if imageAny, ok := itemNode.Props["image"]; ok {
+			image := imageAny.(string)
+			pipelineJob.Image = &image
+		}
+		if environmentAny, ok := itemNode.Props["environment"]; ok {
+			environment := environmentAny.(string)
+			pipelineJob.Environment = &environment
+		}
+		if deploysAny, ok := itemNode.Props["deploys"]; ok {
+			deploys := deploysAny.(bool)
+			pipelineJob.Deploys = &deploys
+		}
+		if migratesAny, ok := itemNode.Props["migrates"]; ok {
+			migrates := migratesAny.(bool)
+			pipelineJob.Migrates = &migrates
+		}
+		if publishesImageAny, ok := itemNode.Props["publishesImage"]; ok {
+			publishesImage := publishesImageAny.(bool)
+			pipelineJob.PublishesImage = &publishesImage
+		}
+
+		pipelineJobs = append(pipelineJobs, pipelineJob)
+	}
+
+	return pipelineJobs, nil
+}
+
+func (n *neo4jPipelineRepo) DeleteJob(ctx context.Context, uid string) error {
+	query := `MATCH
+	(pj:PipelineJob)
+	WHERE elementId(pj) = $uid
+	DETACH DELETE pj
+	`
+
+	args := map[string]any{
+		"uid": uid,
+	}
+
+	_, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (n *neo4jPipelineRepo) UpdateJob(ctx context.Context, pipelineJob *types.PipelineJob) (*types.PipelineJob, error) {
+	query := `MATCH
+	(pj:PipelineJob)
+	WHERE elementId(pj) = $uid
+	SET
+	`
+
+	params := []string{}
+
+	if pipelineJob.UID == nil {
+		return nil, fmt.Errorf("pipeline job cannot be updated, uid field is required")
+	}
+	if pipelineJob.Name != nil {
+		params = append(params, "pj.name = $name")
+	}
+	if pipelineJob.Link != nil {
+		params = append(params, "pj.link = $link")
+	}
+	if pipelineJob.Stage != nil {
+		params = append(params, "pj.stage = $stage")
+	}
+	if pipelineJob.Image != nil {
+		params = append(params, "pj.image = $image")
+	}
+	if pipelineJob.Environment != nil {
+		params = append(params, "pj.environment = $environment")
+	}
+	if pipelineJob.Deploys != nil {
+		params = append(params, "pj.deploys = $deploys")
+	}
+	if pipelineJob.Migrates != nil {
+		params = append(params, "pj.migrates = $migrates")
+	}
+	if pipelineJob.PublishesImage != nil {
+		params = append(params, "pj.publishesImage = $publishesImage")
+	}
+
+	if len(params) == 0 {
+		return nil, fmt.Errorf("noting to update")
+	}
+
+	query += strings.Join(params, ", ")
+	query += " RETURN pj"
+
+	args := map[string]any{
+		"uid":            pipelineJob.UID,
+		"name":           pipelineJob.Name,
+		"link":           pipelineJob.Link,
+		"stage":          pipelineJob.Stage,
+		"image":          pipelineJob.Image,
+		"environment":    pipelineJob.Environment,
+		"deploys":        pipelineJob.Deploys,
+		"migrates":       pipelineJob.Migrates,
+		"publishesImage": pipelineJob.PublishesImage,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Records) == 0 {
+		return nil, fmt.Errorf("pipeline job node not found")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "pj")
+	if err != nil {
+		return nil, err
+	}
+
+	newJob := &types.PipelineJob{
+		UID: &itemNode.ElementId,
+	}
+
+	if nameAny, ok := itemNode.Props["name"]; ok {
+		name := nameAny.(string)
+		newJob.Name = &name
+	}
+	if linkAny, ok := itemNode.Props["link"]; ok {
+		link := linkAny.(string)
+		newJob.Link = &link
+	}
+	if stageAny, ok := itemNode.Props["stage"]; ok {
+		stage := stageAny.(string)
+		newJob.Stage = &stage
+	}
+	if imageAny, ok := itemNode.Props["image"]; ok {
+		image := imageAny.(string)
+		newJob.Image = &image
+	}
+	if environmentAny, ok := itemNode.Props["environment"]; ok {
+		environment := environmentAny.(string)
+		newJob.Environment = &environment
+	}
+	if deploysAny, ok := itemNode.Props["deploys"]; ok {
+		deploys := deploysAny.(bool)
+		newJob.Deploys = &deploys
+	}
+	if migratesAny, ok := itemNode.Props["migrates"]; ok {
+		migrates := migratesAny.(bool)
+		newJob.Migrates = &migrates
+	}
+	if publishesImageAny, ok := itemNode.Props["publishesImage"]; ok {
+		publishesImage := publishesImageAny.(bool)
+		newJob.PublishesImage = &publishesImage
+	}
+
+	return newJob, nil
+}
+
+func (n *neo4jPipelineRepo) CreateTemplate(ctx context.Context, pipelineTemplate *types.PipelineTemplate) (string, error) {
+	query := `CREATE
+	(pt:PipelineTemplate {
+		name: $name,
+		link: $link
+	})
+	RETURN pt
+	`
+
+	args := map[string]any{
+		"name": pipelineTemplate.Name,
+		"link": pipelineTemplate.Link,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return "", err
+	}
+
+	if len(res.Records) == 0 {
+		return "", fmt.Errorf("pipeline template node not created")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "pt")
+	if err != nil {
+		return "", err
+	}
+
+	return itemNode.ElementId, nil
+}
+
+func (n *neo4jPipelineRepo) GetTemplate(ctx context.Context, name string) (*types.PipelineTemplate, error) {
+	query := `MATCH
+	(pt:PipelineTemplate)
+	WHERE pt.name = $name
+	RETURN pt
+	`
+
+	args := map[string]any{
+		"name": name,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Records) == 0 {
+		return nil, fmt.Errorf("pipeline template not found: %s", name)
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "pt")
+	if err != nil {
+		return nil, err
+	}
+
+	pipelineTemplate := &types.PipelineTemplate{
+		UID: &itemNode.ElementId,
+	}
+
+	if nameAny, ok := itemNode.Props["name"]; ok {
+		name := nameAny.(string)
+		pipelineTemplate.Name = &name
+	}
+	if linkAny, ok := itemNode.Props["link"]; ok {
+		link := linkAny.(string)
+		pipelineTemplate.Link = &link
+	}
+
+	return pipelineTemplate, nil
+}
+
+func (n *neo4jPipelineRepo) DeleteTemplate(ctx context.Context, uid string) error {
+	query := `MATCH
+	(pt:PipelineTemplate)
+	WHERE elementId(pt) = $uid
+	DETACH DELETE pt
+	`
+
+	args := map[string]any{
+		"uid": uid,
+	}
+
+	_, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (n *neo4jPipelineRepo) UpdateTemplate(ctx context.Context, pipelineTemplate *types.PipelineTemplate) (*types.PipelineTemplate, error) {
+	query := `MATCH
+	(pt:PipelineTemplate)
+	WHERE elementId(pt) = $uid
+	SET
+	`
+
+	params := []string{}
+
+	if pipelineTemplate.UID == nil {
+		return nil, fmt.Errorf("pipeline template cannot be updated, uid field is required")
+	}
+	if pipelineTemplate.Name != nil {
+		params = append(params, "pt.name = $name")
+	}
+	if pipelineTemplate.Link != nil {
+		params = append(params, "pt.link = $link")
+	}
+
+	if len(params) == 0 {
+		return nil, fmt.Errorf("noting to update")
+	}
+
+	query += strings.Join(params, ", ")
+	query += " RETURN pt"
+
+	args := map[string]any{
+		"uid":  pipelineTemplate.UID,
+		"name": pipelineTemplate.Name,
+		"link": pipelineTemplate.Link,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Records) == 0 {
+		return nil, fmt.Errorf("pipeline template node not found")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "pt")
+	if err != nil {
+		return nil, err
+	}
+
+	newTemplate := &types.PipelineTemplate{
+		UID: &itemNode.ElementId,
+	}
+
+	if nameAny, ok := itemNode.Props["name"]; ok {
+		name := nameAny.(string)
+		newTemplate.Name = &name
+	}
+	if linkAny, ok := itemNode.Props["link"]; ok {
+		link := linkAny.(string)
+		newTemplate.Link = &link
+	}
+
+	return newTemplate, nil
+}
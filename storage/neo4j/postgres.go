@@ -119,13 +119,17 @@ func (n *neo4jPostgresRepo) CreateScheme(ctx context.Context, scheme *types.Post
 func (n *neo4jPostgresRepo) CreateTable(ctx context.Context, table *types.PostgresqlTable) (string, error) {
 	query := `CREATE
 	(pt:PostgresTable {
-		name: $name
+		name: $name,
+		type: $type,
+		owner: $owner
 	})
 	RETURN pt
 	`
 
 	args := map[string]any{
-		"name": table.Name,
+		"name":  table.Name,
+		"type":  table.Type,
+		"owner": table.Owner,
 	}
 
 	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
@@ -312,6 +316,14 @@ func (n *neo4jPostgresRepo) GetTables(ctx context.Context, schemeUID string) ([]
 			name := nameAny.(string)
 			table.Name = &name
 		}
+		if typeAny, ok := itemNode.Props["type"]; ok {
+			typ := typeAny.(string)
+			table.Type = &typ
+		}
+		if ownerAny, ok := itemNode.Props["owner"]; ok {
+			owner := ownerAny.(string)
+			table.Owner = &owner
+		}
 
 		tables = append(tables, table)
 	}
@@ -578,8 +590,17 @@ func (n *neo4jPostgresRepo) UpdateTable(ctx context.Context, table *types.Postgr
 
 	params := []string{}
 
-	if table.Name == nil {
-		return nil, fmt.Errorf("postgres table cannot be updated, name field is required")
+	if table.UID == nil {
+		return nil, fmt.Errorf("postgres table cannot be updated, uid field is required")
+	}
+	if table.Name != nil {
+		params = append(params, "pt.name = $name")
+	}
+	if table.Type != nil {
+		params = append(params, "pt.type = $type")
+	}
+	if table.Owner != nil {
+		params = append(params, "pt.owner = $owner")
 	}
 
 	if len(params) == 0 {
@@ -590,7 +611,10 @@ func (n *neo4jPostgresRepo) UpdateTable(ctx context.Context, table *types.Postgr
 	query += " RETURN pt"
 
 	args := map[string]any{
-		"uid": table.Name,
+		"uid":   table.UID,
+		"name":  table.Name,
+		"type":  table.Type,
+		"owner": table.Owner,
 	}
 
 	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
@@ -615,6 +639,14 @@ func (n *neo4jPostgresRepo) UpdateTable(ctx context.Context, table *types.Postgr
 		name := nameAny.(string)
 		newTable.Name = &name
 	}
+	if typeAny, ok := itemNode.Props["type"]; ok {
+		typ := typeAny.(string)
+		newTable.Type = &typ
+	}
+	if ownerAny, ok := itemNode.Props["owner"]; ok {
+		owner := ownerAny.(string)
+		newTable.Owner = &owner
+	}
 
 	return newTable, nil
 }
@@ -0,0 +1,185 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"vislab/storage/neo4j/types"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+func (n *neo4jPostgresRepo) CreateRole(ctx context.Context, role *types.PostgresqlRole) (string, error) {
+	query := `CREATE
+	(pr:PostgresRole {
+		name: $name,
+		login: $login,
+		memberOf: $memberOf
+	})
+	RETURN pr
+	`
+
+	args := map[string]any{
+		"name":     role.Name,
+		"login":    role.Login,
+		"memberOf": role.MemberOf,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return "", err
+	}
+
+	if len(res.Records) == 0 {
+		return "", fmt.Errorf("postgres role node not created")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "pr")
+	if err != nil {
+		return "", err
+	}
+
+	return itemNode.ElementId, nil
+}
+
+func (n *neo4jPostgresRepo) GetRoles(ctx context.Context, postgresUid string) ([]*types.PostgresqlRole, error) {
+	query := `MATCH
+	(pr:PostgresRole)-[:IN]->(p:Postgres)
+	WHERE elementId(p) = $uid
+	RETURN pr
+	`
+
+	args := map[string]any{
+		"uid": postgresUid,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := []*types.PostgresqlRole{}
+
+	if len(res.Records) == 0 {
+		return roles, nil
+	}
+
+	for _, record := range res.Records {
+		itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](record, "pr")
+		if err != nil {
+			return nil, err
+		}
+
+		role := &types.PostgresqlRole{
+			UID: &itemNode.ElementId,
+		}
+
+		if nameAny, ok := itemNode.Props["name"]; ok {
+			name := nameAny.(string)
+			role.Name = &name
+		}
+		if loginAny, ok := itemNode.Props["login"]; ok {
+			login := loginAny.(bool)
+			role.Login = &login
+		}
+		if memberOfAny, ok := itemNode.Props["memberOf"]; ok {
+			for _, valueAny := range memberOfAny.([]any) {
+				role.MemberOf = append(role.MemberOf, valueAny.(string))
+			}
+		}
+
+		roles = append(roles, role)
+	}
+
+	return roles, nil
+}
+
+func (n *neo4jPostgresRepo) DeleteRole(ctx context.Context, uid string) error {
+	query := `MATCH
+	(pr:PostgresRole)
+	WHERE elementId(pr) = $uid
+	DETACH DELETE pr
+	`
+
+	args := map[string]any{
+		"uid": uid,
+	}
+
+	_, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (n *neo4jPostgresRepo) UpdateRole(ctx context.Context, role *types.PostgresqlRole) (*types.PostgresqlRole, error) {
+	query := `MATCH
+	(pr:PostgresRole)
+	WHERE elementId(pr) = $uid
+	SET
+	`
+
+	params := []string{}
+
+	if role.UID == nil {
+		return nil, fmt.Errorf("postgres role cannot be updated, uid field is required")
+	}
+	if role.Name != nil {
+		params = append(params, "pr.name = $name")
+	}
+	if role.Login != nil {
+		params = append(params, "pr.login = $login")
+	}
+	if role.MemberOf != nil {
+		params = append(params, "pr.memberOf = $memberOf")
+	}
+
+	if len(params) == 0 {
+		return nil, fmt.Errorf("noting to update")
+	}
+
+	query += strings.Join(params, ", ")
+	query += " RETURN pr"
+
+	args := map[string]any{
+		"uid":      role.UID,
+		"name":     role.Name,
+		"login":    role.Login,
+		"memberOf": role.MemberOf,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Records) == 0 {
+		return nil, fmt.Errorf("postgres role node not found")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "pr")
+	if err != nil {
+		return nil, err
+	}
+
+	newRole := &types.PostgresqlRole{
+		UID: &itemNode.ElementId,
+	}
+
+	if nameAny, ok := itemNode.Props["name"]; ok {
+		name := nameAny.(string)
+		newRole.Name = &name
+	}
+	if loginAny, ok := itemNode.Props["login"]; ok {
+		login := loginAny.(bool)
+		newRole.Login = &login
+	}
+	if memberOfAny, ok := itemNode.Props["memberOf"]; ok {
+		for _, valueAny := range memberOfAny.([]any) {
+			newRole.MemberOf = append(newRole.MemberOf, valueAny.(string))
+		}
+	}
+
+	return newRole, nil
+}
@@ -0,0 +1,1330 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"vislab/storage/neo4j/types"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+func (n *neo4jPostgresRepo) CreateColumn(ctx context.Context, column *types.PostgresqlColumn) (string, error) {
+	query := `CREATE
+	(pc:PostgresColumn {
+		name: $name,
+		type: $type,
+		constraints: $constraints
+	})
+	RETURN pc
+	`
+
+	args := map[string]any{
+		"name":        column.Name,
+		"type":        column.Type,
+		"constraints": column.Constraints,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return "", err
+	}
+
+	if len(res.Records) == 0 {
+		return "", fmt.Errorf("postgres column node not created")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "pc")
+	if err != nil {
+		return "", err
+	}
+
+	return itemNode.ElementId, nil
+}
+
+func (n *neo4jPostgresRepo) GetColumns(ctx context.Context, tableUid string) ([]*types.PostgresqlColumn, error) {
+	query := `MATCH
+	(pc:PostgresColumn)-[:IN]->(pt:PostgresTable)
+	WHERE elementId(pt) = $uid
+	RETURN pc
+	`
+
+	args := map[string]any{
+		"uid": tableUid,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := []*types.PostgresqlColumn{}
+
+	if len(res.Records) == 0 {
+		return columns, nil
+	}
+
+	for _, record := range res.Records {
+		itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](record, "pc")
+		if err != nil {
+			return nil, err
+		}
+
+		column := &types.PostgresqlColumn{
+			UID: &itemNode.ElementId,
+		}
+
+		if nameAny, ok := itemNode.Props["name"]; ok {
+			name := nameAny.(string)
+			column.Name = &name
+		}
+		if typeAny, ok := itemNode.Props["type"]; ok {
+			typ := typeAny.(string)
+			column.Type = &typ
+		}
+		if constraintsAny, ok := itemNode.Props["constraints"]; ok {
+			for _, constraintAny := range constraintsAny.([]any) {
+				column.Constraints = append(column.Constraints, constraintAny.(string))
+			}
+		}
+
+		columns = append(columns, column)
+	}
+
+	return columns, nil
+}
+
+func (n *neo4jPostgresRepo) DeleteColumn(ctx context.Context, uid string) error {
+	query := `MATCH
+	(pc:PostgresColumn)
+	WHERE elementId(pc) = $uid
+	DETACH DELETE pc
+	`
+
+	args := map[string]any{
+		"uid": uid,
+	}
+
+	_, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (n *neo4jPostgresRepo) UpdateColumn(ctx context.Context, column *types.PostgresqlColumn) (*types.PostgresqlColumn, error) {
+	query := `MATCH
+	(pc:PostgresColumn)
+	WHERE elementId(pc) = $uid
+	SET
+	`
+
+	params := []string{}
+
+	if column.UID == nil {
+		return nil, fmt.Errorf("postgres column cannot be updated, uid field is required")
+	}
+	if column.Name != nil {
+		params = append(params, "pc.name = $name")
+	}
+	if column.Type != nil {
+		params = append(params, "pc.type = $type")
+	}
+	if column.Constraints != nil {
+		params = append(params, "pc.constraints = $constraints")
+	}
+
+	if len(params) == 0 {
+		return nil, fmt.Errorf("noting to update")
+	}
+
+	query += strings.Join(params, ", ")
+	query += " RETURN pc"
+
+	args := map[string]any{
+		"uid":         column.UID,
+		"name":        column.Name,
+		"type":        column.Type,
+		"constraints": column.Constraints,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Records) == 0 {
+		return nil, fmt.Errorf("postgres column node not found")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "pc")
+	if err != nil {
+		return nil, err
+	}
+
+	newColumn := &types.PostgresqlColumn{
+		UID: &itemNode.ElementId,
+	}
+
+	if nameAny, ok := itemNode.Props["name"]; ok {
+		name := nameAny.(string)
+		newColumn.Name = &name
+	}
+	if typeAny, ok := itemNode.Props["type"]; ok {
+		typ := typeAny.(string)
+		newColumn.Type = &typ
+	}
+	if constraintsAny, ok := itemNode.Props["constraints"]; ok {
+		for _, constraintAny := range constraintsAny.([]any) {
+			newColumn.Constraints = append(newColumn.Constraints, constraintAny.(string))
+		}
+	}
+
+	return newColumn, nil
+}
+
+func (n *neo4jPostgresRepo) CreateIndex(ctx context.Context, index *types.PostgresqlIndex) (string, error) {
+	query := `CREATE
+	(pi:PostgresIndex {
+		name: $name,
+		columns: $columns,
+		unique: $unique
+	})
+	RETURN pi
+	`
+
+	args := map[string]any{
+		"name":    index.Name,
+		"columns": index.Columns,
+		"unique":  index.Unique,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return "", err
+	}
+
+	if len(res.Records) == 0 {
+		return "", fmt.Errorf("postgres index node not created")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "pi")
+	if err != nil {
+		return "", err
+	}
+
+	return itemNode.ElementId, nil
+}
+
+func (n *neo4jPostgresRepo) GetIndexes(ctx context.Context, tableUid string) ([]*types.PostgresqlIndex, error) {
+	query := `MATCH
+	(pi:PostgresIndex)-[:IN]->(pt:PostgresTable)
+	WHERE elementId(pt) = $uid
+	RETURN pi
+	`
+
+	args := map[string]any{
+		"uid": tableUid,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	indexs := []*types.PostgresqlIndex{}
+
+	if len(res.Records) == 0 {
+		return indexs, nil
+	}
+
+	for _, record := range res.Records {
+		itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](record, "pi")
+		if err != nil {
+			return nil, err
+		}
+
+		index := &types.PostgresqlIndex{
+			UID: &itemNode.ElementId,
+		}
+
+		if nameAny, ok := itemNode.Props["name"]; ok {
+			name := nameAny.(string)
+			index.Name = &name
+		}
+		if columnsAny, ok := itemNode.Props["columns"]; ok {
+			for _, columnAny := range columnsAny.([]any) {
+				index.Columns = append(index.Columns, columnAny.(string))
+			}
+		}
+		if uniqueAny, ok := itemNode.Props["unique"]; ok {
+			unique := uniqueAny.(bool)
+			index.Unique = &unique
+		}
+
+		indexs = append(indexs, index)
+	}
+
+	return indexs, nil
+}
+
+func (n *neo4jPostgresRepo) DeleteIndex(ctx context.Context, uid string) error {
+	query := `MATCH
+	(pi:PostgresIndex)
+	WHERE elementId(pi) = $uid
+	DETACH DELETE pi
+	`
+
+	args := map[string]any{
+		"uid": uid,
+	}
+
+	_, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (n *neo4jPostgresRepo) UpdateIndex(ctx context.Context, index *types.PostgresqlIndex) (*types.PostgresqlIndex, error) {
+	query := `MATCH
+	(pi:PostgresIndex)
+	WHERE elementId(pi) = $uid
+	SET
+	`
+
+	params := []string{}
+
+	if index.UID == nil {
+		return nil, fmt.Errorf("postgres index cannot be updated, uid field is required")
+	}
+	if index.Name != nil {
+		params = append(params, "pi.name = $name")
+	}
+	if index.Columns != nil {
+		params = append(params, "pi.columns = $columns")
+	}
+	if index.Unique != nil {
+		params = append(params, "pi.unique = $unique")
+	}
+
+	if len(params) == 0 {
+		return nil, fmt.Errorf("noting to update")
+	}
+
+	query += strings.Join(params, ", ")
+	query += " RETURN pi"
+
+	args := map[string]any{
+		"uid":     index.UID,
+		"name":    index.Name,
+		"columns": index.Columns,
+		"unique":  index.Unique,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Records) == 0 {
+		return nil, fmt.Errorf("postgres index node not found")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "pi")
+	if err != nil {
+		return nil, err
+	}
+
+	newIndex := &types.PostgresqlIndex{
+		UID: &itemNode.ElementId,
+	}
+
+	if nameAny, ok := itemNode.Props["name"]; ok {
+		name := nameAny.(string)
+		newIndex.Name = &name
+	}
+	if columnsAny, ok := itemNode.Props["columns"]; ok {
+		for _, columnAny := range columnsAny.([]any) {
+			newIndex.Columns = append(newIndex.Columns, columnAny.(string))
+		}
+	}
+	if uniqueAny, ok := itemNode.Props["unique"]; ok {
+		unique := uniqueAny.(bool)
+		newIndex.Unique = &unique
+	}
+
+	return newIndex, nil
+}
+
+func (n *neo4jPostgresRepo) CreateTrigger(ctx context.Context, trigger *types.PostgresqlTrigger) (string, error) {
+	query := `CREATE
+	(ptr:PostgresTrigger {
+		name: $name,
+		function: $function
+	})
+	RETURN ptr
+	`
+
+	args := map[string]any{
+		"name":     trigger.Name,
+		"function": trigger.Function,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return "", err
+	}
+
+	if len(res.Records) == 0 {
+		return "", fmt.Errorf("postgres trigger node not created")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "ptr")
+	if err != nil {
+		return "", err
+	}
+
+	return itemNode.ElementId, nil
+}
+
+func (n *neo4jPostgresRepo) GetTriggers(ctx context.Context, tableUid string) ([]*types.PostgresqlTrigger, error) {
+	query := `MATCH
+	(ptr:PostgresTrigger)-[:IN]->(pt:PostgresTable)
+	WHERE elementId(pt) = $uid
+	RETURN ptr
+	`
+
+	args := map[string]any{
+		"uid": tableUid,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	triggers := []*types.PostgresqlTrigger{}
+
+	if len(res.Records) == 0 {
+		return triggers, nil
+	}
+
+	for _, record := range res.Records {
+		itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](record, "ptr")
+		if err != nil {
+			return nil, err
+		}
+
+		trigger := &types.PostgresqlTrigger{
+			UID: &itemNode.ElementId,
+		}
+
+		if nameAny, ok := itemNode.Props["name"]; ok {
+			name := nameAny.(string)
+			trigger.Name = &name
+		}
+		if functionAny, ok := itemNode.Props["function"]; ok {
+			function := functionAny.(string)
+			trigger.Function = &function
+		}
+
+		triggers = append(triggers, trigger)
+	}
+
+	return triggers, nil
+}
+
+func (n *neo4jPostgresRepo) DeleteTrigger(ctx context.Context, uid string) error {
+	query := `MATCH
+	(ptr:PostgresTrigger)
+	WHERE elementId(ptr) = $uid
+	DETACH DELETE ptr
+	`
+
+	args := map[string]any{
+		"uid": uid,
+	}
+
+	_, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (n *neo4jPostgresRepo) UpdateTrigger(ctx context.Context, trigger *types.PostgresqlTrigger) (*types.PostgresqlTrigger, error) {
+	query := `MATCH
+	(ptr:PostgresTrigger)
+	WHERE elementId(ptr) = $uid
+	SET
+	`
+
+	params := []string{}
+
+	if trigger.UID == nil {
+		return nil, fmt.Errorf("postgres trigger cannot be updated, uid field is required")
+	}
+	if trigger.Name != nil {
+		params = append(params, "ptr.name = $name")
+	}
+	if trigger.Function != nil {
+		params = append(params, "ptr.function = $function")
+	}
+
+	if len(params) == 0 {
+		return nil, fmt.Errorf("noting to update")
+	}
+
+	query += strings.Join(params, ", ")
+	query += " RETURN ptr"
+
+	args := map[string]any{
+		"uid":      trigger.UID,
+		"name":     trigger.Name,
+		"function": trigger.Function,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Records) == 0 {
+		return nil, fmt.Errorf("postgres trigger node not found")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "ptr")
+	if err != nil {
+		return nil, err
+	}
+
+	newTrigger := &types.PostgresqlTrigger{
+		UID: &itemNode.ElementId,
+	}
+
+	if nameAny, ok := itemNode.Props["name"]; ok {
+		name := nameAny.(string)
+		newTrigger.Name = &name
+	}
+	if functionAny, ok := itemNode.Props["function"]; ok {
+		function := functionAny.(string)
+		newTrigger.Function = &function
+	}
+
+	return newTrigger, nil
+}
+
+func (n *neo4jPostgresRepo) CreateFunction(ctx context.Context, function *types.PostgresqlFunction) (string, error) {
+	query := `CREATE
+	(pf:PostgresFunction {
+		name: $name,
+		args: $args
+	})
+	RETURN pf
+	`
+
+	args := map[string]any{
+		"name": function.Name,
+		"args": function.Args,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return "", err
+	}
+
+	if len(res.Records) == 0 {
+		return "", fmt.Errorf("postgres function node not created")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "pf")
+	if err != nil {
+		return "", err
+	}
+
+	return itemNode.ElementId, nil
+}
+
+func (n *neo4jPostgresRepo) GetFunctions(ctx context.Context, schemeUid string) ([]*types.PostgresqlFunction, error) {
+	query := `MATCH
+	(pf:PostgresFunction)-[:IN]->(ps:PostgresScheme)
+	WHERE elementId(ps) = $uid
+	RETURN pf
+	`
+
+	args := map[string]any{
+		"uid": schemeUid,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	functions := []*types.PostgresqlFunction{}
+
+	if len(res.Records) == 0 {
+		return functions, nil
+	}
+
+	for _, record := range res.Records {
+		itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](record, "pf")
+		if err != nil {
+			return nil, err
+		}
+
+		function := &types.PostgresqlFunction{
+			UID: &itemNode.ElementId,
+		}
+
+		if nameAny, ok := itemNode.Props["name"]; ok {
+			name := nameAny.(string)
+			function.Name = &name
+		}
+		if argsAny, ok := itemNode.Props["args"]; ok {
+			for _, argAny := range argsAny.([]any) {
+				function.Args = append(function.Args, argAny.(string))
+			}
+		}
+
+		functions = append(functions, function)
+	}
+
+	return functions, nil
+}
+
+func (n *neo4jPostgresRepo) DeleteFunction(ctx context.Context, uid string) error {
+	query := `MATCH
+	(pf:PostgresFunction)
+	WHERE elementId(pf) = $uid
+	DETACH DELETE pf
+	`
+
+	args := map[string]any{
+		"uid": uid,
+	}
+
+	_, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (n *neo4jPostgresRepo) UpdateFunction(ctx context.Context, function *types.PostgresqlFunction) (*types.PostgresqlFunction, error) {
+	query := `MATCH
+	(pf:PostgresFunction)
+	WHERE elementId(pf) = $uid
+	SET
+	`
+
+	params := []string{}
+
+	if function.UID == nil {
+		return nil, fmt.Errorf("postgres function cannot be updated, uid field is required")
+	}
+	if function.Name != nil {
+		params = append(params, "pf.name = $name")
+	}
+	if function.Args != nil {
+		params = append(params, "pf.args = $args")
+	}
+
+	if len(params) == 0 {
+		return nil, fmt.Errorf("noting to update")
+	}
+
+	query += strings.Join(params, ", ")
+	query += " RETURN pf"
+
+	args := map[string]any{
+		"uid":  function.UID,
+		"name": function.Name,
+		"args": function.Args,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Records) == 0 {
+		return nil, fmt.Errorf("postgres function node not found")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "pf")
+	if err != nil {
+		return nil, err
+	}
+
+	newFunction := &types.PostgresqlFunction{
+		UID: &itemNode.ElementId,
+	}
+
+	if nameAny, ok := itemNode.Props["name"]; ok {
+		name := nameAny.(string)
+		newFunction.Name = &name
+	}
+	if argsAny, ok := itemNode.Props["args"]; ok {
+		for _, argAny := range argsAny.([]any) {
+			newFunction.Args = append(newFunction.Args, argAny.(string))
+		}
+	}
+
+	return newFunction, nil
+}
+
+func (n *neo4jPostgresRepo) CreateEnum(ctx context.Context, enum *types.PostgresqlEnum) (string, error) {
+	query := `CREATE
+	(pe:PostgresEnum {
+		name: $name,
+		values: $values
+	})
+	RETURN pe
+	`
+
+	args := map[string]any{
+		"name":   enum.Name,
+		"values": enum.Values,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return "", err
+	}
+
+	if len(res.Records) == 0 {
+		return "", fmt.Errorf("postgres enum node not created")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "pe")
+	if err != nil {
+		return "", err
+	}
+
+	return itemNode.ElementId, nil
+}
+
+func (n *neo4jPostgresRepo) GetEnums(ctx context.Context, schemeUid string) ([]*types.PostgresqlEnum, error) {
+	query := `MATCH
+	(pe:PostgresEnum)-[:IN]->(ps:PostgresScheme)
+	WHERE elementId(ps) = $uid
+	RETURN pe
+	`
+
+	args := map[string]any{
+		"uid": schemeUid,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	enums := []*types.PostgresqlEnum{}
+
+	if len(res.Records) == 0 {
+		return enums, nil
+	}
+
+	for _, record := range res.Records {
+		itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](record, "pe")
+		if err != nil {
+			return nil, err
+		}
+
+		enum := &types.PostgresqlEnum{
+			UID: &itemNode.ElementId,
+		}
+
+		if nameAny, ok := itemNode.Props["name"]; ok {
+			name := nameAny.(string)
+			enum.Name = &name
+		}
+		if valuesAny, ok := itemNode.Props["values"]; ok {
+			for _, valueAny := range valuesAny.([]any) {
+				enum.Values = append(enum.Values, valueAny.(string))
+			}
+		}
+
+		enums = append(enums, enum)
+	}
+
+	return enums, nil
+}
+
+func (n *neo4jPostgresRepo) DeleteEnum(ctx context.Context, uid string) error {
+	query := `MATCH
+	(pe:PostgresEnum)
+	WHERE elementId(pe) = $uid
+	DETACH DELETE pe
+	`
+
+	args := map[string]any{
+		"uid": uid,
+	}
+
+	_, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (n *neo4jPostgresRepo) UpdateEnum(ctx context.Context, enum *types.PostgresqlEnum) (*types.PostgresqlEnum, error) {
+	query := `MATCH
+	(pe:PostgresEnum)
+	WHERE elementId(pe) = $uid
+	SET
+	`
+
+	params := []string{}
+
+	if enum.UID == nil {
+		return nil, fmt.Errorf("postgres enum cannot be updated, uid field is required")
+	}
+	if enum.Name != nil {
+		params = append(params, "pe.name = $name")
+	}
+	if enum.Values != nil {
+		params = append(params, "pe.values = $values")
+	}
+
+	if len(params) == 0 {
+		return nil, fmt.Errorf("noting to update")
+	}
+
+	query += strings.Join(params, ", ")
+	query += " RETURN pe"
+
+	args := map[string]any{
+		"uid":    enum.UID,
+		"name":   enum.Name,
+		"values": enum.Values,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Records) == 0 {
+		return nil, fmt.Errorf("postgres enum node not found")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "pe")
+	if err != nil {
+		return nil, err
+	}
+
+	newEnum := &types.PostgresqlEnum{
+		UID: &itemNode.ElementId,
+	}
+
+	if nameAny, ok := itemNode.Props["name"]; ok {
+		name := nameAny.(string)
+		newEnum.Name = &name
+	}
+	if valuesAny, ok := itemNode.Props["values"]; ok {
+		for _, valueAny := range valuesAny.([]any) {
+			newEnum.Values = append(newEnum.Values, valueAny.(string))
+		}
+	}
+
+	return newEnum, nil
+}
+
+func (n *neo4jPostgresRepo) CreateView(ctx context.Context, view *types.PostgresqlView) (string, error) {
+	query := `CREATE
+	(pv:PostgresView {
+		name: $name,
+		materialized: $materialized
+	})
+	RETURN pv
+	`
+
+	args := map[string]any{
+		"name":         view.Name,
+		"materialized": view.Materialized,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return "", err
+	}
+
+	if len(res.Records) == 0 {
+		return "", fmt.Errorf("postgres view node not created")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "pv")
+	if err != nil {
+		return "", err
+	}
+
+	return itemNode.ElementId, nil
+}
+
+func (n *neo4jPostgresRepo) GetViews(ctx context.Context, schemeUid string) ([]*types.PostgresqlView, error) {
+	query := `MATCH
+	(pv:PostgresView)-[:IN]->(ps:PostgresScheme)
+	WHERE elementId(ps) = $uid
+	RETURN pv
+	`
+
+	args := map[string]any{
+		"uid": schemeUid,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	views := []*types.PostgresqlView{}
+
+	if len(res.Records) == 0 {
+		return views, nil
+	}
+
+	for _, record := range res.Records {
+		itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](record, "pv")
+		if err != nil {
+			return nil, err
+		}
+
+		view := &types.PostgresqlView{
+			UID: &itemNode.ElementId,
+		}
+
+		if nameAny, ok := itemNode.Props["name"]; ok {
+			name := nameAny.(string)
+			view.Name = &name
+		}
+		if materializedAny, ok := itemNode.Props["materialized"]; ok {
+			materialized := materializedAny.(bool)
+			view.Materialized = &materialized
+		}
+
+		views = append(views, view)
+	}
+
+	return views, nil
+}
+
+func (n *neo4jPostgresRepo) DeleteView(ctx context.Context, uid string) error {
+	query := `MATCH
+	(pv:PostgresView)
+	WHERE elementId(pv) = $uid
+	DETACH DELETE pv
+	`
+
+	args := map[string]any{
+		"uid": uid,
+	}
+
+	_, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (n *neo4jPostgresRepo) UpdateView(ctx context.Context, view *types.PostgresqlView) (*types.PostgresqlView, error) {
+	query := `MATCH
+	(pv:PostgresView)
+	WHERE elementId(pv) = $uid
+	SET
+	`
+
+	params := []string{}
+
+	if view.UID == nil {
+		return nil, fmt.Errorf("postgres view cannot be updated, uid field is required")
+	}
+	if view.Name != nil {
+		params = append(params, "pv.name = $name")
+	}
+	if view.Materialized != nil {
+		params = append(params, "pv.materialized = $materialized")
+	}
+
+	if len(params) == 0 {
+		return nil, fmt.Errorf("noting to update")
+	}
+
+	query += strings.Join(params, ", ")
+	query += " RETURN pv"
+
+	args := map[string]any{
+		"uid":          view.UID,
+		"name":         view.Name,
+		"materialized": view.Materialized,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Records) == 0 {
+		return nil, fmt.Errorf("postgres view node not found")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "pv")
+	if err != nil {
+		return nil, err
+	}
+
+	newView := &types.PostgresqlView{
+		UID: &itemNode.ElementId,
+	}
+
+	if nameAny, ok := itemNode.Props["name"]; ok {
+		name := nameAny.(string)
+		newView.Name = &name
+	}
+	if materializedAny, ok := itemNode.Props["materialized"]; ok {
+		materialized := materializedAny.(bool)
+		newView.Materialized = &materialized
+	}
+
+	return newView, nil
+}
+
+func (n *neo4jPostgresRepo) CreateExtension(ctx context.Context, extension *types.PostgresqlExtension) (string, error) {
+	query := `CREATE
+	(pe:PostgresExtension {
+		name: $name,
+		scheme: $scheme
+	})
+	RETURN pe
+	`
+
+	args := map[string]any{
+		"name":   extension.Name,
+		"scheme": extension.Scheme,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return "", err
+	}
+
+	if len(res.Records) == 0 {
+		return "", fmt.Errorf("postgres extension node not created")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "pe")
+	if err != nil {
+		return "", err
+	}
+
+	return itemNode.ElementId, nil
+}
+
+func (n *neo4jPostgresRepo) GetExtensions(ctx context.Context, dbUid string) ([]*types.PostgresqlExtension, error) {
+	query := `MATCH
+	(pe:PostgresExtension)-[:IN]->(pd:PostgresDB)
+	WHERE elementId(pd) = $uid
+	RETURN pe
+	`
+
+	args := map[string]any{
+		"uid": dbUid,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	extensions := []*types.PostgresqlExtension{}
+
+	if len(res.Records) == 0 {
+		return extensions, nil
+	}
+
+	for _, record := range res.Records {
+		itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](record, "pe")
+		if err != nil {
+			return nil, err
+		}
+
+		extension := &types.PostgresqlExtension{
+			UID: &itemNode.ElementId,
+		}
+
+		if nameAny, ok := itemNode.Props["name"]; ok {
+			name := nameAny.(string)
+			extension.Name = &name
+		}
+		if schemeAny, ok := itemNode.Props["scheme"]; ok {
+			scheme := schemeAny.(string)
+			extension.Scheme = &scheme
+		}
+
+		extensions = append(extensions, extension)
+	}
+
+	return extensions, nil
+}
+
+func (n *neo4jPostgresRepo) DeleteExtension(ctx context.Context, uid string) error {
+	query := `MATCH
+	(pe:PostgresExtension)
+	WHERE elementId(pe) = $uid
+	DETACH DELETE pe
+	`
+
+	args := map[string]any{
+		"uid": uid,
+	}
+
+	_, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (n *neo4jPostgresRepo) UpdateExtension(ctx context.Context, extension *types.PostgresqlExtension) (*types.PostgresqlExtension, error) {
+	query := `MATCH
+	(pe:PostgresExtension)
+	WHERE elementId(pe) = $uid
+	SET
+	`
+
+	params := []string{}
+
+	if extension.UID == nil {
+		return nil, fmt.Errorf("postgres extension cannot be updated, uid field is required")
+	}
+	if extension.Name != nil {
+		params = append(params, "pe.name = $name")
+	}
+	if extension.Scheme != nil {
+		params = append(params, "pe.scheme = $scheme")
+	}
+
+	if len(params) == 0 {
+		return nil, fmt.Errorf("noting to update")
+	}
+
+	query += strings.Join(params, ", ")
+	query += " RETURN pe"
+
+	args := map[string]any{
+		"uid":    extension.UID,
+		"name":   extension.Name,
+		"scheme": extension.Scheme,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Records) == 0 {
+		return nil, fmt.Errorf("postgres extension node not found")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "pe")
+	if err != nil {
+		return nil, err
+	}
+
+	newExtension := &types.PostgresqlExtension{
+		UID: &itemNode.ElementId,
+	}
+
+	if nameAny, ok := itemNode.Props["name"]; ok {
+		name := nameAny.(string)
+		newExtension.Name = &name
+	}
+	if schemeAny, ok := itemNode.Props["scheme"]; ok {
+		scheme := schemeAny.(string)
+		newExtension.Scheme = &scheme
+	}
+
+	return newExtension, nil
+}
+
+func (n *neo4jPostgresRepo) CreateSequence(ctx context.Context, sequence *types.PostgresqlSequence) (string, error) {
+	query := `CREATE
+	(pq:PostgresSequence {
+		name: $name
+	})
+	RETURN pq
+	`
+
+	args := map[string]any{
+		"name": sequence.Name,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return "", err
+	}
+
+	if len(res.Records) == 0 {
+		return "", fmt.Errorf("postgres sequence node not created")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "pq")
+	if err != nil {
+		return "", err
+	}
+
+	return itemNode.ElementId, nil
+}
+
+func (n *neo4jPostgresRepo) GetSequences(ctx context.Context, schemeUid string) ([]*types.PostgresqlSequence, error) {
+	query := `MATCH
+	(pq:PostgresSequence)-[:IN]->(ps:PostgresScheme)
+	WHERE elementId(ps) = $uid
+	RETURN pq
+	`
+
+	args := map[string]any{
+		"uid": schemeUid,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	sequences := []*types.PostgresqlSequence{}
+
+	if len(res.Records) == 0 {
+		return sequences, nil
+	}
+
+	for _, record := range res.Records {
+		itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](record, "pq")
+		if err != nil {
+			return nil, err
+		}
+
+		sequence := &types.PostgresqlSequence{
+			UID: &itemNode.ElementId,
+		}
+
+		if nameAny, ok := itemNode.Props["name"]; ok {
+			name := nameAny.(string)
+			sequence.Name = &name
+		}
+
+		sequences = append(sequences, sequence)
+	}
+
+	return sequences, nil
+}
+
+func (n *neo4jPostgresRepo) DeleteSequence(ctx context.Context, uid string) error {
+	query := `MATCH
+	(pq:PostgresSequence)
+	WHERE elementId(pq) = $uid
+	DETACH DELETE pq
+	`
+
+	args := map[string]any{
+		"uid": uid,
+	}
+
+	_, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (n *neo4jPostgresRepo) UpdateSequence(ctx context.Context, sequence *types.PostgresqlSequence) (*types.PostgresqlSequence, error) {
+	query := `MATCH
+	(pq:PostgresSequence)
+	WHERE elementId(pq) = $uid
+	SET
+	`
+
+	params := []string{}
+
+	if sequence.UID == nil {
+		return nil, fmt.Errorf("postgres sequence cannot be updated, uid field is required")
+	}
+	if sequence.Name != nil {
+		params = append(params, "pq.name = $name")
+	}
+
+	if len(params) == 0 {
+		return nil, fmt.Errorf("noting to update")
+	}
+
+	query += strings.Join(params, ", ")
+	query += " RETURN pq"
+
+	args := map[string]any{
+		"uid":  sequence.UID,
+		"name": sequence.Name,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Records) == 0 {
+		return nil, fmt.Errorf("postgres sequence node not found")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "pq")
+	if err != nil {
+		return nil, err
+	}
+
+	newSequence := &types.PostgresqlSequence{
+		UID: &itemNode.ElementId,
+	}
+
+	if nameAny, ok := itemNode.Props["name"]; ok {
+		name := nameAny.(string)
+		newSequence.Name = &name
+	}
+
+	return newSequence, nil
+}
+
+func (n *neo4jPostgresRepo) CreateReference(ctx context.Context, fromTableUid, toTableUid string, reference *types.PostgresqlReference) error {
+	query := fmt.Sprintf(`MATCH
+	(from:PostgresTable),
+	(to:PostgresTable)
+	WHERE elementId(from) = $fromUid and elementId(to) = $toUid
+	MERGE
+	(from)-[r:%s {name: $name}]->(to)
+	SET r.columns = $columns, r.refColumns = $refColumns
+	`, types.ConnReferences)
+
+	args := map[string]any{
+		"fromUid":    fromTableUid,
+		"toUid":      toTableUid,
+		"name":       reference.Name,
+		"columns":    reference.Columns,
+		"refColumns": reference.RefColumns,
+	}
+
+	_, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
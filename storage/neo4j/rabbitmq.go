@@ -59,13 +59,19 @@ func (n *neo4jRabbitRepo) Create(ctx context.Context, rabbit *types.RabbitMQ) (s
 func (n *neo4jRabbitRepo) CreateQueue(ctx context.Context, queue *types.RabbitQueue) (string, error) {
 	query := `CREATE
 	(rq:RabbitQueue {
-		name: $name
+		name: $name,
+		queueType: $queueType,
+		exchange: $exchange,
+		routingKey: $routingKey
 	})
 	RETURN rq
 	`
 
 	args := map[string]any{
-		"name": queue.Name,
+		"name":       queue.Name,
+		"queueType":  queue.QueueType,
+		"exchange":   queue.Exchange,
+		"routingKey": queue.RoutingKey,
 	}
 
 	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
@@ -166,6 +172,18 @@ func (n *neo4jRabbitRepo) GetQueues(ctx context.Context, rabbitUid string) ([]*t
 			name := nameAny.(string)
 			queue.Name = &name
 		}
+		if queueTypeAny, ok := itemNode.Props["queueType"]; ok {
+			queueType := queueTypeAny.(string)
+			queue.QueueType = &queueType
+		}
+		if exchangeAny, ok := itemNode.Props["exchange"]; ok {
+			exchange := exchangeAny.(string)
+			queue.Exchange = &exchange
+		}
+		if routingKeyAny, ok := itemNode.Props["routingKey"]; ok {
+			routingKey := routingKeyAny.(string)
+			queue.RoutingKey = &routingKey
+		}
 
 		queues = append(queues, queue)
 	}
@@ -290,6 +308,15 @@ func (n *neo4jRabbitRepo) UpdateQueue(ctx context.Context, queue *types.RabbitQu
 	if queue.Name != nil {
 		params = append(params, "rq.name = $name")
 	}
+	if queue.QueueType != nil {
+		params = append(params, "rq.queueType = $queueType")
+	}
+	if queue.Exchange != nil {
+		params = append(params, "rq.exchange = $exchange")
+	}
+	if queue.RoutingKey != nil {
+		params = append(params, "rq.routingKey = $routingKey")
+	}
 
 	if len(params) == 0 {
 		return nil, fmt.Errorf("nothing to update")
@@ -299,8 +326,11 @@ func (n *neo4jRabbitRepo) UpdateQueue(ctx context.Context, queue *types.RabbitQu
 	query += " RETURN rq"
 
 	args := map[string]any{
-		"uid":  queue.UID,
-		"name": queue.Name,
+		"uid":        queue.UID,
+		"name":       queue.Name,
+		"queueType":  queue.QueueType,
+		"exchange":   queue.Exchange,
+		"routingKey": queue.RoutingKey,
 	}
 
 	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
@@ -325,6 +355,18 @@ func (n *neo4jRabbitRepo) UpdateQueue(ctx context.Context, queue *types.RabbitQu
 		name := nameAny.(string)
 		newRabbitQueue.Name = &name
 	}
+	if queueTypeAny, ok := itemNode.Props["queueType"]; ok {
+		queueType := queueTypeAny.(string)
+		newRabbitQueue.QueueType = &queueType
+	}
+	if exchangeAny, ok := itemNode.Props["exchange"]; ok {
+		exchange := exchangeAny.(string)
+		newRabbitQueue.Exchange = &exchange
+	}
+	if routingKeyAny, ok := itemNode.Props["routingKey"]; ok {
+		routingKey := routingKeyAny.(string)
+		newRabbitQueue.RoutingKey = &routingKey
+	}
 
 	return newRabbitQueue, nil
 }
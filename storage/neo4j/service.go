@@ -44,15 +44,25 @@ func (n *neo4jServiceRepo) Create(ctx context.Context, service *types.Service) (
 	(s:Service {
 		name: $name,
 		group: $group,
-		fullName: $fullName
+		fullName: $fullName,
+		origin: $origin,
+		external: $external,
+		language: $language,
+		status: $status
 	})
+	SET s += $languages
 	RETURN s
 	`
 
 	args := map[string]any{
-		"name":     service.Name,
-		"group":    service.Group,
-		"fullName": service.FullName,
+		"name":      service.Name,
+		"group":     service.Group,
+		"fullName":  service.FullName,
+		"origin":    service.Origin,
+		"external":  service.External,
+		"language":  service.Language,
+		"status":    service.Status,
+		"languages": languageProps(service.Languages),
 	}
 
 	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
@@ -101,15 +111,16 @@ func (n *neo4jServiceRepo) CreatePort(ctx context.Context, port *types.ServicePo
 	return itemNode.ElementId, nil
 }
 
-func (n *neo4jServiceRepo) Get(ctx context.Context, name string) (*types.Service, error) {
+func (n *neo4jServiceRepo) Get(ctx context.Context, name, origin string) (*types.Service, error) {
 	query := `MATCH
 	(s:Service)
-	WHERE s.name = $name
+	WHERE s.name = $name AND coalesce(s.origin, '') = $origin
 	RETURN s
 	`
 
 	args := map[string]any{
-		"name": name,
+		"name":   name,
+		"origin": origin,
 	}
 
 	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
@@ -151,10 +162,27 @@ func (n *neo4jServiceRepo) Get(ctx context.Context, name string) (*types.Service
 		fullName := fullNameAny.(string)
 		service.FullName = &fullName
 	}
+	if originAny, ok := itemNode.Props["origin"]; ok {
+		origin := originAny.(string)
+		service.Origin = &origin
+	}
 	if groupAny, ok := itemNode.Props["group"]; ok {
 		group := groupAny.(string)
 		service.Group = &group
 	}
+	if externalAny, ok := itemNode.Props["external"]; ok {
+		external := externalAny.(bool)
+		service.External = &external
+	}
+	if languageAny, ok := itemNode.Props["language"]; ok {
+		language := languageAny.(string)
+		service.Language = &language
+	}
+	if statusAny, ok := itemNode.Props["status"]; ok {
+		status := statusAny.(string)
+		service.Status = &status
+	}
+	service.Languages = parseLanguageProps(itemNode.Props)
 
 	return service, nil
 }
@@ -243,7 +271,7 @@ func (n *neo4jServiceRepo) DeletePort(ctx context.Context, uid string) error {
 func (n *neo4jServiceRepo) Update(ctx context.Context, service *types.Service) (*types.Service, error) {
 	query := `MATCH
 	(s:Service)
-	WHERE s.name = $name
+	WHERE s.name = $name AND coalesce(s.origin, '') = $origin
 	SET
 	`
 
@@ -258,15 +286,53 @@ func (n *neo4jServiceRepo) Update(ctx context.Context, service *types.Service) (
 	if service.Group != nil {
 		params = append(params, "s.group = $group")
 	}
+	if service.External != nil {
+		params = append(params, "s.external = $external")
+	}
+	if service.Language != nil {
+		params = append(params, "s.language = $language")
+	}
+	if service.Status != nil {
+		params = append(params, "s.status = $status")
+	}
+	if len(service.Languages) != 0 {
+		params = append(params, "s += $languages")
+	}
 
 	if len(params) == 0 {
 		return nil, fmt.Errorf("nothing to update")
 	}
 
+	origin := ""
+	if service.Origin != nil {
+		origin = *service.Origin
+	}
+
+	languages := languageProps(service.Languages)
+	if len(service.Languages) != 0 {
+		current, err := n.Get(ctx, *service.Name, origin)
+		if err != nil {
+			return nil, err
+		}
+
+		// setting a property to null removes it, languages no longer used
+		// by the repository would stay on the node otherwise
+		for language := range current.Languages {
+			if _, ok := service.Languages[language]; !ok {
+				languages[types.LanguagePropPrefix+language] = nil
+			}
+		}
+	}
+
 	args := map[string]any{
-		"name":     service.Name,
-		"fullName": service.FullName,
-		"group":    service.Group,
+		"name":      service.Name,
+		"origin":    origin,
+		"fullName":  service.FullName,
+		"group":     service.Group,
+		"external":  service.External,
+		"language":  service.Language,
+		"status":    service.Status,
+		"languages": languages,
 	}
 
 	query += strings.Join(params, ", ")
@@ -298,10 +364,27 @@ func (n *neo4jServiceRepo) Update(ctx context.Context, service *types.Service) (
 		fullName := fullNameAny.(string)
 		newService.FullName = &fullName
 	}
+	if originAny, ok := itemNode.Props["origin"]; ok {
+		origin := originAny.(string)
+		newService.Origin = &origin
+	}
 	if groupAny, ok := itemNode.Props["group"]; ok {
 		group := groupAny.(string)
 		newService.Group = &group
 	}
+	if externalAny, ok := itemNode.Props["external"]; ok {
+		external := externalAny.(bool)
+		newService.External = &external
+	}
+	if languageAny, ok := itemNode.Props["language"]; ok {
+		language := languageAny.(string)
+		newService.Language = &language
+	}
+	if statusAny, ok := itemNode.Props["status"]; ok {
+		status := statusAny.(string)
+		newService.Status = &status
+	}
+	newService.Languages = parseLanguageProps(itemNode.Props)
 
 	return newService, nil
 }
@@ -353,3 +436,35 @@ func (n *neo4jServiceRepo) UpdatePort(ctx context.Context, port *types.ServicePo
 
 	return newPort, nil
 }
+
+func languageProps(languages map[string]float64) map[string]any {
+	props := map[string]any{}
+	for language, share := range languages {
+		props[types.LanguagePropPrefix+language] = share
+	}
+
+	return props
+}
+
+func parseLanguageProps(props map[string]any) map[string]float64 {
+	var languages map[string]float64
+
+	for key, valueAny := range props {
+		language, ok := strings.CutPrefix(key, types.LanguagePropPrefix)
+		if !ok {
+			continue
+		}
+
+		share, ok := valueAny.(float64)
+		if !ok {
+			continue
+		}
+
+		if languages == nil {
+			languages = map[string]float64{}
+		}
+		languages[language] = share
+	}
+
+	return languages
+}
@@ -0,0 +1,142 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+	"vislab/storage"
+	"vislab/storage/neo4j/types"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+type (
+	neo4jSharingRepo struct {
+		db neo4j.DriverWithContext
+	}
+)
+
+func (n *Neo4jStorage) Sharing() storage.SharingRepository {
+	if n.sharingRepo != nil {
+		return n.sharingRepo
+	}
+
+	n.sharingRepo = &neo4jSharingRepo{db: n.db}
+	return n.sharingRepo
+}
+
+func (n *neo4jSharingRepo) GetTableUsages(ctx context.Context) ([]*types.TableUsage, error) {
+	query := fmt.Sprintf(`MATCH
+	(s:Service)-[c:%s|%s|%s|%s|%s]-(pt:PostgresTable)-[:IN]->(ps:PostgresScheme)-[:IN]->(pd:PostgresDB)-[:IN]->(p:Postgres)
+	RETURN DISTINCT
+	elementId(s) AS serviceUid,
+	s.name AS serviceName,
+	s.origin AS serviceOrigin,
+	type(c) AS connType,
+	elementId(pt) AS tableUid,
+	pt.name AS table,
+	elementId(ps) AS schemeUid,
+	ps.name AS scheme,
+	elementId(pd) AS databaseUid,
+	pd.name AS database,
+	p.host AS host
+	`, types.ConnMigrates, types.ConnUses, types.ConnReads, types.ConnWrites, types.ConnDummy)
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, nil, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	usages := []*types.TableUsage{}
+
+	for _, record := range res.Records {
+		usage := &types.TableUsage{}
+
+		connType, _, err := neo4j.GetRecordValue[string](record, "connType")
+		if err != nil {
+			return nil, err
+		}
+		usage.ConnType = types.ConnType(connType)
+
+		if serviceUidAny, ok := record.Get("serviceUid"); ok && serviceUidAny != nil {
+			serviceUid := serviceUidAny.(string)
+			usage.ServiceUID = &serviceUid
+		}
+		if serviceNameAny, ok := record.Get("serviceName"); ok && serviceNameAny != nil {
+			serviceName := serviceNameAny.(string)
+			usage.ServiceName = &serviceName
+		}
+		if serviceOriginAny, ok := record.Get("serviceOrigin"); ok && serviceOriginAny != nil {
+			serviceOrigin := serviceOriginAny.(string)
+			usage.ServiceOrigin = &serviceOrigin
+		}
+		if tableUidAny, ok := record.Get("tableUid"); ok && tableUidAny != nil {
+			tableUid := tableUidAny.(string)
+			usage.TableUID = &tableUid
+		}
+		if tableAny, ok := record.Get("table"); ok && tableAny != nil {
+			table := tableAny.(string)
+			usage.Table = &table
+		}
+		if schemeUidAny, ok := record.Get("schemeUid"); ok && schemeUidAny != nil {
+			schemeUid := schemeUidAny.(string)
+			usage.SchemeUID = &schemeUid
+		}
+		if schemeAny, ok := record.Get("scheme"); ok && schemeAny != nil {
+			scheme := schemeAny.(string)
+			usage.Scheme = &scheme
+		}
+		if databaseUidAny, ok := record.Get("databaseUid"); ok && databaseUidAny != nil {
+			databaseUid := databaseUidAny.(string)
+			usage.DatabaseUID = &databaseUid
+		}
+		if databaseAny, ok := record.Get("database"); ok && databaseAny != nil {
+			database := databaseAny.(string)
+			usage.Database = &database
+		}
+		if hostAny, ok := record.Get("host"); ok && hostAny != nil {
+			host := hostAny.(string)
+			usage.Host = &host
+		}
+
+		usages = append(usages, usage)
+	}
+
+	return usages, nil
+}
+
+func (n *neo4jSharingRepo) Set(ctx context.Context, resource *types.SharedResource) error {
+	query := fmt.Sprintf(`MATCH
+	(n:%s)
+	WHERE elementId(n) = $uid
+	SET n.shared = $shared, n.owners = $owners, n.sharedWith = $users
+	`, resource.Node.Class)
+
+	args := map[string]any{
+		"uid":    resource.Node.ID,
+		"shared": resource.Shared(),
+		"owners": resource.Owners,
+		"users":  resource.Users,
+	}
+
+	_, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (n *neo4jSharingRepo) ResetAll(ctx context.Context) error {
+	query := `MATCH
+	(n)
+	WHERE (n:PostgresTable OR n:PostgresScheme OR n:PostgresDB) AND n.shared IS NOT NULL
+	REMOVE n.shared, n.owners, n.sharedWith
+	`
+
+	_, err := neo4j.ExecuteQuery(ctx, n.db, query, nil, neo4j.EagerResultTransformer)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
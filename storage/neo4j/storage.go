@@ -2,10 +2,14 @@ package neo4j
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"log/slog"
+	"strings"
 	"sync"
 	"time"
 	myConf "vislab/config"
+	"vislab/libs/tlsconf"
 	"vislab/storage"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
@@ -14,15 +18,18 @@ import (
 )
 
 type Neo4jStorage struct {
-	db          neo4j.DriverWithContext
-	serviceRepo storage.ServiceRepository
-	redisRepo   storage.RedisRepository
-	// teamRepo    storage.TeamRepository
-	// pipelineRepo storage.PipelineRepository
-	connRepo     storage.ConnectionRepository
-	postgresRepo storage.PostgresRepository
-	kafkaRepo    storage.KafkaRepository
-	rabbitRepo   storage.RabbitMQRepository
+	db             neo4j.DriverWithContext
+	serviceRepo    storage.ServiceRepository
+	redisRepo      storage.RedisRepository
+	connRepo       storage.ConnectionRepository
+	postgresRepo   storage.PostgresRepository
+	kafkaRepo      storage.KafkaRepository
+	rabbitRepo     storage.RabbitMQRepository
+	flowRepo       storage.FlowRepository
+	sharingRepo    storage.SharingRepository
+	kubernetesRepo storage.KubernetesRepository
+	pipelineRepo   storage.PipelineRepository
+	teamRepo       storage.TeamRepository
 }
 
 var (
@@ -34,15 +41,24 @@ func MustStorage(ctx context.Context, myConf *myConf.StorageConfig) (*Neo4jStora
 	var err error
 
 	neo4jOnce.Do(func() {
-		dbUri := fmt.Sprintf("%s://%s:%s", myConf.Name, myConf.Host, myConf.Port)
+		var (
+			dbUri     string
+			tlsConfig *tls.Config
+		)
 
-		logger := func(conf *config.Config) {
+		dbUri, tlsConfig, err = connectionURI(myConf)
+		if err != nil {
+			return
+		}
+
+		driverConfig := func(conf *config.Config) {
 			conf.Log = log.ToConsole(log.INFO)
+			conf.TlsConfig = tlsConfig
 		}
 
 		var db neo4j.DriverWithContext
 
-		db, err = neo4j.NewDriverWithContext(dbUri, neo4j.BasicAuth(myConf.User, myConf.Password, ""), logger)
+		db, err = neo4j.NewDriverWithContext(dbUri, neo4j.BasicAuth(myConf.User, myConf.Password, ""), driverConfig)
 		if err != nil {
 			return
 		}
@@ -66,6 +82,35 @@ func MustStorage(ctx context.Context, myConf *myConf.StorageConfig) (*Neo4jStora
 	return neo4jInstance, err
 }
 
+// connectionURI returns the uri with the scheme from the config name, with a
+// tls block the scheme gets the encrypted variant of it.
+func connectionURI(myConf *myConf.StorageConfig) (string, *tls.Config, error) {
+	scheme := myConf.Name
+
+	if myConf.TLS == nil {
+		return fmt.Sprintf("%s://%s:%s", scheme, myConf.Host, myConf.Port), nil, nil
+	}
+
+	if strings.Contains(scheme, "+") {
+		return "", nil, fmt.Errorf("storage name %q already sets the tls mode, remove the suffix or the tls block", scheme)
+	}
+
+	tlsConfig, err := tlsconf.New(myConf.TLS)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid storage tls config: %w", err)
+	}
+
+	// the driver takes verification from the scheme only
+	if tlsconf.Verify(myConf.TLS) {
+		scheme += "+s"
+	} else {
+		slog.Warn("storage tls verification is disabled", "host", myConf.Host)
+		scheme += "+ssc"
+	}
+
+	return fmt.Sprintf("%s://%s:%s", scheme, myConf.Host, myConf.Port), tlsConfig, nil
+}
+
 func (n *Neo4jStorage) Disconnect(ctx context.Context) error {
 	err := n.db.Close(ctx)
 	if err != nil {
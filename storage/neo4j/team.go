@@ -0,0 +1,413 @@
+package neo4j
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"vislab/storage"
+	"vislab/storage/neo4j/types"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+type (
+	neo4jTeamRepo struct {
+		db neo4j.DriverWithContext
+	}
+)
+
+func (n *Neo4jStorage) Team() storage.TeamRepository {
+	if n.teamRepo != nil {
+		return n.teamRepo
+	}
+
+	n.teamRepo = &neo4jTeamRepo{db: n.db}
+	return n.teamRepo
+}
+
+func (n *neo4jTeamRepo) Create(ctx context.Context, team *types.Team) (string, error) {
+	query := `CREATE
+	(t:Team {
+		name: $name
+	})
+	RETURN t
+	`
+
+	args := map[string]any{
+		"name": team.Name,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return "", err
+	}
+
+	if len(res.Records) == 0 {
+		return "", fmt.Errorf("team node not created")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "t")
+	if err != nil {
+		return "", err
+	}
+
+	return itemNode.ElementId, nil
+}
+
+func (n *neo4jTeamRepo) Get(ctx context.Context, name string) (*types.Team, error) {
+	query := `MATCH
+	(t:Team)
+	WHERE t.name = $name
+	RETURN t
+	`
+
+	args := map[string]any{
+		"name": name,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Records) == 0 {
+		return nil, fmt.Errorf("team not found: %s", name)
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "t")
+	if err != nil {
+		return nil, err
+	}
+
+	team := &types.Team{
+		UID: &itemNode.ElementId,
+	}
+
+	if nameAny, ok := itemNode.Props["name"]; ok {
+		name := nameAny.(string)
+		team.Name = &name
+	}
+
+	return team, nil
+}
+
+func (n *neo4jTeamRepo) GetByService(ctx context.Context, serviceUid string) ([]*types.Team, error) {
+	query := fmt.Sprintf(`MATCH
+	(t:Team)-[:%s]-(s:Service)
+	WHERE elementId(s) = $uid
+	RETURN t
+	`, types.ConnOwns)
+
+	args := map[string]any{
+		"uid": serviceUid,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	teams := []*types.Team{}
+
+	for _, record := range res.Records {
+		itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](record, "t")
+		if err != nil {
+			return nil, err
+		}
+
+		team := &types.Team{
+			UID: &itemNode.ElementId,
+		}
+
+		if nameAny, ok := itemNode.Props["name"]; ok {
+			name := nameAny.(string)
+			team.Name = &name
+		}
+
+		teams = append(teams, team)
+	}
+
+	return teams, nil
+}
+
+func (n *neo4jTeamRepo) Delete(ctx context.Context, uid string) error {
+	query := `MATCH
+	(t:Team)
+	WHERE elementId(t) = $uid
+	DETACH DELETE t
+	`
+
+	args := map[string]any{
+		"uid": uid,
+	}
+
+	_, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (n *neo4jTeamRepo) Update(ctx context.Context, team *types.Team) (*types.Team, error) {
+	query := `MATCH
+	(t:Team)
+	WHERE elementId(t) = $uid
+	SET
+	`
+
+	params := []string{}
+
+	if team.UID == nil {
+		return nil, fmt.Errorf("team cannot be updated, uid field is required")
+	}
+	if team.Name != nil {
+		params = append(params, "t.name = $name")
+	}
+
+	if len(params) == 0 {
+		return nil, fmt.Errorf("noting to update")
+	}
+
+	query += strings.Join(params, ", ")
+	query += " RETURN t"
+
+	args := map[string]any{
+		"uid":  team.UID,
+		"name": team.Name,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Records) == 0 {
+		return nil, fmt.Errorf("team node not found")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "t")
+	if err != nil {
+		return nil, err
+	}
+
+	new := &types.Team{
+		UID: &itemNode.ElementId,
+	}
+
+	if nameAny, ok := itemNode.Props["name"]; ok {
+		name := nameAny.(string)
+		new.Name = &name
+	}
+
+	return new, nil
+}
+
+func (n *neo4jTeamRepo) CreateDev(ctx context.Context, dev *types.Dev) (string, error) {
+	query := `CREATE
+	(d:Dev {
+		key: $key,
+		name: $name,
+		username: $username,
+		email: $email,
+		link: $link,
+		role: $role
+	})
+	RETURN d
+	`
+
+	args := map[string]any{
+		"key":      dev.Key,
+		"name":     dev.Name,
+		"username": dev.Username,
+		"email":    dev.Email,
+		"link":     dev.Link,
+		"role":     dev.Role,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return "", err
+	}
+
+	if len(res.Records) == 0 {
+		return "", fmt.Errorf("dev node not created")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "d")
+	if err != nil {
+		return "", err
+	}
+
+	return itemNode.ElementId, nil
+}
+
+func (n *neo4jTeamRepo) GetDev(ctx context.Context, key string) (*types.Dev, error) {
+	query := `MATCH
+	(d:Dev)
+	WHERE d.key = $key
+	RETURN d
+	`
+
+	args := map[string]any{
+		"key": key,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Records) == 0 {
+		return nil, fmt.Errorf("dev not found: %s", key)
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "d")
+	if err != nil {
+		return nil, err
+	}
+
+	dev := &types.Dev{
+		UID: &itemNode.ElementId,
+	}
+
+	if keyAny, ok := itemNode.Props["key"]; ok {
+		key := keyAny.(string)
+		dev.Key = &key
+	}
+	if nameAny, ok := itemNode.Props["name"]; ok {
+		name := nameAny.(string)
+		dev.Name = &name
+	}
+	if usernameAny, ok := itemNode.Props["username"]; ok {
+		username := usernameAny.(string)
+		dev.Username = &username
+	}
+	if emailAny, ok := itemNode.Props["email"]; ok {
+		email := emailAny.(string)
+		dev.Email = &email
+	}
+	if linkAny, ok := itemNode.Props["link"]; ok {
+		link := linkAny.(string)
+		dev.Link = &link
+	}
+	if roleAny, ok := itemNode.Props["role"]; ok {
+		role := roleAny.(string)
+		dev.Role = &role
+	}
+
+	return dev, nil
+}
+
+func (n *neo4jTeamRepo) DeleteDev(ctx context.Context, uid string) error {
+	query := `MATCH
+	(d:Dev)
+	WHERE elementId(d) = $uid
+	DETACH DELETE d
+	`
+
+	args := map[string]any{
+		"uid": uid,
+	}
+
+	_, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (n *neo4jTeamRepo) UpdateDev(ctx context.Context, dev *types.Dev) (*types.Dev, error) {
+	query := `MATCH
+	(d:Dev)
+	WHERE elementId(d) = $uid
+	SET
+	`
+
+	params := []string{}
+
+	if dev.UID == nil {
+		return nil, fmt.Errorf("dev cannot be updated, uid field is required")
+	}
+	if dev.Key != nil {
+		params = append(params, "d.key = $key")
+	}
+	if dev.Name != nil {
+		params = append(params, "d.name = $name")
+	}
+	if dev.Username != nil {
+		params = append(params, "d.username = $username")
+	}
+	if dev.Email != nil {
+		params = append(params, "d.email = $email")
+	}
+	if dev.Link != nil {
+		params = append(params, "d.link = $link")
+	}
+	if dev.Role != nil {
+		params = append(params, "d.role = $role")
+	}
+
+	if len(params) == 0 {
+		return nil, fmt.Errorf("noting to update")
+	}
+
+	query += strings.Join(params, ", ")
+	query += " RETURN d"
+
+	args := map[string]any{
+		"uid":      dev.UID,
+		"key":      dev.Key,
+		"name":     dev.Name,
+		"username": dev.Username,
+		"email":    dev.Email,
+		"link":     dev.Link,
+		"role":     dev.Role,
+	}
+
+	res, err := neo4j.ExecuteQuery(ctx, n.db, query, args, neo4j.EagerResultTransformer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Records) == 0 {
+		return nil, fmt.Errorf("dev node not found")
+	}
+
+	itemNode, _, err := neo4j.GetRecordValue[neo4j.Node](res.Records[0], "d")
+	if err != nil {
+		return nil, err
+	}
+
+	newDev := &types.Dev{
+		UID: &itemNode.ElementId,
+	}
+
+	if keyAny, ok := itemNode.Props["key"]; ok {
+		key := keyAny.(string)
+		newDev.Key = &key
+	}
+	if nameAny, ok := itemNode.Props["name"]; ok {
+		name := nameAny.(string)
+		newDev.Name = &name
+	}
+	if usernameAny, ok := itemNode.Props["username"]; ok {
+		username := usernameAny.(string)
+		newDev.Username = &username
+	}
+	if emailAny, ok := itemNode.Props["email"]; ok {
+		email := emailAny.(string)
+		newDev.Email = &email
+	}
+	if linkAny, ok := itemNode.Props["link"]; ok {
+		link := linkAny.(string)
+		newDev.Link = &link
+	}
+	if roleAny, ok := itemNode.Props["role"]; ok {
+		role := roleAny.(string)
+		newDev.Role = &role
+	}
+
+	return newDev, nil
+}
@@ -14,6 +14,21 @@ const (
 	ConnSendsTo      ConnType = "SENDS_TO"
 	ConnReceivesFrom ConnType = "RECEIVES_FROM"
 	ConnUses         ConnType = "USES"
+	ConnPublishesTo  ConnType = "PUBLISHES_TO"
+	ConnExecutes     ConnType = "EXECUTES"
+	ConnOfType       ConnType = "OF_TYPE"
+	ConnReferences   ConnType = "REFERENCES"
+	ConnReads        ConnType = "READS"
+	ConnWrites       ConnType = "WRITES"
+	ConnMigrates     ConnType = "MIGRATES"
+	ConnDummy        ConnType = "dummy"
+	ConnCanRead      ConnType = "CAN_READ"
+	ConnCanWrite     ConnType = "CAN_WRITE"
+	ConnOwns         ConnType = "OWNS"
+	ConnConnectsAs   ConnType = "CONNECTS_AS"
+	ConnRoutesTo     ConnType = "ROUTES_TO"
+	ConnIncludes     ConnType = "INCLUDES"
+	ConnExtends      ConnType = "EXTENDS"
 )
 
 func (c ConnType) String() string {
@@ -0,0 +1,20 @@
+package types
+
+type FlowEndpoint struct {
+	ServiceUID  *string
+	ServiceName *string
+	BrokerUID   *string
+	ConnType    ConnType
+	Queue       *string
+	Topic       *string
+	Exchange    *string
+	RoutingKey  *string
+}
+
+type Flow struct {
+	FromUID  *string
+	ToUID    *string
+	Broker   *string
+	Topic    *string
+	Exchange *string
+}
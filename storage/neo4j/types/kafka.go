@@ -1,6 +1,9 @@
 package types
 
-import "vislab/libs/check"
+import (
+	"slices"
+	"vislab/libs/check"
+)
 
 const (
 	KafkaClass      NodeClass = "Kafka"
@@ -8,16 +11,14 @@ const (
 )
 
 type Kafka struct {
-	UID  *string
-	Name *string
-	Host *string
-	Port *int64
+	UID     *string
+	Name    *string
+	Brokers []string
 }
 
 func (k *Kafka) Equal(other *Kafka) bool {
 	return check.ComparePointers(k.Name, other.Name) &&
-		check.ComparePointers(k.Host, other.Host) &&
-		check.ComparePointers(k.Port, other.Port)
+		slices.Equal(k.Brokers, other.Brokers)
 }
 
 type KafkaQueue struct {
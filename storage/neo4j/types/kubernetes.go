@@ -0,0 +1,99 @@
+package types
+
+import (
+	"slices"
+	"vislab/libs/check"
+)
+
+const (
+	DeploymentClass        NodeClass = "Deployment"
+	CronJobClass           NodeClass = "CronJob"
+	KubernetesJobClass     NodeClass = "KubernetesJob"
+	KubernetesServiceClass NodeClass = "KubernetesService"
+	IngressClass           NodeClass = "Ingress"
+)
+
+type Deployment struct {
+	UID       *string
+	Name      *string
+	Link      *string
+	Namespace *string
+	Replicas  *int64
+	Images    []string
+	Ports     []int64
+}
+
+func (d *Deployment) Equal(other *Deployment) bool {
+	return check.ComparePointers(d.Name, other.Name) &&
+		check.ComparePointers(d.Link, other.Link) &&
+		check.ComparePointers(d.Namespace, other.Namespace) &&
+		check.ComparePointers(d.Replicas, other.Replicas) &&
+		slices.Equal(d.Images, other.Images) &&
+		slices.Equal(d.Ports, other.Ports)
+}
+
+type CronJob struct {
+	UID       *string
+	Name      *string
+	Link      *string
+	Namespace *string
+	Schedule  *string
+	Images    []string
+}
+
+func (c *CronJob) Equal(other *CronJob) bool {
+	return check.ComparePointers(c.Name, other.Name) &&
+		check.ComparePointers(c.Link, other.Link) &&
+		check.ComparePointers(c.Namespace, other.Namespace) &&
+		check.ComparePointers(c.Schedule, other.Schedule) &&
+		slices.Equal(c.Images, other.Images)
+}
+
+type KubernetesJob struct {
+	UID       *string
+	Name      *string
+	Link      *string
+	Namespace *string
+	Images    []string
+}
+
+func (k *KubernetesJob) Equal(other *KubernetesJob) bool {
+	return check.ComparePointers(k.Name, other.Name) &&
+		check.ComparePointers(k.Link, other.Link) &&
+		check.ComparePointers(k.Namespace, other.Namespace) &&
+		slices.Equal(k.Images, other.Images)
+}
+
+type KubernetesService struct {
+	UID       *string
+	Name      *string
+	Link      *string
+	Namespace *string
+	Type      *string
+	Ports     []int64
+}
+
+func (k *KubernetesService) Equal(other *KubernetesService) bool {
+	return check.ComparePointers(k.Name, other.Name) &&
+		check.ComparePointers(k.Link, other.Link) &&
+		check.ComparePointers(k.Namespace, other.Namespace) &&
+		check.ComparePointers(k.Type, other.Type) &&
+		slices.Equal(k.Ports, other.Ports)
+}
+
+type Ingress struct {
+	UID       *string
+	Name      *string
+	Link      *string
+	Namespace *string
+	Hosts     []string
+	Backends  []string
+}
+
+func (i *Ingress) Equal(other *Ingress) bool {
+	return check.ComparePointers(i.Name, other.Name) &&
+		check.ComparePointers(i.Link, other.Link) &&
+		check.ComparePointers(i.Namespace, other.Namespace) &&
+		slices.Equal(i.Hosts, other.Hosts) &&
+		slices.Equal(i.Backends, other.Backends)
+}
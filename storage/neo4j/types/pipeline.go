@@ -0,0 +1,59 @@
+package types
+
+import (
+	"slices"
+	"vislab/libs/check"
+)
+
+const (
+	PipelineClass         NodeClass = "Pipeline"
+	PipelineJobClass      NodeClass = "PipelineJob"
+	PipelineTemplateClass NodeClass = "PipelineTemplate"
+)
+
+type Pipeline struct {
+	UID    *string
+	Name   *string
+	Link   *string
+	Stages []string
+}
+
+func (p *Pipeline) Equal(other *Pipeline) bool {
+	return check.ComparePointers(p.Name, other.Name) &&
+		check.ComparePointers(p.Link, other.Link) &&
+		slices.Equal(p.Stages, other.Stages)
+}
+
+type PipelineJob struct {
+	UID            *string
+	Name           *string
+	Link           *string
+	Stage          *string
+	Image          *string
+	Environment    *string
+	Deploys        *bool
+	Migrates       *bool
+	PublishesImage *bool
+}
+
+func (p *PipelineJob) Equal(other *PipelineJob) bool {
+	return check.ComparePointers(p.Name, other.Name) &&
+		check.ComparePointers(p.Link, other.Link) &&
+		check.ComparePointers(p.Stage, other.Stage) &&
+		check.ComparePointers(p.Image, other.Image) &&
+		check.ComparePointers(p.Environment, other.Environment) &&
+		check.ComparePointers(p.Deploys, other.Deploys) &&
+		check.ComparePointers(p.Migrates, other.Migrates) &&
+		check.ComparePointers(p.PublishesImage, other.PublishesImage)
+}
+
+type PipelineTemplate struct {
+	UID  *string
+	Name *string
+	Link *string
+}
+
+func (p *PipelineTemplate) Equal(other *PipelineTemplate) bool {
+	return check.ComparePointers(p.Name, other.Name) &&
+		check.ComparePointers(p.Link, other.Link)
+}
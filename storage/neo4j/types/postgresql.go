@@ -1,12 +1,24 @@
 package types
 
-import "vislab/libs/check"
+import (
+	"slices"
+	"vislab/libs/check"
+)
 
 const (
-	PostgresClass       NodeClass = "Postgres"
-	PostgresDBClass     NodeClass = "PostgresDB"
-	PostgresSchemeClass NodeClass = "PostgresScheme"
-	PostgresTableClass  NodeClass = "PostgresTable"
+	PostgresClass          NodeClass = "Postgres"
+	PostgresDBClass        NodeClass = "PostgresDB"
+	PostgresSchemeClass    NodeClass = "PostgresScheme"
+	PostgresTableClass     NodeClass = "PostgresTable"
+	PostgresColumnClass    NodeClass = "PostgresColumn"
+	PostgresIndexClass     NodeClass = "PostgresIndex"
+	PostgresTriggerClass   NodeClass = "PostgresTrigger"
+	PostgresFunctionClass  NodeClass = "PostgresFunction"
+	PostgresEnumClass      NodeClass = "PostgresEnum"
+	PostgresViewClass      NodeClass = "PostgresView"
+	PostgresRoleClass      NodeClass = "PostgresRole"
+	PostgresExtensionClass NodeClass = "PostgresExtension"
+	PostgresSequenceClass  NodeClass = "PostgresSequence"
 )
 
 type Postgresql struct {
@@ -41,10 +53,123 @@ func (p *PostgresqlScheme) Equal(other *PostgresqlScheme) bool {
 }
 
 type PostgresqlTable struct {
+	UID   *string
+	Name  *string
+	Type  *string
+	Owner *string
+}
+
+func (p *PostgresqlTable) Equal(other *PostgresqlTable) bool {
+	return check.ComparePointers(p.Name, other.Name) &&
+		check.ComparePointers(p.Type, other.Type) &&
+		check.ComparePointers(p.Owner, other.Owner)
+}
+
+type PostgresqlColumn struct {
+	UID         *string
+	Name        *string
+	Type        *string
+	Constraints []string
+}
+
+func (p *PostgresqlColumn) Equal(other *PostgresqlColumn) bool {
+	return check.ComparePointers(p.Name, other.Name) &&
+		check.ComparePointers(p.Type, other.Type) &&
+		slices.Equal(p.Constraints, other.Constraints)
+}
+
+type PostgresqlIndex struct {
+	UID     *string
+	Name    *string
+	Columns []string
+	Unique  *bool
+}
+
+func (p *PostgresqlIndex) Equal(other *PostgresqlIndex) bool {
+	return check.ComparePointers(p.Name, other.Name) &&
+		slices.Equal(p.Columns, other.Columns) &&
+		check.ComparePointers(p.Unique, other.Unique)
+}
+
+type PostgresqlTrigger struct {
+	UID      *string
+	Name     *string
+	Function *string
+}
+
+func (p *PostgresqlTrigger) Equal(other *PostgresqlTrigger) bool {
+	return check.ComparePointers(p.Name, other.Name) &&
+		check.ComparePointers(p.Function, other.Function)
+}
+
+type PostgresqlFunction struct {
 	UID  *string
 	Name *string
+	Args []string
 }
 
-func (p *PostgresqlTable) Equal(other *PostgresqlTable) bool {
+func (p *PostgresqlFunction) Equal(other *PostgresqlFunction) bool {
+	return check.ComparePointers(p.Name, other.Name) &&
+		slices.Equal(p.Args, other.Args)
+}
+
+type PostgresqlEnum struct {
+	UID    *string
+	Name   *string
+	Values []string
+}
+
+func (p *PostgresqlEnum) Equal(other *PostgresqlEnum) bool {
+	return check.ComparePointers(p.Name, other.Name) &&
+		slices.Equal(p.Values, other.Values)
+}
+
+type PostgresqlView struct {
+	UID          *string
+	Name         *string
+	Materialized *bool
+}
+
+func (p *PostgresqlView) Equal(other *PostgresqlView) bool {
+	return check.ComparePointers(p.Name, other.Name) &&
+		check.ComparePointers(p.Materialized, other.Materialized)
+}
+
+type PostgresqlRole struct {
+	UID      *string
+	Name     *string
+	Login    *bool
+	MemberOf []string
+}
+
+func (p *PostgresqlRole) Equal(other *PostgresqlRole) bool {
+	return check.ComparePointers(p.Name, other.Name) &&
+		check.ComparePointers(p.Login, other.Login) &&
+		slices.Equal(p.MemberOf, other.MemberOf)
+}
+
+type PostgresqlExtension struct {
+	UID    *string
+	Name   *string
+	Scheme *string
+}
+
+func (p *PostgresqlExtension) Equal(other *PostgresqlExtension) bool {
+	return check.ComparePointers(p.Name, other.Name) &&
+		check.ComparePointers(p.Scheme, other.Scheme)
+}
+
+type PostgresqlSequence struct {
+	UID  *string
+	Name *string
+}
+
+func (p *PostgresqlSequence) Equal(other *PostgresqlSequence) bool {
 	return check.ComparePointers(p.Name, other.Name)
 }
+
+type PostgresqlReference struct {
+	Name       *string
+	Columns    []string
+	RefColumns []string
+}
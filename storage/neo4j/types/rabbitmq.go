@@ -21,10 +21,16 @@ func (r *RabbitMQ) Equal(other *RabbitMQ) bool {
 }
 
 type RabbitQueue struct {
-	UID  *string
-	Name *string
+	UID        *string
+	Name       *string
+	QueueType  *string
+	Exchange   *string
+	RoutingKey *string
 }
 
 func (r *RabbitQueue) Equal(other *RabbitQueue) bool {
-	return check.ComparePointers(r.Name, other.Name)
+	return check.ComparePointers(r.Name, other.Name) &&
+		check.ComparePointers(r.QueueType, other.QueueType) &&
+		check.ComparePointers(r.Exchange, other.Exchange) &&
+		check.ComparePointers(r.RoutingKey, other.RoutingKey)
 }
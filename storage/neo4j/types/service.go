@@ -1,23 +1,33 @@
 package types
 
-import "vislab/libs/check"
+import (
+	"maps"
+	"vislab/libs/check"
+)
 
 const (
 	ServiceClass     NodeClass = "Service"
 	ServicePortClass NodeClass = "ServicePort"
+
+	// LanguagePropPrefix prefixes the service properties holding the share of
+	// every project language.
+	LanguagePropPrefix = "lang_"
 )
 
 type Service struct {
 	UID         *string
 	Name        *string
 	FullName    *string `yaml:"full_name"`
+	Origin      *string
 	Link        *string
 	Group       *string
 	MainBranch  *string
 	LatestTag   *string
 	Language    *string
+	Languages   map[string]float64
 	Description *string
 	Status      *string
+	External    *bool
 }
 
 func (s Service) Equal(other *Service) bool {
@@ -25,11 +35,14 @@ func (s Service) Equal(other *Service) bool {
 		check.ComparePointers(s.Link, other.Link) &&
 		check.ComparePointers(s.Group, other.Group) &&
 		check.ComparePointers(s.FullName, other.FullName) &&
+		check.ComparePointers(s.Origin, other.Origin) &&
 		check.ComparePointers(s.MainBranch, other.MainBranch) &&
 		check.ComparePointers(s.LatestTag, other.LatestTag) &&
 		check.ComparePointers(s.Language, other.Language) &&
+		maps.Equal(s.Languages, other.Languages) &&
 		check.ComparePointers(s.Description, other.Description) &&
-		check.ComparePointers(s.Status, other.Status)
+		check.ComparePointers(s.Status, other.Status) &&
+		check.ComparePointers(s.External, other.External)
 }
 
 type ServicePort struct {
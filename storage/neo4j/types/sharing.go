@@ -0,0 +1,47 @@
+package types
+
+import "strings"
+
+type TableUsage struct {
+	ServiceUID    *string
+	ServiceName   *string
+	ServiceOrigin *string
+	ConnType      ConnType
+	TableUID      *string
+	Table         *string
+	SchemeUID     *string
+	Scheme        *string
+	DatabaseUID   *string
+	Database      *string
+	Host          *string
+}
+
+type SharedResource struct {
+	Node     ConnNode
+	Host     *string
+	Database *string
+	Scheme   *string
+	Table    *string
+	Owners   []string
+	Users    []string
+}
+
+// Shared reports whether more than one service works with the resource.
+func (s *SharedResource) Shared() bool {
+	return len(s.Owners)+len(s.Users) > 1
+}
+
+// Path joins the names from the postgres host down to the resource.
+func (s *SharedResource) Path() string {
+	parts := []string{}
+
+	for _, part := range []*string{s.Host, s.Database, s.Scheme, s.Table} {
+		if part == nil {
+			break
+		}
+
+		parts = append(parts, *part)
+	}
+
+	return strings.Join(parts, "/")
+}
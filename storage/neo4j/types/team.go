@@ -0,0 +1,37 @@
+package types
+
+import "vislab/libs/check"
+
+const (
+	TeamClass NodeClass = "Team"
+	DevClass  NodeClass = "Dev"
+)
+
+type Team struct {
+	UID  *string
+	Name *string
+}
+
+func (t *Team) Equal(other *Team) bool {
+	return check.ComparePointers(t.Name, other.Name)
+}
+
+// Dev is matched by key which is the lowercased username, email or name.
+type Dev struct {
+	UID      *string
+	Key      *string
+	Name     *string
+	Username *string
+	Email    *string
+	Link     *string
+	Role     *string
+}
+
+func (d *Dev) Equal(other *Dev) bool {
+	return check.ComparePointers(d.Key, other.Key) &&
+		check.ComparePointers(d.Name, other.Name) &&
+		check.ComparePointers(d.Username, other.Username) &&
+		check.ComparePointers(d.Email, other.Email) &&
+		check.ComparePointers(d.Link, other.Link) &&
+		check.ComparePointers(d.Role, other.Role)
+}
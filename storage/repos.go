@@ -7,7 +7,9 @@ import (
 
 type ServiceRepository interface {
 	Create(ctx context.Context, service *types.Service) (string, error)
-	Get(ctx context.Context, name string) (*types.Service, error)
+	// Get finds the service by name and origin, the scm endpoint it is
+	// collected from. Services of unnamed endpoints have an empty origin.
+	Get(ctx context.Context, name, origin string) (*types.Service, error)
 	Delete(ctx context.Context, uid string) error
 	Update(ctx context.Context, service *types.Service) (*types.Service, error)
 
@@ -19,14 +21,16 @@ type ServiceRepository interface {
 
 type KafkaRepository interface {
 	Create(ctx context.Context, kafka *types.Kafka) (string, error)
-	Get(ctx context.Context, host string) (*types.Kafka, error)
+	Find(ctx context.Context, name *string, brokers []string) ([]*types.Kafka, error)
 	Delete(ctx context.Context, uid string) error
 	Update(ctx context.Context, kafka *types.Kafka) (*types.Kafka, error)
+	Merge(ctx context.Context, intoUid, fromUid string) error
 
 	CreateQueue(ctx context.Context, kafkaQueue *types.KafkaQueue) (string, error)
 	GetQueues(ctx context.Context, kafkaUid string) ([]*types.KafkaQueue, error)
 	DeleteQueue(ctx context.Context, uid string) error
 	UpdateQueue(ctx context.Context, kafkaQueue *types.KafkaQueue) (*types.KafkaQueue, error)
+	MergeQueue(ctx context.Context, intoUid, fromUid string) error
 }
 
 type RedisRepository interface {
@@ -78,6 +82,53 @@ type PostgresRepository interface {
 	GetTables(ctx context.Context, schemeUid string) ([]*types.PostgresqlTable, error)
 	DeleteTable(ctx context.Context, uid string) error
 	UpdateTable(ctx context.Context, postgresTable *types.PostgresqlTable) (*types.PostgresqlTable, error)
+
+	CreateColumn(ctx context.Context, postgresColumn *types.PostgresqlColumn) (string, error)
+	GetColumns(ctx context.Context, tableUid string) ([]*types.PostgresqlColumn, error)
+	DeleteColumn(ctx context.Context, uid string) error
+	UpdateColumn(ctx context.Context, postgresColumn *types.PostgresqlColumn) (*types.PostgresqlColumn, error)
+
+	CreateIndex(ctx context.Context, postgresIndex *types.PostgresqlIndex) (string, error)
+	GetIndexes(ctx context.Context, tableUid string) ([]*types.PostgresqlIndex, error)
+	DeleteIndex(ctx context.Context, uid string) error
+	UpdateIndex(ctx context.Context, postgresIndex *types.PostgresqlIndex) (*types.PostgresqlIndex, error)
+
+	CreateTrigger(ctx context.Context, postgresTrigger *types.PostgresqlTrigger) (string, error)
+	GetTriggers(ctx context.Context, tableUid string) ([]*types.PostgresqlTrigger, error)
+	DeleteTrigger(ctx context.Context, uid string) error
+	UpdateTrigger(ctx context.Context, postgresTrigger *types.PostgresqlTrigger) (*types.PostgresqlTrigger, error)
+
+	CreateFunction(ctx context.Context, postgresFunction *types.PostgresqlFunction) (string, error)
+	GetFunctions(ctx context.Context, schemeUid string) ([]*types.PostgresqlFunction, error)
+	DeleteFunction(ctx context.Context, uid string) error
+	UpdateFunction(ctx context.Context, postgresFunction *types.PostgresqlFunction) (*types.PostgresqlFunction, error)
+
+	CreateEnum(ctx context.Context, postgresEnum *types.PostgresqlEnum) (string, error)
+	GetEnums(ctx context.Context, schemeUid string) ([]*types.PostgresqlEnum, error)
+	DeleteEnum(ctx context.Context, uid string) error
+	UpdateEnum(ctx context.Context, postgresEnum *types.PostgresqlEnum) (*types.PostgresqlEnum, error)
+
+	CreateView(ctx context.Context, postgresView *types.PostgresqlView) (string, error)
+	GetViews(ctx context.Context, schemeUid string) ([]*types.PostgresqlView, error)
+	DeleteView(ctx context.Context, uid string) error
+	UpdateView(ctx context.Context, postgresView *types.PostgresqlView) (*types.PostgresqlView, error)
+
+	CreateExtension(ctx context.Context, postgresExtension *types.PostgresqlExtension) (string, error)
+	GetExtensions(ctx context.Context, dbUid string) ([]*types.PostgresqlExtension, error)
+	DeleteExtension(ctx context.Context, uid string) error
+	UpdateExtension(ctx context.Context, postgresExtension *types.PostgresqlExtension) (*types.PostgresqlExtension, error)
+
+	CreateSequence(ctx context.Context, postgresSequence *types.PostgresqlSequence) (string, error)
+	GetSequences(ctx context.Context, schemeUid string) ([]*types.PostgresqlSequence, error)
+	DeleteSequence(ctx context.Context, uid string) error
+	UpdateSequence(ctx context.Context, postgresSequence *types.PostgresqlSequence) (*types.PostgresqlSequence, error)
+
+	CreateRole(ctx context.Context, postgresRole *types.PostgresqlRole) (string, error)
+	GetRoles(ctx context.Context, postgresUid string) ([]*types.PostgresqlRole, error)
+	DeleteRole(ctx context.Context, uid string) error
+	UpdateRole(ctx context.Context, postgresRole *types.PostgresqlRole) (*types.PostgresqlRole, error)
+
+	CreateReference(ctx context.Context, fromTableUid, toTableUid string, reference *types.PostgresqlReference) error
 }
 
 type ConnectionRepository interface {
@@ -85,10 +136,73 @@ type ConnectionRepository interface {
 	Delete(ctx context.Context, fromID, toID *types.ConnNode, connType types.ConnType) error
 }
 
-// type TeamRepository interface {
-// 	Create(team *types.Team) error
-// }
+type FlowRepository interface {
+	GetKafkaEndpoints(ctx context.Context) ([]*types.FlowEndpoint, error)
+	GetRabbitEndpoints(ctx context.Context) ([]*types.FlowEndpoint, error)
+	Create(ctx context.Context, flow *types.Flow) error
+	DeleteAll(ctx context.Context) error
+}
+
+type TeamRepository interface {
+	Create(ctx context.Context, team *types.Team) (string, error)
+	Get(ctx context.Context, name string) (*types.Team, error)
+	GetByService(ctx context.Context, serviceUid string) ([]*types.Team, error)
+	Delete(ctx context.Context, uid string) error
+	Update(ctx context.Context, team *types.Team) (*types.Team, error)
+
+	CreateDev(ctx context.Context, dev *types.Dev) (string, error)
+	GetDev(ctx context.Context, key string) (*types.Dev, error)
+	DeleteDev(ctx context.Context, uid string) error
+	UpdateDev(ctx context.Context, dev *types.Dev) (*types.Dev, error)
+}
+
+type PipelineRepository interface {
+	Create(ctx context.Context, pipeline *types.Pipeline) (string, error)
+	Get(ctx context.Context, serviceUid string) ([]*types.Pipeline, error)
+	Delete(ctx context.Context, uid string) error
+	Update(ctx context.Context, pipeline *types.Pipeline) (*types.Pipeline, error)
+
+	CreateJob(ctx context.Context, pipelineJob *types.PipelineJob) (string, error)
+	GetJobs(ctx context.Context, pipelineUid string) ([]*types.PipelineJob, error)
+	DeleteJob(ctx context.Context, uid string) error
+	UpdateJob(ctx context.Context, pipelineJob *types.PipelineJob) (*types.PipelineJob, error)
+
+	CreateTemplate(ctx context.Context, pipelineTemplate *types.PipelineTemplate) (string, error)
+	GetTemplate(ctx context.Context, name string) (*types.PipelineTemplate, error)
+	DeleteTemplate(ctx context.Context, uid string) error
+	UpdateTemplate(ctx context.Context, pipelineTemplate *types.PipelineTemplate) (*types.PipelineTemplate, error)
+}
 
-// type PipelineRepository interface {
-// 	Create(pipeline *types.Pipeline) error
-// }
+type SharingRepository interface {
+	GetTableUsages(ctx context.Context) ([]*types.TableUsage, error)
+	Set(ctx context.Context, resource *types.SharedResource) error
+	ResetAll(ctx context.Context) error
+}
+
+type KubernetesRepository interface {
+	CreateDeployment(ctx context.Context, deployment *types.Deployment) (string, error)
+	GetDeployments(ctx context.Context, serviceUid string) ([]*types.Deployment, error)
+	DeleteDeployment(ctx context.Context, uid string) error
+	UpdateDeployment(ctx context.Context, deployment *types.Deployment) (*types.Deployment, error)
+
+	CreateCronJob(ctx context.Context, cronJob *types.CronJob) (string, error)
+	GetCronJobs(ctx context.Context, serviceUid string) ([]*types.CronJob, error)
+	DeleteCronJob(ctx context.Context, uid string) error
+	UpdateCronJob(ctx context.Context, cronJob *types.CronJob) (*types.CronJob, error)
+
+	CreateJob(ctx context.Context, job *types.KubernetesJob) (string, error)
+	GetJobs(ctx context.Context, serviceUid string) ([]*types.KubernetesJob, error)
+	DeleteJob(ctx context.Context, uid string) error
+	UpdateJob(ctx context.Context, job *types.KubernetesJob) (*types.KubernetesJob, error)
+
+	CreateService(ctx context.Context, service *types.KubernetesService) (string, error)
+	GetServices(ctx context.Context, serviceUid string) ([]*types.KubernetesService, error)
+	DeleteService(ctx context.Context, uid string) error
+	UpdateService(ctx context.Context, service *types.KubernetesService) (*types.KubernetesService, error)
+
+	CreateIngress(ctx context.Context, ingress *types.Ingress) (string, error)
+	GetIngresses(ctx context.Context, serviceUid string) ([]*types.Ingress, error)
+	DeleteIngress(ctx context.Context, uid string) error
+	UpdateIngress(ctx context.Context, ingress *types.Ingress) (*types.Ingress, error)
+	GetServiceByIngressHost(ctx context.Context, host string) (string, error)
+}
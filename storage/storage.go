@@ -6,11 +6,14 @@ type Storage interface {
 	// Reconnect(ctx context.Context) error
 	Disconnect(ctx context.Context) error
 	Service() ServiceRepository
-	// Team() TeamRepository
-	// Pipeline() PipelineRepository
+	Team() TeamRepository
+	Pipeline() PipelineRepository
 	Kafka() KafkaRepository
 	Redis() RedisRepository
 	RabbitMQ() RabbitMQRepository
 	Postgres() PostgresRepository
 	Connection() ConnectionRepository
+	Flow() FlowRepository
+	Sharing() SharingRepository
+	Kubernetes() KubernetesRepository
 }
@@ -8,5 +8,14 @@ type (
 		Postgresqls   []*Postgresql
 		RabbitMQs     []*RabbitMQ
 		OtherServices []*Service
+
+		Deployments        []*Deployment
+		CronJobs           []*CronJob
+		KubernetesJobs     []*KubernetesJob
+		KubernetesServices []*KubernetesService
+		Ingresses          []*Ingress
+
+		Pipeline *Pipeline
+		Teams    []*Team
 	}
-)
\ No newline at end of file
+)
@@ -1,8 +1,11 @@
 package types
 
 type ServiceToPqTableConn struct {
-	From *Service
-	To   *PostgresqlTable
+	From   *Service
+	To     *PostgresqlTable
+	Scheme *string
+	Read   *bool
+	Write  *bool
 }
 
 type ServiceToKubernetesJobConn struct {
@@ -1,10 +1,14 @@
 package types
 
 type Kafka struct {
-	Name   *string
-	Host   *string
-	Port   *int64
-	Queues []*KafkaQueue
+	Name    *string
+	Brokers []*KafkaBroker
+	Queues  []*KafkaQueue
+}
+
+type KafkaBroker struct {
+	Host *string
+	Port *int64
 }
 
 type KafkaQueue struct {
@@ -1,26 +1,41 @@
 package types
 
 type KubernetesJob struct {
-	Name *string
-	Link *string
+	Name      *string
+	Link      *string
+	Namespace *string
+	Images    []string
 }
 
 type Deployment struct {
-	Name *string
-	Link *string
+	Name      *string
+	Link      *string
+	Namespace *string
+	Replicas  *int64
+	Images    []string
+	Ports     []int64
 }
 
 type CronJob struct {
-	Name *string
-	Link *string
+	Name      *string
+	Link      *string
+	Namespace *string
+	Schedule  *string
+	Images    []string
 }
 
 type KubernetesService struct {
-	Name *string
-	Link *string
+	Name      *string
+	Link      *string
+	Namespace *string
+	Type      *string
+	Ports     []int64
 }
 
 type Ingress struct {
-	Name *string
-	Link *string
+	Name      *string
+	Link      *string
+	Namespace *string
+	Hosts     []string
+	Backends  []string
 }
@@ -1,12 +1,26 @@
 package types
 
 type Pipeline struct {
-	Name *string
-	Link *string
-	Jobs []*PipelineJob
+	Name      *string
+	Link      *string
+	Stages    []string
+	Jobs      []*PipelineJob
+	Templates []*PipelineTemplate
 }
 
 type PipelineJob struct {
+	Name           *string
+	Link           *string
+	Stage          *string
+	Image          *string
+	Environment    *string
+	Template       *string
+	Deploys        *bool
+	Migrates       *bool
+	PublishesImage *bool
+}
+
+type PipelineTemplate struct {
 	Name *string
 	Link *string
 }
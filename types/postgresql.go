@@ -5,6 +5,13 @@ type Postgresql struct {
 	Port      *int64
 	Databases []*PostgresqlDB
 	User      *string
+	Roles     []*PostgresqlRole
+}
+
+type PostgresqlRole struct {
+	Name     *string
+	Login    *bool
+	MemberOf []string
 }
 
 type PostgresqlDB struct {
@@ -12,16 +19,88 @@ type PostgresqlDB struct {
 	Owner         *string
 	ForMigrations *bool
 	Schemes       []*PostgresqlScheme
+	Extensions    []*PostgresqlExtension
+	Usages        []*ServiceToPqTableConn
 }
 
-type PostgresqlScheme struct {
+type PostgresqlExtension struct {
 	Name   *string
-	Owner  *string
-	Tables []*PostgresqlTable
+	Scheme *string
+}
+
+type PostgresqlScheme struct {
+	Name      *string
+	Owner     *string
+	Tables    []*PostgresqlTable
+	Functions []*PostgresqlFunction
+	Enums     []*PostgresqlEnum
+	Views     []*PostgresqlView
+	Sequences []*PostgresqlSequence
+}
+
+type PostgresqlSequence struct {
+	Name *string
 }
 
 type PostgresqlTable struct {
-	Name  *string
-	Owner *string
-	Type  *string
+	Name        *string
+	Owner       *string
+	Type        *string
+	Migrated    *bool
+	Columns     []*PostgresqlColumn
+	Indexes     []*PostgresqlIndex
+	Triggers    []*PostgresqlTrigger
+	ForeignKeys []*PostgresqlForeignKey
+	Grants      []*PostgresqlGrant
+}
+
+type PostgresqlGrant struct {
+	Role       *string
+	Privileges []string
+}
+
+type PostgresqlForeignKey struct {
+	Name       *string
+	Columns    []string
+	RefScheme  *string
+	RefTable   *string
+	RefColumns []string
+}
+
+type PostgresqlColumn struct {
+	Name        *string
+	Type        *string
+	Constraints []string
+}
+
+type PostgresqlIndex struct {
+	Name    *string
+	Columns []string
+	Unique  *bool
+}
+
+type PostgresqlTrigger struct {
+	Name     *string
+	Function *string
+}
+
+type PostgresqlFunction struct {
+	Name *string
+	Args []string
+}
+
+type PostgresqlEnum struct {
+	Name   *string
+	Values []string
+}
+
+type PostgresqlView struct {
+	Name         *string
+	Materialized *bool
+	Reads        []*PostgresqlRelation
+}
+
+type PostgresqlRelation struct {
+	Scheme *string
+	Name   *string
 }
@@ -8,8 +8,9 @@ type RabbitMQ struct {
 }
 
 type RabbitQueue struct {
-	Name      *string
-	QueueType *string
-	Topic     *string
-	TypeName  *string
+	Name       *string
+	QueueType  *string
+	Exchange   *string
+	RoutingKey *string
+	TypeName   *string
 }
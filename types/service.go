@@ -5,11 +5,14 @@ type Service struct {
 	Link        *string
 	Group       *string
 	FullName    *string
+	Origin      *string
 	MainBranch  *string
 	LatestTag   *string
 	Language    *string
+	Languages   map[string]float64
 	Description *string
 	Status      *string
+	External    *bool
 	Ports       []*Port
 }
 
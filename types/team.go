@@ -6,7 +6,9 @@ type Team struct {
 }
 
 type Dev struct {
-	Name *string
-	Link *string
-	Role *string
+	Name     *string
+	Username *string
+	Email    *string
+	Link     *string
+	Role     *string
 }
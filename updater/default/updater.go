@@ -15,11 +15,18 @@ type (
 		port      string
 	}
 	Response struct {
-		Status string `json:"status"`
+		Status string            `json:"status"`
+		Report *collector.Report `json:"report,omitempty"`
 	}
 	UpdateRequest struct {
 		ReleaseTag string `json:"release_tag"`
 	}
+	SchemaDiffRequest struct {
+		Origin  string `json:"origin"`
+		Project string `json:"project"`
+		FromRef string `json:"from_ref"`
+		ToRef   string `json:"to_ref"`
+	}
 )
 
 func New(collector collector.Collector, port string) (*Updater, error) {
@@ -38,6 +45,7 @@ func New(collector collector.Collector, port string) (*Updater, error) {
 
 func (u *Updater) Start(ctx context.Context) error {
 	http.HandleFunc("/update", u.handleUpdate)
+	http.HandleFunc("/schema-diff", u.handleSchemaDiff)
 
 	return http.ListenAndServe(":"+u.port, nil)
 }
@@ -62,6 +70,7 @@ func (u *Updater) handleUpdate(w http.ResponseWriter, r *http.Request) {
 
 	res := &Response{
 		Status: "ok",
+		Report: u.collector.Report(),
 	}
 
 	if err := json.NewEncoder(w).Encode(res); err != nil {
@@ -69,3 +78,28 @@ func (u *Updater) handleUpdate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+func (u *Updater) handleSchemaDiff(w http.ResponseWriter, r *http.Request) {
+	var req SchemaDiffRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.Project == "" || req.FromRef == "" || req.ToRef == "" {
+		http.Error(w, "project, from_ref and to_ref are required", http.StatusBadRequest)
+		return
+	}
+
+	diff, err := u.collector.SchemaDiff(r.Context(), req.Origin, req.Project, req.FromRef, req.ToRef)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(diff); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}